@@ -16,16 +16,55 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
 // Config defines configuration for file exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Path of the file to write to. Path is relative to current directory.
+	// The path may reference resource attributes as ${attribute.name}, in which case the data of each
+	// resource is written to the file that its attributes resolve to.
 	Path string `mapstructure:"path"`
+
+	// Format is the encoding of the written data, either json (default) or proto.
+	Format string `mapstructure:"format"`
+
+	// Rotation configures when files are rotated. Files are never rotated if it is not set.
+	Rotation *Rotation `mapstructure:"rotation"`
+
+	// MaxOpenFiles is the maximum number of files kept open when the path references resource
+	// attributes. The least recently written file is closed when another one must be opened.
+	// Zero keeps all the files open.
+	MaxOpenFiles int `mapstructure:"max_open_files"`
+}
+
+// Rotation defines when files are rotated and what happens to the rotated files.
+type Rotation struct {
+	// MaxMegabytes is the maximum size of a file before it is rotated. Zero disables size based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the maximum age of a file before it is rotated. Zero disables time based rotation.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxBackups is the maximum number of rotated files to keep. Zero keeps all rotated files.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// Compression is the algorithm rotated files are compressed with, either gzip or zstd.
+	// Rotated files are not compressed if it is empty.
+	Compression string `mapstructure:"compression"`
 }
 
 var _ config.Exporter = (*Config)(nil)
@@ -35,6 +74,40 @@ func (cfg *Config) Validate() error {
 	if cfg.Path == "" {
 		return errors.New("path must be non-empty")
 	}
+	if _, err := parsePathTemplate(cfg.Path); err != nil {
+		return err
+	}
+	if _, ok := marshalers[cfg.Format]; !ok {
+		return fmt.Errorf("format %q is not supported, must be one of %q or %q", cfg.Format, formatTypeJSON, formatTypeProto)
+	}
+	if cfg.MaxOpenFiles < 0 {
+		return errors.New("max_open_files must not be negative")
+	}
+	if cfg.Rotation != nil {
+		return cfg.Rotation.Validate()
+	}
+
+	return nil
+}
 
+// Validate checks if the rotation configuration is valid
+func (r *Rotation) Validate() error {
+	if r.MaxMegabytes < 0 {
+		return errors.New("rotation max_megabytes must not be negative")
+	}
+	if r.Interval < 0 {
+		return errors.New("rotation interval must not be negative")
+	}
+	if r.MaxMegabytes == 0 && r.Interval == 0 {
+		return errors.New("rotation requires max_megabytes or interval to be set")
+	}
+	if r.MaxBackups < 0 {
+		return errors.New("rotation max_backups must not be negative")
+	}
+	switch r.Compression {
+	case "", compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("rotation compression %q is not supported, must be one of %q or %q", r.Compression, compressionGzip, compressionZstd)
+	}
 	return nil
 }
@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,5 +44,95 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
 			Path:             "./filename.json",
+			Format:           formatTypeJSON,
+			MaxOpenFiles:     defaultMaxOpenFiles,
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			Path:             "./data/${service.name}/traces.pb",
+			Format:           formatTypeProto,
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				Interval:     time.Hour,
+				MaxBackups:   3,
+				Compression:  compressionZstd,
+			},
+			MaxOpenFiles: 20,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Path: "./data/${service.name}.json", Format: formatTypeJSON},
+		},
+		{
+			name: "empty path",
+			cfg:  &Config{Format: formatTypeJSON},
+			err:  "path must be non-empty",
+		},
+		{
+			name: "unclosed attribute reference",
+			cfg:  &Config{Path: "./data/${service.name.json", Format: formatTypeJSON},
+			err:  `path "./data/${service.name.json" has an unclosed attribute reference`,
+		},
+		{
+			name: "empty attribute name",
+			cfg:  &Config{Path: "./data/${}.json", Format: formatTypeJSON},
+			err:  `path "./data/${}.json" references an empty attribute name`,
+		},
+		{
+			name: "unsupported format",
+			cfg:  &Config{Path: "./data.csv", Format: "csv"},
+			err:  `format "csv" is not supported, must be one of "json" or "proto"`,
+		},
+		{
+			name: "rotation without limits",
+			cfg:  &Config{Path: "./data.json", Format: formatTypeJSON, Rotation: &Rotation{MaxBackups: 1}},
+			err:  "rotation requires max_megabytes or interval to be set",
+		},
+		{
+			name: "negative max_megabytes",
+			cfg:  &Config{Path: "./data.json", Format: formatTypeJSON, Rotation: &Rotation{MaxMegabytes: -1}},
+			err:  "rotation max_megabytes must not be negative",
+		},
+		{
+			name: "negative interval",
+			cfg:  &Config{Path: "./data.json", Format: formatTypeJSON, Rotation: &Rotation{Interval: -time.Second}},
+			err:  "rotation interval must not be negative",
+		},
+		{
+			name: "negative max open files",
+			cfg:  &Config{Path: "./data/${service.name}.json", Format: formatTypeJSON, MaxOpenFiles: -1},
+			err:  "max_open_files must not be negative",
+		},
+		{
+			name: "negative max_backups",
+			cfg:  &Config{Path: "./data.json", Format: formatTypeJSON, Rotation: &Rotation{MaxMegabytes: 1, MaxBackups: -1}},
+			err:  "rotation max_backups must not be negative",
+		},
+		{
+			name: "unsupported compression",
+			cfg:  &Config{Path: "./data.json", Format: formatTypeJSON, Rotation: &Rotation{MaxMegabytes: 1, Compression: "lz4"}},
+			err:  `rotation compression "lz4" is not supported, must be one of "gzip" or "zstd"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
 }
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
@@ -29,6 +30,8 @@ const (
 	typeStr = "file"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelAlpha
+
+	defaultMaxOpenFiles = 100
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -44,6 +47,8 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Format:           formatTypeJSON,
+		MaxOpenFiles:     defaultMaxOpenFiles,
 	}
 }
 
@@ -52,9 +57,10 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
-	})
+	fe, err := getOrCreateFileExporter(cfg, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewTracesExporterWithContext(
 		ctx,
 		set,
@@ -70,9 +76,10 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
-	})
+	fe, err := getOrCreateFileExporter(cfg, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewMetricsExporterWithContext(
 		ctx,
 		set,
@@ -88,9 +95,10 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
-	})
+	fe, err := getOrCreateFileExporter(cfg, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewLogsExporterWithContext(
 		ctx,
 		set,
@@ -101,6 +109,17 @@ func createLogsExporter(
 	)
 }
 
+// getOrCreateFileExporter returns the File exporter for cfg, creating it if it does not exist yet.
+func getOrCreateFileExporter(cfg config.Exporter, logger *zap.Logger) (*sharedcomponent.SharedComponent, error) {
+	fe, err := newFileExporter(cfg.(*Config), logger)
+	if err != nil {
+		return nil, err
+	}
+	return exporters.GetOrAdd(cfg, func() component.Component {
+		return fe
+	}), nil
+}
+
 // This is the map of already created File exporters for particular configurations.
 // We maintain this map because the Factory is asked trace and metric receivers separately
 // when it gets CreateTracesReceiver() and CreateMetricsReceiver() but they must not
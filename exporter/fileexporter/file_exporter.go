@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
@@ -25,19 +26,54 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 )
 
-// Marshaler configuration used for marhsaling Protobuf to JSON.
-var tracesMarshaler = ptrace.NewJSONMarshaler()
-var metricsMarshaler = pmetric.NewJSONMarshaler()
-var logsMarshaler = plog.NewJSONMarshaler()
-
-// fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// fileExporter is the implementation of file exporter that writes telemetry data to files
+// in Protobuf-JSON or Protobuf format.
 type fileExporter struct {
-	path  string
-	file  io.WriteCloser
+	path         *pathTemplate
+	marshaler    *marshaler
+	rotation     *Rotation
+	maxOpenFiles int
+	logger       *zap.Logger
+
 	mutex sync.Mutex
+	// writers holds the open file of each resolved path.
+	writers map[string]*openFile
+	// writes counts the writes, to order the open files by their last write.
+	writes uint64
+	// opened holds the paths that were already opened, which are appended to instead of
+	// being truncated when they are opened again.
+	opened map[string]struct{}
+}
+
+// openFile is a file kept open by the exporter.
+type openFile struct {
+	io.WriteCloser
+	// lastWrite is the number of the last write to the file.
+	lastWrite uint64
+}
+
+func newFileExporter(cfg *Config, logger *zap.Logger) (*fileExporter, error) {
+	path, err := parsePathTemplate(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := marshalers[cfg.Format]
+	if !ok {
+		m = marshalers[formatTypeJSON]
+	}
+	return &fileExporter{
+		path:         path,
+		marshaler:    m,
+		rotation:     cfg.Rotation,
+		maxOpenFiles: cfg.MaxOpenFiles,
+		logger:       logger,
+		writers:      map[string]*openFile{},
+		opened:       map[string]struct{}{},
+	}, nil
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -45,49 +81,187 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	buf, err := tracesMarshaler.MarshalTraces(td)
-	if err != nil {
-		return err
+	var errs error
+	for path, traces := range splitTraces(td, e.path) {
+		buf, err := e.marshaler.marshalTraces(traces)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		errs = multierr.Append(errs, e.export(path, buf))
 	}
-	return exportMessageAsLine(e, buf)
+	return errs
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
-	buf, err := metricsMarshaler.MarshalMetrics(md)
-	if err != nil {
-		return err
+	var errs error
+	for path, metrics := range splitMetrics(md, e.path) {
+		buf, err := e.marshaler.marshalMetrics(metrics)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		errs = multierr.Append(errs, e.export(path, buf))
 	}
-	return exportMessageAsLine(e, buf)
+	return errs
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	buf, err := logsMarshaler.MarshalLogs(ld)
-	if err != nil {
-		return err
+	var errs error
+	for path, logs := range splitLogs(ld, e.path) {
+		buf, err := e.marshaler.marshalLogs(logs)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		errs = multierr.Append(errs, e.export(path, buf))
 	}
-	return exportMessageAsLine(e, buf)
+	return errs
 }
 
-func exportMessageAsLine(e *fileExporter, buf []byte) error {
+// export writes a framed message to the file at path, opening the file if necessary.
+func (e *fileExporter) export(path string, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	w, err := e.writer(path)
+	if err != nil {
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
-		return err
+	e.writes++
+	w.lastWrite = e.writes
+	_, err = w.Write(buf)
+	return err
+}
+
+// writer returns the open file at path, opening it if necessary. The caller must hold the mutex.
+func (e *fileExporter) writer(path string) (*openFile, error) {
+	if w, ok := e.writers[path]; ok {
+		return w, nil
+	}
+	if e.maxOpenFiles > 0 && len(e.writers) >= e.maxOpenFiles {
+		e.closeLeastRecentlyWritten()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
 	}
-	return nil
+	var w io.WriteCloser
+	var err error
+	if e.rotation != nil {
+		w, err = newRotatingFile(path, e.rotation, e.logger)
+	} else {
+		// A file is only truncated the first time it is opened, and not when it is opened again
+		// after being closed because of the max_open_files limit.
+		flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+		if _, ok := e.opened[path]; ok {
+			flags = os.O_RDWR | os.O_CREATE | os.O_APPEND
+		}
+		w, err = os.OpenFile(path, flags, 0600)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.opened[path] = struct{}{}
+	f := &openFile{WriteCloser: w}
+	e.writers[path] = f
+	return f, nil
+}
+
+// closeLeastRecentlyWritten closes the open file that was written the least recently.
+// The caller must hold the mutex.
+func (e *fileExporter) closeLeastRecentlyWritten() {
+	var oldest string
+	for path, w := range e.writers {
+		if oldest == "" || w.lastWrite < e.writers[oldest].lastWrite {
+			oldest = path
+		}
+	}
+	if err := e.writers[oldest].Close(); err != nil {
+		e.logger.Warn("Failed to close file", zap.String("path", oldest), zap.Error(err))
+	}
+	delete(e.writers, oldest)
 }
 
+// Start opens the file to write to. If the path references resource attributes, files are
+// opened once data resolving to them is received instead.
 func (e *fileExporter) Start(context.Context, component.Host) error {
-	var err error
-	e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if e.path.hasAttributes() {
+		return nil
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	_, err := e.writer(e.path.literals[0])
 	return err
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
-	return e.file.Close()
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for path, w := range e.writers {
+		errs = multierr.Append(errs, w.Close())
+		delete(e.writers, path)
+	}
+	return errs
+}
+
+// splitTraces groups the resources of td by the path their attributes resolve to.
+func splitTraces(td ptrace.Traces, path *pathTemplate) map[string]ptrace.Traces {
+	if !path.hasAttributes() {
+		return map[string]ptrace.Traces{path.literals[0]: td}
+	}
+	split := map[string]ptrace.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		p := path.resolve(rs.Resource())
+		traces, ok := split[p]
+		if !ok {
+			traces = ptrace.NewTraces()
+			split[p] = traces
+		}
+		rs.CopyTo(traces.ResourceSpans().AppendEmpty())
+	}
+	return split
+}
+
+// splitMetrics groups the resources of md by the path their attributes resolve to.
+func splitMetrics(md pmetric.Metrics, path *pathTemplate) map[string]pmetric.Metrics {
+	if !path.hasAttributes() {
+		return map[string]pmetric.Metrics{path.literals[0]: md}
+	}
+	split := map[string]pmetric.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p := path.resolve(rm.Resource())
+		metrics, ok := split[p]
+		if !ok {
+			metrics = pmetric.NewMetrics()
+			split[p] = metrics
+		}
+		rm.CopyTo(metrics.ResourceMetrics().AppendEmpty())
+	}
+	return split
+}
+
+// splitLogs groups the resources of ld by the path their attributes resolve to.
+func splitLogs(ld plog.Logs, path *pathTemplate) map[string]plog.Logs {
+	if !path.hasAttributes() {
+		return map[string]plog.Logs{path.literals[0]: ld}
+	}
+	split := map[string]plog.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		p := path.resolve(rl.Resource())
+		logs, ok := split[p]
+		if !ok {
+			logs = plog.NewLogs()
+			split[p] = logs
+		}
+		rl.CopyTo(logs.ResourceLogs().AppendEmpty())
+	}
+	return split
 }
@@ -14,10 +14,14 @@
 package fileexporter
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,13 +29,14 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
 func TestFileTracesExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
-	require.NotNil(t, fe)
+	path := tempFileName(t)
+	fe := newTestFileExporter(t, &Config{Path: path, Format: formatTypeJSON})
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
 	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
@@ -39,7 +44,7 @@ func TestFileTracesExporter(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 
 	unmarshaler := ptrace.NewJSONUnmarshaler()
-	buf, err := os.ReadFile(fe.path)
+	buf, err := os.ReadFile(path)
 	assert.NoError(t, err)
 	got, err := unmarshaler.UnmarshalTraces(buf)
 	assert.NoError(t, err)
@@ -47,9 +52,8 @@ func TestFileTracesExporter(t *testing.T) {
 }
 
 func TestFileTracesExporterError(t *testing.T) {
-	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
-	require.NotNil(t, fe)
+	fe := newTestFileExporter(t, &Config{Path: "unused.json", Format: formatTypeJSON})
+	fe.writers["unused.json"] = &openFile{WriteCloser: &errorWriter{}}
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -58,8 +62,8 @@ func TestFileTracesExporterError(t *testing.T) {
 }
 
 func TestFileMetricsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
-	require.NotNil(t, fe)
+	path := tempFileName(t)
+	fe := newTestFileExporter(t, &Config{Path: path, Format: formatTypeJSON})
 
 	md := testdata.GenerateMetricsTwoMetrics()
 	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
@@ -67,7 +71,7 @@ func TestFileMetricsExporter(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 
 	unmarshaler := pmetric.NewJSONUnmarshaler()
-	buf, err := os.ReadFile(fe.path)
+	buf, err := os.ReadFile(path)
 	assert.NoError(t, err)
 	got, err := unmarshaler.UnmarshalMetrics(buf)
 	assert.NoError(t, err)
@@ -75,9 +79,8 @@ func TestFileMetricsExporter(t *testing.T) {
 }
 
 func TestFileMetricsExporterError(t *testing.T) {
-	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
-	require.NotNil(t, fe)
+	fe := newTestFileExporter(t, &Config{Path: "unused.json", Format: formatTypeJSON})
+	fe.writers["unused.json"] = &openFile{WriteCloser: &errorWriter{}}
 
 	md := testdata.GenerateMetricsTwoMetrics()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -86,8 +89,8 @@ func TestFileMetricsExporterError(t *testing.T) {
 }
 
 func TestFileLogsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
-	require.NotNil(t, fe)
+	path := tempFileName(t)
+	fe := newTestFileExporter(t, &Config{Path: path, Format: formatTypeJSON})
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
 	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
@@ -95,7 +98,7 @@ func TestFileLogsExporter(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 
 	unmarshaler := plog.NewJSONUnmarshaler()
-	buf, err := os.ReadFile(fe.path)
+	buf, err := os.ReadFile(path)
 	assert.NoError(t, err)
 	got, err := unmarshaler.UnmarshalLogs(buf)
 	assert.NoError(t, err)
@@ -103,9 +106,8 @@ func TestFileLogsExporter(t *testing.T) {
 }
 
 func TestFileLogsExporterErrors(t *testing.T) {
-	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
-	require.NotNil(t, fe)
+	fe := newTestFileExporter(t, &Config{Path: "unused.json", Format: formatTypeJSON})
+	fe.writers["unused.json"] = &openFile{WriteCloser: &errorWriter{}}
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -113,6 +115,121 @@ func TestFileLogsExporterErrors(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 }
 
+func TestFileExporterProtoFormat(t *testing.T) {
+	path := tempFileName(t)
+	fe := newTestFileExporter(t, &Config{Path: path, Format: formatTypeProto})
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	unmarshaler := ptrace.NewProtoUnmarshaler()
+	for i := 0; i < 2; i++ {
+		require.GreaterOrEqual(t, len(buf), 4)
+		size := binary.BigEndian.Uint32(buf)
+		require.GreaterOrEqual(t, len(buf), 4+int(size))
+		got, err := unmarshaler.UnmarshalTraces(buf[4 : 4+size])
+		require.NoError(t, err)
+		assert.EqualValues(t, td, got)
+		buf = buf[4+size:]
+	}
+	assert.Empty(t, buf)
+}
+
+func TestFileExporterPathTemplate(t *testing.T) {
+	dir := t.TempDir()
+	fe := newTestFileExporter(t, &Config{Path: filepath.Join(dir, "${service.name}", "logs.json"), Format: formatTypeJSON})
+
+	ld := plog.NewLogs()
+	for _, service := range []string{"frontend", "backend", "frontend", ""} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if service != "" {
+			rl.Resource().Attributes().InsertString("service.name", service)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal(service)
+	}
+
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	unmarshaler := plog.NewJSONUnmarshaler()
+	for service, want := range map[string][]string{
+		"frontend": {"frontend", "frontend"},
+		"backend":  {"backend"},
+		"_":        {""},
+	} {
+		buf, err := os.ReadFile(filepath.Join(dir, service, "logs.json"))
+		require.NoError(t, err)
+		got, err := unmarshaler.UnmarshalLogs(buf)
+		require.NoError(t, err)
+
+		var bodies []string
+		for i := 0; i < got.ResourceLogs().Len(); i++ {
+			bodies = append(bodies, got.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+		}
+		assert.Equal(t, want, bodies, service)
+	}
+}
+
+func TestFileExporterRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.json")
+	fe := newTestFileExporter(t, &Config{
+		Path:     path,
+		Format:   formatTypeJSON,
+		Rotation: &Rotation{Interval: time.Hour, MaxBackups: 1, Compression: compressionGzip},
+	})
+
+	now := time.Date(2022, 8, 20, 10, 0, 0, 0, time.UTC)
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	fe.writers[path].WriteCloser.(*rotatingFile).now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+		now = now.Add(time.Hour)
+	}
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"traces-2022-08-20T12-00-00.000.json.gz", "traces.json"}, dirEntries(t, dir))
+}
+
+func TestFileExporterMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	fe := newTestFileExporter(t, &Config{Path: filepath.Join(dir, "${service.name}.json"), Format: formatTypeJSON, MaxOpenFiles: 2})
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, service := range []string{"a", "b", "a", "c", "a", "b"} {
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal(service)
+		assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+		assert.LessOrEqual(t, len(fe.writers), 2)
+	}
+	// "b" was the least recently written file when "c" was opened
+	assert.Len(t, fe.writers, 2)
+	assert.Contains(t, fe.writers, filepath.Join(dir, "a.json"))
+	assert.Contains(t, fe.writers, filepath.Join(dir, "b.json"))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	for service, want := range map[string]int{"a": 3, "b": 2, "c": 1} {
+		buf, err := os.ReadFile(filepath.Join(dir, service+".json"))
+		require.NoError(t, err)
+		assert.Equal(t, want, bytes.Count(buf, []byte("\n")), "a file opened again must be appended to")
+	}
+}
+
+func newTestFileExporter(t *testing.T, cfg *Config) *fileExporter {
+	fe, err := newFileExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return fe
+}
+
 // tempFileName provides a temporary file name for testing.
 func tempFileName(t *testing.T) string {
 	tmpfile, err := os.CreateTemp("", "*.json")
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"encoding/binary"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// marshaler encodes telemetry data in one of the supported formats. Each encoded message is framed,
+// so that consecutive messages written to the same file can be told apart.
+type marshaler struct {
+	traces  ptrace.Marshaler
+	metrics pmetric.Marshaler
+	logs    plog.Marshaler
+	frame   func([]byte) []byte
+}
+
+// marshalers holds the marshaler of each supported format.
+var marshalers = map[string]*marshaler{
+	formatTypeJSON: {
+		traces:  ptrace.NewJSONMarshaler(),
+		metrics: pmetric.NewJSONMarshaler(),
+		logs:    plog.NewJSONMarshaler(),
+		frame:   jsonLine,
+	},
+	formatTypeProto: {
+		traces:  ptrace.NewProtoMarshaler(),
+		metrics: pmetric.NewProtoMarshaler(),
+		logs:    plog.NewProtoMarshaler(),
+		frame:   lengthPrefixed,
+	},
+}
+
+func (m *marshaler) marshalTraces(td ptrace.Traces) ([]byte, error) {
+	buf, err := m.traces.MarshalTraces(td)
+	if err != nil {
+		return nil, err
+	}
+	return m.frame(buf), nil
+}
+
+func (m *marshaler) marshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	buf, err := m.metrics.MarshalMetrics(md)
+	if err != nil {
+		return nil, err
+	}
+	return m.frame(buf), nil
+}
+
+func (m *marshaler) marshalLogs(ld plog.Logs) ([]byte, error) {
+	buf, err := m.logs.MarshalLogs(ld)
+	if err != nil {
+		return nil, err
+	}
+	return m.frame(buf), nil
+}
+
+// jsonLine terminates a JSON message with a newline, so the written file contains one message per line.
+func jsonLine(buf []byte) []byte {
+	return append(buf, '\n')
+}
+
+// lengthPrefixed prefixes a protobuf message with its size as a 4 byte big endian integer.
+func lengthPrefixed(buf []byte) []byte {
+	framed := make([]byte, 4+len(buf))
+	binary.BigEndian.PutUint32(framed, uint32(len(buf)))
+	copy(framed[4:], buf)
+	return framed
+}
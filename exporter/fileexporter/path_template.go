@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// emptyPathSegment replaces resource attribute values that are missing, empty or would
+// otherwise refer to a different directory.
+const emptyPathSegment = "_"
+
+// pathTemplate is a file path that may reference resource attributes as ${attribute.name}.
+type pathTemplate struct {
+	// literals surround the referenced attributes, so there is always one more literal than attributes.
+	literals   []string
+	attributes []string
+}
+
+func parsePathTemplate(path string) (*pathTemplate, error) {
+	t := &pathTemplate{}
+	rest := path
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			t.literals = append(t.literals, rest)
+			return t, nil
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("path %q has an unclosed attribute reference", path)
+		}
+		attribute := rest[start+2 : start+end]
+		if attribute == "" {
+			return nil, fmt.Errorf("path %q references an empty attribute name", path)
+		}
+		t.literals = append(t.literals, rest[:start])
+		t.attributes = append(t.attributes, attribute)
+		rest = rest[start+end+1:]
+	}
+}
+
+// hasAttributes returns whether the path references any resource attributes. If it does not,
+// all data is written to a single file.
+func (t *pathTemplate) hasAttributes() bool {
+	return len(t.attributes) > 0
+}
+
+// resolve returns the path of the file the data of resource is written to.
+func (t *pathTemplate) resolve(resource pcommon.Resource) string {
+	var b strings.Builder
+	b.WriteString(t.literals[0])
+	for i, attribute := range t.attributes {
+		value := ""
+		if v, ok := resource.Attributes().Get(attribute); ok {
+			value = v.AsString()
+		}
+		b.WriteString(sanitizePathSegment(value))
+		b.WriteString(t.literals[i+1])
+	}
+	return b.String()
+}
+
+// sanitizePathSegment makes sure an attribute value cannot escape the directory it is used in.
+func sanitizePathSegment(value string) string {
+	switch value {
+	case "", ".", "..":
+		return emptyPathSegment
+	}
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(value)
+}
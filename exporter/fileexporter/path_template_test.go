@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPathTemplate(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	resource.Attributes().InsertString("service.namespace", "shop")
+	resource.Attributes().InsertInt("pid", 42)
+	resource.Attributes().InsertString("empty", "")
+	resource.Attributes().InsertString("parent", "..")
+	resource.Attributes().InsertString("nested", "a/b\\c")
+
+	tests := []struct {
+		path          string
+		want          string
+		hasAttributes bool
+	}{
+		{
+			path: "/data/traces.json",
+			want: "/data/traces.json",
+		},
+		{
+			path:          "/data/${service.name}/traces.json",
+			want:          "/data/checkout/traces.json",
+			hasAttributes: true,
+		},
+		{
+			path:          "${service.namespace}-${service.name}-${pid}.json",
+			want:          "shop-checkout-42.json",
+			hasAttributes: true,
+		},
+		{
+			path:          "/data/${missing}/${empty}/${parent}/traces.json",
+			want:          "/data/_/_/_/traces.json",
+			hasAttributes: true,
+		},
+		{
+			path:          "/data/${nested}.json",
+			want:          "/data/a_b_c.json",
+			hasAttributes: true,
+		},
+		{
+			path: "/data/$service.name/{traces}.json",
+			want: "/data/$service.name/{traces}.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			template, err := parsePathTemplate(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.hasAttributes, template.hasAttributes())
+			assert.Equal(t, tt.want, template.resolve(resource))
+		})
+	}
+}
+
+func TestPathTemplateErrors(t *testing.T) {
+	_, err := parsePathTemplate("/data/${service.name/traces.json")
+	assert.EqualError(t, err, `path "/data/${service.name/traces.json" has an unclosed attribute reference`)
+
+	_, err = parsePathTemplate("/data/${}/traces.json")
+	assert.EqualError(t, err, `path "/data/${}/traces.json" references an empty attribute name`)
+}
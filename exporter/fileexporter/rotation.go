@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	// backupTimeFormat is the format of the rotation time in the name of rotated files.
+	// It sorts lexicographically and does not contain characters that are invalid in file names.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// compressionExtensions holds the extension appended to rotated files by each compression.
+var compressionExtensions = map[string]string{
+	compressionGzip: ".gz",
+	compressionZstd: ".zst",
+}
+
+// rotatingFile is an io.WriteCloser that appends to the file at path and rotates it before a write
+// would exceed the configured size, or once its first write is older than the configured interval.
+// Rotated files are renamed to <name>-<rotation time><extension>, optionally compressed,
+// and the oldest ones are removed once there are more than the configured maximum.
+type rotatingFile struct {
+	path     string
+	rotation *Rotation
+	logger   *zap.Logger
+	now      func() time.Time
+
+	file *os.File
+	size int64
+	// startedAt is the time of the first write to the current file.
+	startedAt time.Time
+}
+
+func newRotatingFile(path string, rotation *Rotation, logger *zap.Logger) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path:     path,
+		rotation: rotation,
+		logger:   logger,
+		now:      time.Now,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return multierr.Append(err, file.Close())
+	}
+	r.file = file
+	r.size = info.Size()
+	r.startedAt = r.now()
+	return nil
+}
+
+// Write writes p to the file, rotating the file first if necessary. A single write is never split
+// across files, so a file may exceed the maximum size if p alone does.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.shouldRotate(len(p)) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size == 0 {
+		r.startedAt = r.now()
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) shouldRotate(n int) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.rotation.MaxMegabytes > 0 && r.size+int64(n) > int64(r.rotation.MaxMegabytes)*megabyte {
+		return true
+	}
+	return r.rotation.Interval > 0 && r.now().Sub(r.startedAt) >= r.rotation.Interval
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	backup := r.backupName(r.now())
+	renameErr := os.Rename(r.path, backup)
+	if err := r.open(); err != nil {
+		return multierr.Append(renameErr, err)
+	}
+	if renameErr != nil {
+		return renameErr
+	}
+
+	// The data has been written successfully at this point, failing to compress or remove
+	// rotated files must therefore not fail the export.
+	if r.rotation.Compression != "" {
+		if err := compressFile(backup, r.rotation.Compression); err != nil {
+			r.logger.Warn("Failed to compress rotated file", zap.String("path", backup), zap.Error(err))
+		}
+	}
+	if r.rotation.MaxBackups > 0 {
+		if err := r.removeOldBackups(); err != nil {
+			r.logger.Warn("Failed to remove old rotated files", zap.String("path", r.path), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Close closes the file without rotating it.
+func (r *rotatingFile) Close() error {
+	return r.file.Close()
+}
+
+func (r *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(r.path)
+	return strings.TrimSuffix(r.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// removeOldBackups removes the oldest rotated files until at most MaxBackups are left.
+func (r *rotatingFile) removeOldBackups() error {
+	dir := filepath.Dir(r.path)
+	ext := filepath.Ext(r.path)
+	prefix := strings.TrimSuffix(filepath.Base(r.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isBackup(entry.Name(), prefix, ext) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	if len(backups) <= r.rotation.MaxBackups {
+		return nil
+	}
+
+	// The rotation time sorts lexicographically, so the newest backups sort last.
+	sort.Strings(backups)
+	var errs error
+	for _, backup := range backups[:len(backups)-r.rotation.MaxBackups] {
+		errs = multierr.Append(errs, os.Remove(filepath.Join(dir, backup)))
+	}
+	return errs
+}
+
+// isBackup returns whether name is the name of a file rotated by a rotatingFile,
+// i.e. <prefix><rotation time><ext> with an optional compression extension.
+func isBackup(name string, prefix string, ext string) bool {
+	if !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+len(backupTimeFormat) {
+		return false
+	}
+	timestamp := name[len(prefix) : len(prefix)+len(backupTimeFormat)]
+	if _, err := time.Parse(backupTimeFormat, timestamp); err != nil {
+		return false
+	}
+	switch name[len(prefix)+len(backupTimeFormat):] {
+	case ext, ext + compressionExtensions[compressionGzip], ext + compressionExtensions[compressionZstd]:
+		return true
+	}
+	return false
+}
+
+// compressFile replaces the file at path by a copy compressed with compression.
+func compressFile(path string, compression string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	compressed := path + compressionExtensions[compression]
+	dst, err := os.OpenFile(compressed, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(compressed)
+		}
+	}()
+
+	var writer io.WriteCloser
+	switch compression {
+	case compressionGzip:
+		writer = gzip.NewWriter(dst)
+	case compressionZstd:
+		if writer, err = zstd.NewWriter(dst); err != nil {
+			return multierr.Append(err, dst.Close())
+		}
+	default:
+		return multierr.Append(fmt.Errorf("unsupported compression %q", compression), dst.Close())
+	}
+
+	if _, err = io.Copy(writer, src); err != nil {
+		return multierr.Combine(err, writer.Close(), dst.Close())
+	}
+	if err = writer.Close(); err != nil {
+		return multierr.Append(err, dst.Close())
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	r, now := newTestRotatingFile(t, path, &Rotation{MaxMegabytes: 1})
+
+	chunk := make([]byte, megabyte/2)
+	for i := 0; i < 3; i++ {
+		_, err := r.Write(chunk)
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+	}
+	// A single write larger than the maximum size is not split.
+	_, err := r.Write(make([]byte, 2*megabyte))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, []string{
+		"data-2022-08-20T10-00-02.000.json",
+		"data-2022-08-20T10-00-03.000.json",
+		"data.json",
+	}, dirEntries(t, dir))
+	assertFileSize(t, filepath.Join(dir, "data-2022-08-20T10-00-02.000.json"), megabyte)
+	assertFileSize(t, filepath.Join(dir, "data-2022-08-20T10-00-03.000.json"), megabyte/2)
+	assertFileSize(t, path, 2*megabyte)
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	r, now := newTestRotatingFile(t, path, &Rotation{Interval: time.Minute})
+
+	// The age of a file starts with its first write.
+	*now = now.Add(time.Hour)
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		_, err := r.Write([]byte(line))
+		require.NoError(t, err)
+		*now = now.Add(40 * time.Second)
+	}
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, []string{"data-2022-08-20T11-01-20.000.json", "data.json"}, dirEntries(t, dir))
+	assertFileContent(t, filepath.Join(dir, "data-2022-08-20T11-01-20.000.json"), "a\nb\n")
+	assertFileContent(t, path, "c\n")
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(path, []byte("a\n"), 0600))
+
+	r, _ := newTestRotatingFile(t, path, &Rotation{MaxMegabytes: 1})
+	_, err := r.Write([]byte("b\n"))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	assertFileContent(t, path, "a\nb\n")
+}
+
+func TestRotatingFileMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	// Files that are not backups of path are never removed.
+	unrelated := []string{"data-latest.json", "data.json.bak", "other-2022-08-20T00-00-00.000.json"}
+	for _, name := range unrelated {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+
+	r, now := newTestRotatingFile(t, path, &Rotation{Interval: time.Minute, MaxBackups: 2})
+	for i := 0; i < 5; i++ {
+		_, err := r.Write([]byte("line\n"))
+		require.NoError(t, err)
+		*now = now.Add(time.Minute)
+	}
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, []string{
+		"data-2022-08-20T10-03-00.000.json",
+		"data-2022-08-20T10-04-00.000.json",
+		"data-latest.json",
+		"data.json",
+		"data.json.bak",
+		"other-2022-08-20T00-00-00.000.json",
+	}, dirEntries(t, dir))
+}
+
+func TestRotatingFileCompression(t *testing.T) {
+	tests := []struct {
+		compression string
+		extension   string
+		decompress  func(io.Reader) (io.Reader, error)
+	}{
+		{
+			compression: compressionGzip,
+			extension:   ".gz",
+			decompress: func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			},
+		},
+		{
+			compression: compressionZstd,
+			extension:   ".zst",
+			decompress: func(r io.Reader) (io.Reader, error) {
+				return zstd.NewReader(r)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.compression, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "data.json")
+			r, now := newTestRotatingFile(t, path, &Rotation{Interval: time.Minute, Compression: tt.compression})
+			for _, line := range []string{"a\n", "b\n"} {
+				_, err := r.Write([]byte(line))
+				require.NoError(t, err)
+				*now = now.Add(time.Minute)
+			}
+			require.NoError(t, r.Close())
+
+			backup := "data-2022-08-20T10-01-00.000.json" + tt.extension
+			assert.Equal(t, []string{backup, "data.json"}, dirEntries(t, dir))
+			assertFileContent(t, path, "b\n")
+
+			f, err := os.Open(filepath.Join(dir, backup))
+			require.NoError(t, err)
+			defer f.Close()
+			decompressed, err := tt.decompress(f)
+			require.NoError(t, err)
+			content, err := io.ReadAll(decompressed)
+			require.NoError(t, err)
+			assert.Equal(t, "a\n", string(content))
+		})
+	}
+}
+
+func TestIsBackup(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "data-2022-08-20T10-00-00.000.json", want: true},
+		{name: "data-2022-08-20T10-00-00.000.json.gz", want: true},
+		{name: "data-2022-08-20T10-00-00.000.json.zst", want: true},
+		{name: "data.json"},
+		{name: "data-2022-08-20T10-00-00.000.log"},
+		{name: "data-2022-08-20T10-00-00.000.json.bz2"},
+		{name: "data-2022-13-20T10-00-00.000.json"},
+		{name: "data-latest.json"},
+		{name: "other-2022-08-20T10-00-00.000.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBackup(tt.name, "data-", ".json"))
+		})
+	}
+}
+
+// newTestRotatingFile creates a rotatingFile whose clock starts at 2022-08-20T10:00:00Z and only
+// advances when the returned time is changed.
+func newTestRotatingFile(t *testing.T, path string, rotation *Rotation) (*rotatingFile, *time.Time) {
+	now := time.Date(2022, 8, 20, 10, 0, 0, 0, time.UTC)
+	r, err := newRotatingFile(path, rotation, zap.NewNop())
+	require.NoError(t, err)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func dirEntries(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func assertFileSize(t *testing.T, path string, size int64) {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, size, info.Size(), path)
+}
+
+func assertFileContent(t *testing.T, path string, content string) {
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(buf), path)
+}
@@ -17,6 +17,8 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 type routingKey int
@@ -24,6 +26,9 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricNameRouting
+	streamIDRouting
 )
 
 // Config defines configuration for the exporter.
@@ -43,6 +48,8 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	File   *FileResolver   `mapstructure:"file"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -55,3 +62,17 @@ type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
 	Port     string `mapstructure:"port"`
 }
+
+// FileResolver defines the configuration for the resolver reading the list of backends from a file
+type FileResolver struct {
+	Path string `mapstructure:"path"`
+}
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// Service is the name of the service, optionally followed by its namespace as <name>.<namespace>.
+	Service string  `mapstructure:"service"`
+	Ports   []int32 `mapstructure:"ports"`
+}
@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -63,7 +65,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -84,6 +86,32 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 			return nil, err
 		}
 	}
+	if oCfg.Resolver.File != nil {
+		fileLogger := params.Logger.With(zap.String("resolver", "file"))
+
+		var err error
+		res, err = newFileResolver(fileLogger, oCfg.Resolver.File.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		apiConfig := oCfg.Resolver.K8sSvc.APIConfig
+		if apiConfig.AuthType == "" {
+			apiConfig.AuthType = k8sconfig.AuthTypeServiceAccount
+		}
+		client, err := k8sconfig.MakeClient(apiConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = newK8sResolver(client, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if res == nil {
 		return nil, errNoResolver
@@ -97,6 +125,24 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 	}, nil
 }
 
+// countResolvers returns how many of the resolvers are configured.
+func countResolvers(settings ResolverSettings) int {
+	count := 0
+	if settings.Static != nil {
+		count++
+	}
+	if settings.DNS != nil {
+		count++
+	}
+	if settings.File != nil {
+		count++
+	}
+	if settings.K8sSvc != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -110,6 +112,86 @@ func TestWithDNSResolver(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestNewLoadBalancerInvalidFileResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: &FileResolver{},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.Equal(t, errNoFilePath, err)
+}
+
+func TestWithFileResolver(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: &FileResolver{
+				Path: "backends.txt",
+			},
+		},
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*fileResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+}
+
+func TestWithK8sResolver(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "127.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
+				Service:   "lb.observability",
+			},
+		},
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, "observability", res.svcNs)
+}
+
+func TestNewLoadBalancerInvalidK8sResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				APIConfig: k8sconfig.APIConfig{AuthType: "invalid"},
+				Service:   "lb",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.EqualError(t, err, "invalid authType for kubernetes: invalid")
+}
+
 func TestMultipleResolvers(t *testing.T) {
 	cfg := &Config{
 		Resolver: ResolverSettings{
@@ -130,6 +212,26 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleNewResolvers(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: &FileResolver{
+				Path: "backends.txt",
+			},
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: resourceRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "resource", "":
+	case "metric":
+		metricExporter.routingKey = metricNameRouting
+	case "streamID":
+		metricExporter.routingKey = streamIDRouting
+	default:
+		return nil, fmt.Errorf("unsupported routing_key for metrics: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	batches := splitMetricsByEndpoint(md, e.routingKey, e.loadBalancer.Endpoint)
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batch))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
+	}
+	return err
+}
+
+// splitMetricsByEndpoint splits md into one batch per endpoint, according to the endpoint that
+// endpointFor returns for the routing identifier of each resource, metric or data point.
+// The structure of md is kept in each batch: resources and scopes are copied into every batch
+// that holds some of their data.
+func splitMetricsByEndpoint(md pmetric.Metrics, key routingKey, endpointFor func([]byte) string) map[string]pmetric.Metrics {
+	batches := map[string]*metricsBatch{}
+	batchFor := func(endpoint string) *metricsBatch {
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = newMetricsBatch()
+			batches[endpoint] = batch
+		}
+		return batch
+	}
+
+	var buf bytes.Buffer
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if key == resourceRouting {
+			buf.Reset()
+			writeAttributes(&buf, rm.Resource().Attributes())
+			rm.CopyTo(batchFor(endpointFor(buf.Bytes())).md.ResourceMetrics().AppendEmpty())
+			continue
+		}
+
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				if key == metricNameRouting {
+					m.CopyTo(batchFor(endpointFor([]byte(m.Name()))).scopeMetrics(i, rm, j, sm).Metrics().AppendEmpty())
+					continue
+				}
+
+				forEachDataPointAttributes(m, func(l int, attrs pcommon.Map) {
+					buf.Reset()
+					writeStreamID(&buf, rm.Resource(), sm.Scope(), m, attrs)
+					batchFor(endpointFor(buf.Bytes())).appendDataPoint(i, rm, j, sm, k, m, l)
+				})
+			}
+		}
+	}
+
+	split := make(map[string]pmetric.Metrics, len(batches))
+	for endpoint, batch := range batches {
+		split[endpoint] = batch.md
+	}
+	return split
+}
+
+// metricsBatch holds the data routed to a single endpoint. Since the input is traversed in order,
+// the resource, scope and metric that data was last appended to are reused as long as the data
+// comes from the same input resource, scope and metric.
+type metricsBatch struct {
+	md pmetric.Metrics
+
+	rmIdx, smIdx, mIdx int
+	rm                 pmetric.ResourceMetrics
+	sm                 pmetric.ScopeMetrics
+	m                  pmetric.Metric
+}
+
+func newMetricsBatch() *metricsBatch {
+	return &metricsBatch{md: pmetric.NewMetrics(), rmIdx: -1, smIdx: -1, mIdx: -1}
+}
+
+// scopeMetrics returns the scope metrics of the batch for the scope metrics sm at index j of the
+// resource metrics rm at index i of the input.
+func (b *metricsBatch) scopeMetrics(i int, rm pmetric.ResourceMetrics, j int, sm pmetric.ScopeMetrics) pmetric.ScopeMetrics {
+	if b.rmIdx != i {
+		b.rm = b.md.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(b.rm.Resource())
+		b.rm.SetSchemaUrl(rm.SchemaUrl())
+		b.rmIdx, b.smIdx = i, -1
+	}
+	if b.smIdx != j {
+		b.sm = b.rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(b.sm.Scope())
+		b.sm.SetSchemaUrl(sm.SchemaUrl())
+		b.smIdx, b.mIdx = j, -1
+	}
+	return b.sm
+}
+
+// appendDataPoint appends the data point at index l of the metric m at index k of the input.
+func (b *metricsBatch) appendDataPoint(i int, rm pmetric.ResourceMetrics, j int, sm pmetric.ScopeMetrics, k int, m pmetric.Metric, l int) {
+	sms := b.scopeMetrics(i, rm, j, sm)
+	if b.mIdx != k {
+		b.m = sms.Metrics().AppendEmpty()
+		metricutils.CopyMetricDescriptor(m, b.m)
+		b.mIdx = k
+	}
+
+	metricutils.CopyDataPoint(m, l, b.m)
+}
+
+// forEachDataPointAttributes calls fn with the index and the attributes of each data point of m.
+func forEachDataPointAttributes(m pmetric.Metric, fn func(int, pcommon.Map)) {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			fn(l, dps.At(l).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			fn(l, dps.At(l).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			fn(l, dps.At(l).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			fn(l, dps.At(l).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			fn(l, dps.At(l).Attributes())
+		}
+	}
+}
+
+// writeStreamID writes the identity of the stream of a data point: the attributes of its resource,
+// the name and version of its scope, the name and type of its metric and its own attributes.
+func writeStreamID(buf *bytes.Buffer, resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, attrs pcommon.Map) {
+	writeAttributes(buf, resource.Attributes())
+	buf.WriteString(scope.Name())
+	buf.WriteByte(0)
+	buf.WriteString(scope.Version())
+	buf.WriteByte(0)
+	buf.WriteString(m.Name())
+	buf.WriteByte(0)
+	buf.WriteString(m.DataType().String())
+	buf.WriteByte(0)
+	writeAttributes(buf, attrs)
+}
+
+// writeAttributes writes attrs sorted by key, so that the same attributes always result in the same
+// identifier regardless of their order.
+func writeAttributes(buf *bytes.Buffer, attrs pcommon.Map) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		buf.WriteString(k)
+		buf.WriteByte(0)
+		buf.WriteString(v.AsString())
+		buf.WriteByte(0)
+	}
+	buf.WriteByte(0)
+}
@@ -0,0 +1,449 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		config     *Config
+		routingKey routingKey
+		err        error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			resourceRouting,
+			nil,
+		},
+		{
+			"resource",
+			metricsRoutingConfig("resource"),
+			resourceRouting,
+			nil,
+		},
+		{
+			"metric",
+			metricsRoutingConfig("metric"),
+			metricNameRouting,
+			nil,
+		},
+		{
+			"streamID",
+			metricsRoutingConfig("streamID"),
+			streamIDRouting,
+			nil,
+		},
+		{
+			"traceID",
+			metricsRoutingConfig("traceID"),
+			0,
+			errors.New("unsupported routing_key for metrics: traceID"),
+		},
+		{
+			"empty",
+			&Config{},
+			0,
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.routingKey, p.routingKey)
+			}
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := metricsRoutingConfig("metric")
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1:4317", "endpoint-2:4317"}
+
+	var lock sync.Mutex
+	received := map[string][]string{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+			lock.Lock()
+			defer lock.Unlock()
+			received[endpoint] = append(received[endpoint], metricNames(md)...)
+			return nil
+		}), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	md := pmetric.NewMetrics()
+	for i := 0; i < 2; i++ {
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		for j := 0; j < 10; j++ {
+			appendGauge(metrics, fmt.Sprintf("metric-%d", j), map[string]string{"instance": fmt.Sprint(i)})
+		}
+	}
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Len(t, received, 2, "all metrics went to the same endpoint")
+	total := 0
+	for endpoint, names := range received {
+		total += len(names)
+		for _, name := range names {
+			assert.Equal(t, endpoint, lb.Endpoint([]byte(name)))
+		}
+	}
+	assert.Equal(t, 20, total)
+}
+
+func TestConsumeMetricsExporterNotFound(t *testing.T) {
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), nil)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	lb.componentFactory = func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return nil, errors.New("can't create exporter")
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("couldn't find the exporter for the endpoint %q", "endpoint-1"))
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestSplitMetricsByResource(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, svc := range []string{"svc-a", "svc-b", "svc-a"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		fillResource(rm.Resource(), svc)
+		rm.Resource().Attributes().InsertString("host.name", "host-1")
+		appendGauge(rm.ScopeMetrics().AppendEmpty().Metrics(), "metric-"+svc, nil)
+	}
+	// the order of the attributes doesn't matter
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("host.name", "host-1")
+	fillResource(rm.Resource(), "svc-b")
+	appendGauge(rm.ScopeMetrics().AppendEmpty().Metrics(), "metric-svc-b", nil)
+
+	// test
+	split := splitMetricsByEndpoint(md, resourceRouting, func(identifier []byte) string {
+		if strings.Contains(string(identifier), "svc-a") {
+			return "endpoint-a"
+		}
+		return "endpoint-b"
+	})
+
+	// verify
+	require.Len(t, split, 2)
+	assert.Equal(t, 2, split["endpoint-a"].ResourceMetrics().Len())
+	assert.Equal(t, []string{"metric-svc-a", "metric-svc-a"}, metricNames(split["endpoint-a"]))
+	assert.Equal(t, 2, split["endpoint-b"].ResourceMetrics().Len())
+	assert.Equal(t, []string{"metric-svc-b", "metric-svc-b"}, metricNames(split["endpoint-b"]))
+}
+
+func TestSplitMetricsByMetricName(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, svc := range []string{"svc-a", "svc-b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		fillResource(rm.Resource(), svc)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("scope")
+		appendGauge(sm.Metrics(), "requests", nil)
+		appendGauge(sm.Metrics(), "errors", nil)
+		appendGauge(sm.Metrics(), "latency", nil)
+	}
+
+	// test
+	split := splitMetricsByEndpoint(md, metricNameRouting, func(identifier []byte) string {
+		return string(identifier)
+	})
+
+	// verify
+	require.Len(t, split, 3)
+	for _, name := range []string{"requests", "errors", "latency"} {
+		batch := split[name]
+		require.Equal(t, 2, batch.ResourceMetrics().Len(), name)
+		for i, svc := range []string{"svc-a", "svc-b"} {
+			rm := batch.ResourceMetrics().At(i)
+			svcName, _ := rm.Resource().Attributes().Get("service.name")
+			assert.Equal(t, svc, svcName.StringVal())
+			require.Equal(t, 1, rm.ScopeMetrics().Len())
+			assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+		}
+		assert.Equal(t, []string{name, name}, metricNames(batch))
+	}
+}
+
+func TestSplitMetricsByStreamID(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	fillResource(rm.Resource(), "svc-a")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	for i, route := range []string{"a", "b", "a", "b", "a"} {
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("route", route)
+		dp.SetIntVal(int64(i))
+	}
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	histogram.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	for _, route := range []string{"b", "a"} {
+		dp := histogram.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("route", route)
+		dp.SetCount(1)
+	}
+
+	// test
+	split := splitMetricsByEndpoint(md, streamIDRouting, func(identifier []byte) string {
+		if strings.Contains(string(identifier), "route\x00a\x00") {
+			return "endpoint-a"
+		}
+		return "endpoint-b"
+	})
+
+	// verify
+	require.Len(t, split, 2)
+	for endpoint, tt := range map[string]struct {
+		route      string
+		sumValues  []int64
+		histograms int
+	}{
+		"endpoint-a": {route: "a", sumValues: []int64{0, 2, 4}, histograms: 1},
+		"endpoint-b": {route: "b", sumValues: []int64{1, 3}, histograms: 1},
+	} {
+		batch := split[endpoint]
+		require.Equal(t, 1, batch.ResourceMetrics().Len())
+		require.Equal(t, 1, batch.ResourceMetrics().At(0).ScopeMetrics().Len())
+		metrics := batch.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 2, metrics.Len())
+
+		s := metrics.At(0)
+		assert.Equal(t, "requests", s.Name())
+		assert.Equal(t, "1", s.Unit())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, s.Sum().AggregationTemporality())
+		assert.True(t, s.Sum().IsMonotonic())
+		var values []int64
+		for i := 0; i < s.Sum().DataPoints().Len(); i++ {
+			dp := s.Sum().DataPoints().At(i)
+			route, _ := dp.Attributes().Get("route")
+			assert.Equal(t, tt.route, route.StringVal())
+			values = append(values, dp.IntVal())
+		}
+		assert.Equal(t, tt.sumValues, values)
+
+		h := metrics.At(1)
+		assert.Equal(t, "latency", h.Name())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, h.Histogram().AggregationTemporality())
+		assert.Equal(t, tt.histograms, h.Histogram().DataPoints().Len())
+	}
+}
+
+func TestSplitMetricsSameStreamSameEndpoint(t *testing.T) {
+	// the data points of a stream are routed to the same endpoint, regardless of the order
+	// of the attributes or the other data in the batch
+	newMetrics := func(attrs map[string]string, other bool) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		fillResource(rm.Resource(), "svc-a")
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		if other {
+			appendGauge(metrics, "other", nil)
+		}
+		appendGauge(metrics, "requests", attrs)
+		return md
+	}
+
+	endpointFor := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"}).endpointFor
+	first := splitMetricsByEndpoint(newMetrics(map[string]string{"a": "1", "b": "2"}, false), streamIDRouting, endpointFor)
+	second := splitMetricsByEndpoint(newMetrics(map[string]string{"b": "2", "a": "1"}, true), streamIDRouting, endpointFor)
+
+	require.Len(t, first, 1)
+	for endpoint := range first {
+		assert.Contains(t, metricNames(second[endpoint]), "requests")
+	}
+}
+
+func appendGauge(metrics pmetric.MetricSlice, name string, attrs map[string]string) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pmetric.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(1)
+	for k, v := range attrs {
+		dp.Attributes().InsertString(k, v)
+	}
+}
+
+func metricNames(md pmetric.Metrics) []string {
+	var names []string
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				names = append(names, metrics.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func simpleMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	fillResource(rm.Resource(), "svc-a")
+	appendGauge(rm.ScopeMetrics().AppendEmpty().Metrics(), "metric-1", map[string]string{"instance": "1"})
+	return md
+}
+
+func metricsRoutingConfig(routingKey string) *Config {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		RoutingKey: routingKey,
+	}
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+var _ resolver = (*fileResolver)(nil)
+
+var errNoFilePath = errors.New("no path specified for the file resolver")
+
+// fileResolver reads the list of backends from a file, one backend per line, and reads it again
+// whenever the file changes. Empty lines and lines starting with # are ignored.
+type fileResolver struct {
+	logger *zap.Logger
+
+	path    string
+	watcher *fsnotify.Watcher
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan (struct{})
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newFileResolver(logger *zap.Logger, path string) (*fileResolver, error) {
+	if len(path) == 0 {
+		return nil, errNoFilePath
+	}
+
+	return &fileResolver{
+		logger: logger,
+		path:   path,
+		stopCh: make(chan struct{}),
+	}, nil
+}
+
+func (r *fileResolver) start(ctx context.Context) error {
+	// the directory is watched instead of the file itself, so that files replaced by a rename,
+	// like ConfigMaps mounted as volumes in Kubernetes, keep being watched
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(filepath.Dir(r.path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	r.watcher = watcher
+
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	r.shutdownWg.Add(1)
+	go r.watch()
+
+	return nil
+}
+
+func (r *fileResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+
+	if r.watcher != nil {
+		return r.watcher.Close()
+	}
+	return nil
+}
+
+func (r *fileResolver) watch() {
+	defer r.shutdownWg.Done()
+
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			// any change in the directory might have changed the file, e.g. when the file is a symlink
+			// to a file that has been replaced. Resolutions that don't change the backends are ignored.
+			r.logger.Debug("directory of the backends file changed", zap.String("event", event.String()))
+			if _, err := r.resolve(context.Background()); err != nil {
+				r.logger.Warn("failed to resolve", zap.Error(err))
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.logger.Warn("failed to watch the backends file", zap.Error(err))
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *fileResolver) resolve(ctx context.Context) ([]string, error) {
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("resolver"), "file"))
+
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		failedCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(failedCtx, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	// from this point, we don't fail anymore
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	backends := parseBackends(content)
+
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+func (r *fileResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+// parseBackends returns the sorted, unique backends listed in content, one per line.
+func parseBackends(content []byte) []string {
+	seen := map[string]bool{}
+	backends := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		backend := strings.TrimSpace(scanner.Text())
+		if backend == "" || strings.HasPrefix(backend, "#") || seen[backend] {
+			continue
+		}
+		seen[backend] = true
+		backends = append(backends, backend)
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+	return backends
+}
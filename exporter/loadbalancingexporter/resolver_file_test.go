@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+func TestInitialFileResolution(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "backends")
+	require.NoError(t, os.WriteFile(path, []byte(`
+# the backends of the load balancer
+endpoint-2:4317
+  endpoint-1
+endpoint-2:4317
+`), 0600))
+
+	res, err := newFileResolver(zap.NewNop(), path)
+	require.NoError(t, err)
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2:4317"}, resolved)
+}
+
+func TestErrNoFilePath(t *testing.T) {
+	// test
+	res, err := newFileResolver(zap.NewNop(), "")
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoFilePath, err)
+}
+
+func TestFileResolverMissingFile(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backends")
+	res, err := newFileResolver(zap.NewNop(), path)
+	require.NoError(t, err)
+
+	resolved := make(chan []string, 1)
+	res.onChange(func(endpoints []string) {
+		resolved <- endpoints
+	})
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	_, err = res.resolve(context.Background())
+	assert.Error(t, err)
+
+	// the file is picked up once it is created
+	require.NoError(t, os.WriteFile(path, []byte("endpoint-1\n"), 0600))
+
+	// verify
+	select {
+	case endpoints := <-resolved:
+		assert.Equal(t, []string{"endpoint-1"}, endpoints)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the file resolver didn't pick up the created file")
+	}
+}
+
+func TestFileResolverWatchesChanges(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backends")
+	require.NoError(t, os.WriteFile(path, []byte("endpoint-1\n"), 0600))
+
+	res, err := newFileResolver(zap.NewNop(), path)
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	var resolved []string
+	counter := atomic.NewInt64(0)
+	res.onChange(func(endpoints []string) {
+		lock.Lock()
+		defer lock.Unlock()
+		resolved = endpoints
+		counter.Inc()
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	require.Equal(t, int64(1), counter.Load())
+
+	// test: the file is replaced by a rename, like tools usually update files atomically
+	tmp := filepath.Join(dir, "backends.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("endpoint-1\nendpoint-2\n"), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return counter.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	lock.Lock()
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2"}, resolved)
+	lock.Unlock()
+
+	// writing the same backends again doesn't trigger a change
+	require.NoError(t, os.WriteFile(path, []byte("endpoint-2\nendpoint-1\n"), 0600))
+	_, err = res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counter.Load())
+}
+
+func TestFileResolverShutdownClearsCallbacks(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "backends")
+	require.NoError(t, os.WriteFile(path, []byte("endpoint-1\n"), 0600))
+	res, err := newFileResolver(zap.NewNop(), path)
+	require.NoError(t, err)
+
+	res.onChange(func(s []string) {})
+	require.NoError(t, res.start(context.Background()))
+
+	// sanity check
+	require.Len(t, res.onChangeCallbacks, 1)
+
+	// test
+	err = res.shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
+
+func TestParseBackends(t *testing.T) {
+	for _, tt := range []struct {
+		content  string
+		expected []string
+	}{
+		{
+			"",
+			[]string{},
+		},
+		{
+			"endpoint-1",
+			[]string{"endpoint-1"},
+		},
+		{
+			"endpoint-2\r\n# endpoint-3\r\n\r\nendpoint-1:4317\r\n",
+			[]string{"endpoint-1:4317", "endpoint-2"},
+		},
+		{
+			"\t[::1]:4317 \nendpoint-1\nendpoint-1\n",
+			[]string{"[::1]:4317", "endpoint-1"},
+		},
+	} {
+		assert.Equal(t, tt.expected, parseBackends([]byte(tt.content)))
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace   = "default"
+	defaultK8sSyncTimeout = 10 * time.Second
+)
+
+var errNoSvc = errors.New("no service specified to resolve the backends")
+
+// k8sResolver watches the Endpoints object of a Kubernetes service and resolves
+// the ready addresses of the service as backends.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	ports       []int32
+	informer    cache.SharedInformer
+	syncTimeout time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan (struct{})
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(client kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	if len(ports) == 0 {
+		// IPv6 addresses need to be combined with a port, so the default port is added right away
+		port, _ := strconv.ParseInt(defaultPort, 10, 32)
+		ports = []int32{int32(port)}
+	}
+
+	name, namespace := service, defaultK8sNamespace
+	if idx := strings.Index(service, "."); idx >= 0 {
+		name, namespace = service[:idx], service[idx+1:]
+	}
+
+	// only the Endpoints object of the service is listed and watched
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	r := &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		informer:    cache.NewSharedInformer(listWatch, &corev1.Endpoints{}, 0),
+		syncTimeout: defaultK8sSyncTimeout,
+		stopCh:      make(chan struct{}),
+	}
+	r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { r.resolveOnEvent() },
+		UpdateFunc: func(interface{}, interface{}) { r.resolveOnEvent() },
+		DeleteFunc: func(interface{}) { r.resolveOnEvent() },
+	})
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	r.shutdownWg.Add(1)
+	go func() {
+		defer r.shutdownWg.Done()
+		r.informer.Run(r.stopCh)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), r.informer.HasSynced) {
+		r.logger.Warn("timed out waiting for the endpoints of the service, backends will be resolved once they are available",
+			zap.String("service", r.svcName), zap.String("namespace", r.svcNs))
+		return nil
+	}
+
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) resolveOnEvent() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("resolver"), "k8s"))
+
+	obj, exists, err := r.informer.GetStore().GetByKey(r.svcNs + "/" + r.svcName)
+	if err != nil {
+		failedCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(failedCtx, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	// from this point, we don't fail anymore
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	// a service without an Endpoints object has no backends
+	backends := []string{}
+	if exists {
+		if endpoints, ok := obj.(*corev1.Endpoints); ok {
+			backends = r.backends(endpoints)
+		}
+	}
+
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+// backends returns the sorted backends for the ready addresses of endpoints, combining
+// each address with each of the configured ports.
+func (r *k8sResolver) backends(endpoints *corev1.Endpoints) []string {
+	seen := map[string]bool{}
+	backends := []string{}
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			for _, port := range r.ports {
+				backend := net.JoinHostPort(addr.IP, strconv.Itoa(int(port)))
+				if !seen[backend] {
+					seen[backend] = true
+					backends = append(backends, backend)
+				}
+			}
+		}
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+	return backends
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestInitialK8sResolution(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		service  string
+		ports    []int32
+		expected []string
+	}{
+		{
+			name:     "default port",
+			service:  "lb.observability",
+			expected: []string{"10.0.0.1:4317", "10.0.0.2:4317", "[fd00::1]:4317"},
+		},
+		{
+			name:    "multiple ports",
+			service: "lb.observability",
+			ports:   []int32{4317, 55690},
+			expected: []string{
+				"10.0.0.1:4317", "10.0.0.1:55690",
+				"10.0.0.2:4317", "10.0.0.2:55690",
+				"[fd00::1]:4317", "[fd00::1]:55690",
+			},
+		},
+		{
+			name:     "default namespace",
+			service:  "lb",
+			expected: []string{"10.0.1.1:4317"},
+		},
+		{
+			name:     "missing service",
+			service:  "other.observability",
+			expected: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			client := fake.NewSimpleClientset(
+				newTestEndpoints("lb", "observability", []string{"10.0.0.2", "10.0.0.1"}, []string{"fd00::1"}, []string{"10.0.0.3"}),
+				newTestEndpoints("lb", "default", []string{"10.0.1.1"}, nil, nil),
+			)
+			res, err := newK8sResolver(client, zap.NewNop(), tt.service, tt.ports)
+			require.NoError(t, err)
+
+			// test
+			var resolved []string
+			res.onChange(func(endpoints []string) {
+				resolved = endpoints
+			})
+			require.NoError(t, res.start(context.Background()))
+			defer func() {
+				require.NoError(t, res.shutdown(context.Background()))
+			}()
+
+			// verify
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
+
+func TestErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolverWatchesChanges(t *testing.T) {
+	// prepare
+	endpoints := newTestEndpoints("lb", "observability", []string{"10.0.0.1"}, nil, nil)
+	client := fake.NewSimpleClientset(endpoints)
+
+	// the fake client drops the changes made before the informer started watching
+	watchStarted := make(chan struct{})
+	var once sync.Once
+	client.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := client.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		once.Do(func() { close(watchStarted) })
+		return true, w, err
+	})
+
+	res, err := newK8sResolver(client, zap.NewNop(), "lb.observability", []int32{4317})
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	var resolved [][]string
+	res.onChange(func(endpoints []string) {
+		lock.Lock()
+		defer lock.Unlock()
+		resolved = append(resolved, endpoints)
+	})
+	latest := func() [][]string {
+		lock.Lock()
+		defer lock.Unlock()
+		return resolved
+	}
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	<-watchStarted
+	require.Equal(t, [][]string{{"10.0.0.1:4317"}}, latest())
+
+	// test: a new pod becomes ready, during a rollout for instance
+	updated := newTestEndpoints("lb", "observability", []string{"10.0.0.1", "10.0.0.2"}, nil, nil)
+	_, err = client.CoreV1().Endpoints("observability").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(latest()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317"}, latest()[1])
+
+	// test: an update that doesn't change the ready addresses doesn't trigger a change
+	updated = newTestEndpoints("lb", "observability", []string{"10.0.0.2", "10.0.0.1"}, nil, []string{"10.0.0.3"})
+	_, err = client.CoreV1().Endpoints("observability").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// test: the service is removed
+	err = client.CoreV1().Endpoints("observability").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(latest()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{}, latest()[2])
+}
+
+func TestK8sResolverShutdownClearsCallbacks(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	res.onChange(func(s []string) {})
+	require.NoError(t, res.start(context.Background()))
+
+	// sanity check
+	require.Len(t, res.onChangeCallbacks, 1)
+
+	// test
+	err = res.shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
+
+// newTestEndpoints returns the Endpoints object of a service with the given ready addresses in one subset,
+// and the given ready and not ready addresses in another.
+func newTestEndpoints(name, namespace string, ready []string, otherReady []string, notReady []string) *corev1.Endpoints {
+	toAddresses := func(ips []string) []corev1.EndpointAddress {
+		var addrs []corev1.EndpointAddress
+		for _, ip := range ips {
+			addrs = append(addrs, corev1.EndpointAddress{IP: ip})
+		}
+		return addrs
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: toAddresses(ready)},
+			{Addresses: toAddresses(otherReady), NotReadyAddresses: toAddresses(notReady)},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the root directory of the Hive-style partitioned dataset. Files are written
+	// to <path>/signal=<signal>/date=<YYYY-MM-DD>/hour=<HH>/.
+	Path string `mapstructure:"path"`
+
+	// MaxFileSize is the approximate uncompressed size in bytes after which the current
+	// file is closed and a new one is started.
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+
+	// RotationInterval is the maximum amount of time a file is kept open before it is
+	// closed and a new one is started.
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+
+	// Compression is the codec used for column chunks. One of none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.MaxFileSize <= 0 {
+		return errors.New("max_file_size must be positive")
+	}
+	if cfg.RotationInterval <= 0 {
+		return errors.New("rotation_interval must be positive")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	return nil
+}
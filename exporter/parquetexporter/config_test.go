@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewComponentID(typeStr)]
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Path:             "/var/output",
+		MaxFileSize:      defaultMaxFileSize,
+		RotationInterval: defaultRotationInterval,
+		Compression:      defaultCompression,
+	}, e0)
+
+	e1 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "2")]
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+		Path:             "/var/output/2",
+		MaxFileSize:      1048576,
+		RotationInterval: time.Minute,
+		Compression:      compressionZstd,
+	}, e1)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing path",
+			mutate:  func(cfg *Config) { cfg.Path = "" },
+			wantErr: "path must be non-empty",
+		},
+		{
+			name:    "invalid max file size",
+			mutate:  func(cfg *Config) { cfg.MaxFileSize = 0 },
+			wantErr: "max_file_size must be positive",
+		},
+		{
+			name:    "invalid rotation interval",
+			mutate:  func(cfg *Config) { cfg.RotationInterval = -time.Second },
+			wantErr: "rotation_interval must be positive",
+		},
+		{
+			name:    "unknown compression",
+			mutate:  func(cfg *Config) { cfg.Compression = "brotli" },
+			wantErr: `compression "brotli" is not supported`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = "/var/output"
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -16,33 +16,110 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
 )
 
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+var schemas = map[string]interface{}{
+	signalTraces:  new(spanRow),
+	signalMetrics: new(metricRow),
+	signalLogs:    new(logRow),
+}
+
 type parquetExporter struct {
-	path string
+	cfg    *Config
+	signal string
+	logger *zap.Logger
+	writer *rollingWriter
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, logger *zap.Logger, signal string) *parquetExporter {
+	return &parquetExporter{
+		cfg:    cfg,
+		signal: signal,
+		logger: logger,
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
+	// The instance ID keeps file names unique when several collectors write to the same dataset.
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	e.writer = newRollingWriter(e.cfg, e.signal, schemas[e.signal], hex.EncodeToString(id), e.logger)
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) shutdown(_ context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return e.writer.write(metricsToRows(md))
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.writer.write(tracesToRows(td))
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.writer.write(logsToRows(ld))
+}
+
+// resourceColumns holds the resource columns shared by every row of a resource.
+type resourceColumns struct {
+	serviceName string
+	attributes  map[string]string
+}
+
+func newResourceColumns(res pcommon.Resource) resourceColumns {
+	cols := resourceColumns{attributes: attributesToMap(res.Attributes())}
+	if serviceName, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+		cols.serviceName = serviceName.AsString()
+	}
+	return cols
+}
+
+// scopeColumns holds the instrumentation scope columns shared by every row of a scope.
+type scopeColumns struct {
+	name       string
+	version    string
+	attributes map[string]string
+}
+
+func newScopeColumns(scope pcommon.InstrumentationScope) scopeColumns {
+	return scopeColumns{
+		name:       scope.Name(),
+		version:    scope.Version(),
+		attributes: attributesToMap(scope.Attributes()),
+	}
+}
+
+// attributesToMap converts attributes into a string map. Non-string values are
+// rendered with their string representation, maps and slices as JSON.
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
 }
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func newTestExporter(t *testing.T, signal string) *parquetExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	e := newParquetExporter(cfg, zap.NewNop(), signal)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+// readRows reads all rows of every Parquet file of signal written under root.
+func readRows(t *testing.T, root string, signal string, schema interface{}) []interface{} {
+	files, err := filepath.Glob(filepath.Join(root, "signal="+signal, "date=*", "hour=*", "*.parquet"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	var rows []interface{}
+	for _, file := range files {
+		pf, err := local.NewLocalFileReader(file)
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(pf, schema, 1)
+		require.NoError(t, err)
+		res, err := pr.ReadByNumber(int(pr.GetNumRows()))
+		require.NoError(t, err)
+		rows = append(rows, res...)
+		pr.ReadStop()
+		require.NoError(t, pf.Close())
+	}
+	return rows
+}
+
+func TestParquetTracesExporter(t *testing.T) {
+	e := newTestExporter(t, signalTraces)
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.NoError(t, e.consumeTraces(context.Background(), td))
+	assert.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, e.cfg.Path, signalTraces, new(spanRow))
+	require.Len(t, rows, 2)
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	row := rows[0].(spanRow)
+	assert.Equal(t, "resource-attr-val-1", row.ResourceAttributes["resource-attr"])
+	assert.Equal(t, span.Name(), row.Name)
+	assert.Equal(t, int64(span.StartTimestamp()), row.StartTime)
+	assert.Equal(t, int64(span.EndTimestamp()-span.StartTimestamp()), row.DurationNanos)
+	assert.Equal(t, span.Status().Code().String(), row.StatusCode)
+	require.Len(t, row.Events, 2)
+	assert.Equal(t, "event-with-attr", row.Events[0].Name)
+	assert.Equal(t, "span-event-attr-val", row.Events[0].Attributes["span-event-attr"])
+}
+
+func TestParquetMetricsExporter(t *testing.T) {
+	e := newTestExporter(t, signalMetrics)
+	md := testdata.GenerateMetricsOneCounterOneSummaryMetrics()
+	assert.NoError(t, e.consumeMetrics(context.Background(), md))
+	assert.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, e.cfg.Path, signalMetrics, new(metricRow))
+	require.Len(t, rows, md.DataPointCount())
+
+	sum := rows[0].(metricRow)
+	assert.Equal(t, "counter-int", sum.MetricName)
+	assert.Equal(t, pmetric.MetricDataTypeSum.String(), sum.MetricType)
+	require.NotNil(t, sum.IntValue)
+	assert.Equal(t, int64(123), *sum.IntValue)
+	assert.Nil(t, sum.DoubleValue)
+	require.NotNil(t, sum.AggregationTemporality)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative.String(), *sum.AggregationTemporality)
+
+	summary := rows[len(rows)-1].(metricRow)
+	assert.Equal(t, pmetric.MetricDataTypeSummary.String(), summary.MetricType)
+	require.NotNil(t, summary.Count)
+	assert.Len(t, summary.Quantiles, len(summary.QuantileValues))
+}
+
+func TestParquetLogsExporter(t *testing.T) {
+	e := newTestExporter(t, signalLogs)
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, e.consumeLogs(context.Background(), ld))
+	assert.NoError(t, e.shutdown(context.Background()))
+
+	rows := readRows(t, e.cfg.Path, signalLogs, new(logRow))
+	require.Len(t, rows, 2)
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	row := rows[0].(logRow)
+	assert.Equal(t, lr.Body().AsString(), row.Body)
+	assert.Equal(t, lr.SeverityText(), row.SeverityText)
+	assert.Equal(t, int32(lr.SeverityNumber()), row.SeverityNumber)
+	assert.Equal(t, lr.TraceID().HexString(), row.TraceID)
+}
+
+func TestParquetExporterShutdownWithoutStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	e := newParquetExporter(cfg, zap.NewNop(), signalLogs)
+	assert.NoError(t, e.shutdown(context.Background()))
+}
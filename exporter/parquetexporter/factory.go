@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,12 +28,11 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelInDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultMaxFileSize      = 64 * 1024 * 1024
+	defaultRotationInterval = 5 * time.Minute
+	defaultCompression      = compressionSnappy
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
@@ -47,6 +47,9 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		MaxFileSize:      defaultMaxFileSize,
+		RotationInterval: defaultRotationInterval,
+		Compression:      defaultCompression,
 	}
 }
 
@@ -55,7 +58,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, signalTraces)
 	return exporterhelper.NewTracesExporterWithContext(
 		ctx,
 		set,
@@ -71,7 +74,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, signalMetrics)
 	return exporterhelper.NewMetricsExporterWithContext(
 		ctx,
 		set,
@@ -87,7 +90,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, signalLogs)
 	return exporterhelper.NewLogsExporterWithContext(
 		ctx,
 		set,
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	set := componenttest.NewNopExporterCreateSettings()
+	ctx := context.Background()
+
+	te, err := factory.CreateTracesExporter(ctx, set, cfg)
+	require.NoError(t, err)
+	me, err := factory.CreateMetricsExporter(ctx, set, cfg)
+	require.NoError(t, err)
+	le, err := factory.CreateLogsExporter(ctx, set, cfg)
+	require.NoError(t, err)
+
+	for _, e := range []component.Component{te, me, le} {
+		assert.NoError(t, e.Start(ctx, componenttest.NewNopHost()))
+		assert.NoError(t, e.Shutdown(ctx))
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logRow is the schema of the logs dataset. Each log record is written as one row,
+// with its resource and instrumentation scope flattened into columns.
+type logRow struct {
+	ServiceName        string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes    map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTime           int64             `parquet:"name=observed_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	SeverityNumber         int32             `parquet:"name=severity_number, type=INT32"`
+	SeverityText           string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Body                   string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// logsToRows flattens ld into one logRow per log record.
+func logsToRows(ld plog.Logs) []interface{} {
+	rows := make([]interface{}, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		res := newResourceColumns(rl.Resource())
+		slls := rl.ScopeLogs()
+		for j := 0; j < slls.Len(); j++ {
+			sl := slls.At(j)
+			scope := newScopeColumns(sl.Scope())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				rows = append(rows, &logRow{
+					ServiceName:            res.serviceName,
+					ResourceAttributes:     res.attributes,
+					ScopeName:              scope.name,
+					ScopeVersion:           scope.version,
+					ScopeAttributes:        scope.attributes,
+					Time:                   int64(lr.Timestamp()),
+					ObservedTime:           int64(lr.ObservedTimestamp()),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					SeverityText:           lr.SeverityText(),
+					Body:                   lr.Body().AsString(),
+					Attributes:             attributesToMap(lr.Attributes()),
+					DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+					Flags:                  int64(lr.Flags()),
+					TraceID:                lr.TraceID().HexString(),
+					SpanID:                 lr.SpanID().HexString(),
+				})
+			}
+		}
+	}
+	return rows
+}
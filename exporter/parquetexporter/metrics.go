@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// metricRow is the schema of the metrics dataset. Each data point is written as one row,
+// with its resource, instrumentation scope and metric descriptor flattened into columns.
+// Columns that do not apply to the metric type of the data point are left null.
+type metricRow struct {
+	ServiceName        string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes    map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricType             string            `parquet:"name=metric_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality *string           `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	IsMonotonic            *bool             `parquet:"name=is_monotonic, type=BOOLEAN, repetitiontype=OPTIONAL"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Time                   int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	NoRecordedValue        bool              `parquet:"name=no_recorded_value, type=BOOLEAN"`
+
+	// Gauge and Sum.
+	IntValue    *int64   `parquet:"name=int_value, type=INT64, repetitiontype=OPTIONAL"`
+	DoubleValue *float64 `parquet:"name=double_value, type=DOUBLE, repetitiontype=OPTIONAL"`
+
+	// Histogram, ExponentialHistogram and Summary.
+	Count *int64   `parquet:"name=count, type=INT64, repetitiontype=OPTIONAL"`
+	Sum   *float64 `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min   *float64 `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max   *float64 `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+
+	// Histogram.
+	BucketCounts   []int64   `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds []float64 `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+
+	// ExponentialHistogram.
+	Scale                *int32  `parquet:"name=scale, type=INT32, repetitiontype=OPTIONAL"`
+	ZeroCount            *int64  `parquet:"name=zero_count, type=INT64, repetitiontype=OPTIONAL"`
+	PositiveOffset       *int32  `parquet:"name=positive_offset, type=INT32, repetitiontype=OPTIONAL"`
+	PositiveBucketCounts []int64 `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset       *int32  `parquet:"name=negative_offset, type=INT32, repetitiontype=OPTIONAL"`
+	NegativeBucketCounts []int64 `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+
+	// Summary.
+	Quantiles      []float64 `parquet:"name=quantiles, type=LIST, valuetype=DOUBLE"`
+	QuantileValues []float64 `parquet:"name=quantile_values, type=LIST, valuetype=DOUBLE"`
+}
+
+// metricsToRows flattens md into one metricRow per data point.
+func metricsToRows(md pmetric.Metrics) []interface{} {
+	rows := make([]interface{}, 0, md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		res := newResourceColumns(rm.Resource())
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			scope := newScopeColumns(ilm.Scope())
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				rows = appendMetricRows(rows, res, scope, metrics.At(k))
+			}
+		}
+	}
+	return rows
+}
+
+func appendMetricRows(rows []interface{}, res resourceColumns, scope scopeColumns, metric pmetric.Metric) []interface{} {
+	newRow := func(attributes pcommon.Map, start, ts pcommon.Timestamp, flags pmetric.MetricDataPointFlags) *metricRow {
+		return &metricRow{
+			ServiceName:        res.serviceName,
+			ResourceAttributes: res.attributes,
+			ScopeName:          scope.name,
+			ScopeVersion:       scope.version,
+			ScopeAttributes:    scope.attributes,
+			MetricName:         metric.Name(),
+			MetricDescription:  metric.Description(),
+			MetricUnit:         metric.Unit(),
+			MetricType:         metric.DataType().String(),
+			Attributes:         attributesToMap(attributes),
+			StartTime:          int64(start),
+			Time:               int64(ts),
+			NoRecordedValue:    flags.NoRecordedValue(),
+		}
+	}
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := newRow(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			setNumberValue(row, dp)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricDataTypeSum:
+		sum := metric.Sum()
+		temporality := sum.AggregationTemporality().String()
+		monotonic := sum.IsMonotonic()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := newRow(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			row.AggregationTemporality = &temporality
+			row.IsMonotonic = &monotonic
+			setNumberValue(row, dp)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		temporality := histogram.AggregationTemporality().String()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := newRow(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			row.AggregationTemporality = &temporality
+			row.Count = int64Ptr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = float64Ptr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = float64Ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = float64Ptr(dp.Max())
+			}
+			row.BucketCounts = uint64sToInt64s(dp.BucketCounts().AsRaw())
+			row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+			rows = append(rows, row)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		histogram := metric.ExponentialHistogram()
+		temporality := histogram.AggregationTemporality().String()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := newRow(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			row.AggregationTemporality = &temporality
+			row.Count = int64Ptr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = float64Ptr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = float64Ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = float64Ptr(dp.Max())
+			}
+			scale := dp.Scale()
+			row.Scale = &scale
+			row.ZeroCount = int64Ptr(int64(dp.ZeroCount()))
+			positiveOffset := dp.Positive().Offset()
+			row.PositiveOffset = &positiveOffset
+			row.PositiveBucketCounts = uint64sToInt64s(dp.Positive().BucketCounts().AsRaw())
+			negativeOffset := dp.Negative().Offset()
+			row.NegativeOffset = &negativeOffset
+			row.NegativeBucketCounts = uint64sToInt64s(dp.Negative().BucketCounts().AsRaw())
+			rows = append(rows, row)
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := newRow(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			row.Count = int64Ptr(int64(dp.Count()))
+			row.Sum = float64Ptr(dp.Sum())
+			qvs := dp.QuantileValues()
+			row.Quantiles = make([]float64, qvs.Len())
+			row.QuantileValues = make([]float64, qvs.Len())
+			for j := 0; j < qvs.Len(); j++ {
+				row.Quantiles[j] = qvs.At(j).Quantile()
+				row.QuantileValues[j] = qvs.At(j).Value()
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func setNumberValue(row *metricRow, dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		row.IntValue = int64Ptr(dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		row.DoubleValue = float64Ptr(dp.DoubleVal())
+	}
+}
+
+func uint64sToInt64s(in []uint64) []int64 {
+	out := make([]int64, len(in))
+	for i, v := range in {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
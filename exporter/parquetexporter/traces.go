@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanRow is the schema of the traces dataset. Each span is written as one row,
+// with its resource and instrumentation scope flattened into columns.
+type spanRow struct {
+	ServiceName        string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes    map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID           string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Kind                   string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StartTime              int64             `parquet:"name=start_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTime                int64             `parquet:"name=end_time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNanos          int64             `parquet:"name=duration_nanos, type=INT64"`
+	StatusCode             string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusMessage          string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+	Events                 []spanEventRow    `parquet:"name=events, type=LIST"`
+	DroppedEventsCount     int64             `parquet:"name=dropped_events_count, type=INT64"`
+	Links                  []spanLinkRow     `parquet:"name=links, type=LIST"`
+	DroppedLinksCount      int64             `parquet:"name=dropped_links_count, type=INT64"`
+}
+
+type spanEventRow struct {
+	Time       int64             `parquet:"name=time, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name       string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type spanLinkRow struct {
+	TraceID    string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID     string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+// tracesToRows flattens td into one spanRow per span.
+func tracesToRows(td ptrace.Traces) []interface{} {
+	rows := make([]interface{}, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		res := newResourceColumns(rs.Resource())
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			scope := newScopeColumns(ils.Scope())
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				rows = append(rows, newSpanRow(res, scope, spans.At(k)))
+			}
+		}
+	}
+	return rows
+}
+
+func newSpanRow(res resourceColumns, scope scopeColumns, span ptrace.Span) *spanRow {
+	row := &spanRow{
+		ServiceName:            res.serviceName,
+		ResourceAttributes:     res.attributes,
+		ScopeName:              scope.name,
+		ScopeVersion:           scope.version,
+		ScopeAttributes:        scope.attributes,
+		TraceID:                span.TraceID().HexString(),
+		SpanID:                 span.SpanID().HexString(),
+		ParentSpanID:           span.ParentSpanID().HexString(),
+		TraceState:             string(span.TraceState()),
+		Name:                   span.Name(),
+		Kind:                   span.Kind().String(),
+		StartTime:              int64(span.StartTimestamp()),
+		EndTime:                int64(span.EndTimestamp()),
+		DurationNanos:          int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+		StatusCode:             span.Status().Code().String(),
+		StatusMessage:          span.Status().Message(),
+		Attributes:             attributesToMap(span.Attributes()),
+		DroppedAttributesCount: int64(span.DroppedAttributesCount()),
+		DroppedEventsCount:     int64(span.DroppedEventsCount()),
+		DroppedLinksCount:      int64(span.DroppedLinksCount()),
+	}
+
+	events := span.Events()
+	row.Events = make([]spanEventRow, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		row.Events[i] = spanEventRow{
+			Time:       int64(event.Timestamp()),
+			Name:       event.Name(),
+			Attributes: attributesToMap(event.Attributes()),
+		}
+	}
+
+	links := span.Links()
+	row.Links = make([]spanLinkRow, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		row.Links[i] = spanLinkRow{
+			TraceID:    link.TraceID().HexString(),
+			SpanID:     link.SpanID().HexString(),
+			TraceState: string(link.TraceState()),
+			Attributes: attributesToMap(link.Attributes()),
+		}
+	}
+	return row
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// inProgressSuffix is appended to files that are still being written, so that
+	// query engines globbing for *.parquet never pick up a file without a footer.
+	inProgressSuffix = ".inprogress"
+	// parallelism is the number of goroutines used by the Parquet writer to marshal rows.
+	parallelism = 1
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+}
+
+// rollingWriter writes rows of a single signal to Hive-style partitioned Parquet
+// files, starting a new file when the partition changes, when the current file
+// grows beyond maxFileSize or when it has been open for longer than rotationInterval.
+type rollingWriter struct {
+	root             string
+	signal           string
+	schema           interface{}
+	maxFileSize      int64
+	rotationInterval time.Duration
+	compression      parquet.CompressionCodec
+	instanceID       string
+	logger           *zap.Logger
+	now              func() time.Time
+
+	mu        sync.Mutex
+	file      *os.File
+	pw        *writer.ParquetWriter
+	partition string
+	filePath  string
+	timer     *time.Timer
+	seq       int
+}
+
+func newRollingWriter(cfg *Config, signal string, schema interface{}, instanceID string, logger *zap.Logger) *rollingWriter {
+	return &rollingWriter{
+		root:             cfg.Path,
+		signal:           signal,
+		schema:           schema,
+		maxFileSize:      cfg.MaxFileSize,
+		rotationInterval: cfg.RotationInterval,
+		compression:      compressionCodecs[cfg.Compression],
+		instanceID:       instanceID,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// partitionFor returns the partition directory, relative to the root, for rows written at t.
+func (w *rollingWriter) partitionFor(t time.Time) string {
+	t = t.UTC()
+	return filepath.Join(
+		"signal="+w.signal,
+		"date="+t.Format("2006-01-02"),
+		fmt.Sprintf("hour=%02d", t.Hour()),
+	)
+}
+
+// write appends rows to the current file, rotating it first if needed.
+func (w *rollingWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	if w.pw != nil && (w.partition != w.partitionFor(now) || w.size() >= w.maxFileSize) {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+	if w.pw == nil {
+		if err := w.openFile(now); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if err := w.pw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s row to %s: %w", w.signal, w.filePath, err)
+		}
+	}
+	return nil
+}
+
+// size returns the number of bytes flushed to the current file plus the estimated
+// size of the rows still buffered in memory.
+func (w *rollingWriter) size() int64 {
+	return w.pw.Offset + w.pw.ObjsSize
+}
+
+func (w *rollingWriter) openFile(now time.Time) error {
+	partition := w.partitionFor(now)
+	dir := filepath.Join(w.root, partition)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	w.seq++
+	name := fmt.Sprintf("%s-%s-%06d.parquet", now.UTC().Format("20060102T150405Z"), w.instanceID, w.seq)
+	filePath := filepath.Join(dir, name)
+	file, err := os.OpenFile(filePath+inProgressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	pw, err := writer.NewParquetWriterFromWriter(file, w.schema, parallelism)
+	if err != nil {
+		return multierr.Append(err, file.Close())
+	}
+	pw.CompressionType = w.compression
+	if pw.RowGroupSize > w.maxFileSize {
+		pw.RowGroupSize = w.maxFileSize
+	}
+
+	w.file = file
+	w.pw = pw
+	w.partition = partition
+	w.filePath = filePath
+	seq := w.seq
+	w.timer = time.AfterFunc(w.rotationInterval, func() { w.rotateExpired(seq) })
+	return nil
+}
+
+// rotateExpired closes the file with sequence number seq once it has been open for
+// rotationInterval, even if no more rows arrive.
+func (w *rollingWriter) rotateExpired(seq int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// The file may already have been rotated while the timer was firing.
+	if w.pw == nil || w.seq != seq {
+		return
+	}
+	if err := w.closeFile(); err != nil {
+		w.logger.Error("Failed to rotate Parquet file", zap.String("signal", w.signal), zap.Error(err))
+	}
+}
+
+// closeFile writes the footer of the current file and moves it to its final name.
+// It must be called with w.mu held.
+func (w *rollingWriter) closeFile() error {
+	w.timer.Stop()
+	pw, file, filePath := w.pw, w.file, w.filePath
+	w.pw, w.file, w.filePath, w.timer = nil, nil, "", nil
+
+	if pw.NumRows == 0 && len(pw.Objs) == 0 {
+		return multierr.Append(file.Close(), os.Remove(filePath+inProgressSuffix))
+	}
+	if err := pw.WriteStop(); err != nil {
+		return multierr.Append(fmt.Errorf("failed to finalize %s: %w", filePath, err), file.Close())
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(filePath+inProgressSuffix, filePath)
+}
+
+// close finalizes the current file, if any.
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.pw == nil {
+		return nil
+	}
+	return w.closeFile()
+}
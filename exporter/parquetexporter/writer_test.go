@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func newTestWriter(t *testing.T, mutate func(cfg *Config)) *rollingWriter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	if mutate != nil {
+		mutate(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	return newRollingWriter(cfg, signalLogs, new(logRow), "test", zap.NewNop())
+}
+
+func listFiles(t *testing.T, root string) []string {
+	var files []string
+	require.NoError(t, filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		files = append(files, rel)
+		return err
+	}))
+	return files
+}
+
+func TestRollingWriterHivePartitions(t *testing.T) {
+	w := newTestWriter(t, nil)
+	now := time.Date(2022, 8, 23, 13, 59, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	rows := logsToRows(testdata.GenerateLogsOneLogRecord())
+
+	require.NoError(t, w.write(rows))
+	// The file is only renamed to its final name once it is complete.
+	assert.Equal(t, []string{
+		"signal=logs/date=2022-08-23/hour=13/20220823T135900Z-test-000001.parquet.inprogress",
+	}, listFiles(t, w.root))
+
+	// Crossing into the next hour starts a file in the new partition.
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, w.write(rows))
+	require.NoError(t, w.close())
+	assert.Equal(t, []string{
+		"signal=logs/date=2022-08-23/hour=13/20220823T135900Z-test-000001.parquet",
+		"signal=logs/date=2022-08-23/hour=14/20220823T140100Z-test-000002.parquet",
+	}, listFiles(t, w.root))
+}
+
+func TestRollingWriterRotatesBySize(t *testing.T) {
+	w := newTestWriter(t, func(cfg *Config) {
+		cfg.MaxFileSize = 1
+	})
+	rows := logsToRows(testdata.GenerateLogsOneLogRecord())
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.write(rows))
+	}
+	require.NoError(t, w.close())
+	assert.Len(t, listFiles(t, w.root), 3)
+	assert.Len(t, readRows(t, w.root, signalLogs, new(logRow)), 3)
+}
+
+func TestRollingWriterRotatesByTime(t *testing.T) {
+	w := newTestWriter(t, func(cfg *Config) {
+		cfg.RotationInterval = 10 * time.Millisecond
+	})
+	require.NoError(t, w.write(logsToRows(testdata.GenerateLogsOneLogRecord())))
+
+	assert.Eventually(t, func() bool {
+		files := listFiles(t, w.root)
+		return len(files) == 1 && filepath.Ext(files[0]) == ".parquet"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, w.close())
+}
+
+func TestRollingWriterSkipsEmptyBatches(t *testing.T) {
+	w := newTestWriter(t, nil)
+	require.NoError(t, w.write(logsToRows(testdata.GenerateLogsNoLogRecords())))
+	require.NoError(t, w.close())
+	assert.Empty(t, listFiles(t, w.root))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	networkTCP = "tcp"
+	networkUDP = "udp"
+
+	protocolRFC5424 = "rfc5424"
+	protocolRFC3164 = "rfc3164"
+)
+
+// Config defines configuration for the syslog exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the address of the syslog server, in the form host:port.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Network is the transport used to send the messages, udp or tcp.
+	Network string `mapstructure:"network"`
+
+	// TLSSetting enables TLS on the tcp connections (RFC 5425) when set. The messages sent over TLS
+	// are always framed with octet counting.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Protocol is the format of the messages, rfc5424 or rfc3164.
+	Protocol string `mapstructure:"protocol"`
+
+	// EnableOctetCounting frames the messages sent over tcp with the octet counting method of RFC 6587,
+	// instead of terminating them with a newline, in which case the newlines of the messages are escaped.
+	EnableOctetCounting bool `mapstructure:"enable_octet_counting"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("\"endpoint\" must be set")
+	}
+	if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+		return fmt.Errorf("\"endpoint\" must be in the form host:port: %w", err)
+	}
+
+	switch c.Network {
+	case networkTCP:
+	case networkUDP:
+		if c.TLSSetting != nil {
+			return errors.New("\"tls\" requires the tcp network")
+		}
+		if c.EnableOctetCounting {
+			return errors.New("\"enable_octet_counting\" requires the tcp network")
+		}
+	default:
+		return fmt.Errorf("unsupported network %q, must be %q or %q", c.Network, networkTCP, networkUDP)
+	}
+
+	switch c.Protocol {
+	case protocolRFC5424, protocolRFC3164:
+	default:
+		return fmt.Errorf("unsupported protocol %q, must be %q or %q", c.Protocol, protocolRFC5424, protocolRFC3164)
+	}
+
+	return nil
+}
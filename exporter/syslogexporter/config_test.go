@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:514"
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewComponentID(typeStr)])
+
+	expectedCfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "allsettings")),
+		TimeoutSettings: exporterhelper.TimeoutSettings{
+			Timeout: 10 * time.Second,
+		},
+		RetrySettings: exporterhelper.RetrySettings{
+			Enabled:         false,
+			InitialInterval: 99 * time.Second,
+			MaxInterval:     199 * time.Second,
+			MaxElapsedTime:  299 * time.Minute,
+		},
+		QueueSettings: exporterhelper.QueueSettings{
+			Enabled:      false,
+			NumConsumers: 7,
+			QueueSize:    17,
+		},
+		Endpoint: "siem.example.com:6514",
+		Network:  "tcp",
+		TLSSetting: &configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile: "/var/lib/certs/ca.pem",
+			},
+		},
+		Protocol:            "rfc3164",
+		EnableOctetCounting: true,
+	}
+	assert.Equal(t, expectedCfg, cfg.Exporters[config.NewComponentIDWithName(typeStr, "allsettings")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		errMsg    string
+	}{
+		{
+			name:      "missing endpoint",
+			configure: func(cfg *Config) { cfg.Endpoint = "" },
+			errMsg:    `"endpoint" must be set`,
+		},
+		{
+			name:      "invalid endpoint",
+			configure: func(cfg *Config) { cfg.Endpoint = "localhost" },
+			errMsg:    `"endpoint" must be in the form host:port: address localhost: missing port in address`,
+		},
+		{
+			name:      "invalid network",
+			configure: func(cfg *Config) { cfg.Network = "unix" },
+			errMsg:    `unsupported network "unix", must be "tcp" or "udp"`,
+		},
+		{
+			name: "tls over udp",
+			configure: func(cfg *Config) {
+				cfg.Network = "udp"
+				cfg.TLSSetting = &configtls.TLSClientSetting{}
+			},
+			errMsg: `"tls" requires the tcp network`,
+		},
+		{
+			name: "octet counting over udp",
+			configure: func(cfg *Config) {
+				cfg.Network = "udp"
+				cfg.EnableOctetCounting = true
+			},
+			errMsg: `"enable_octet_counting" requires the tcp network`,
+		},
+		{
+			name:      "invalid protocol",
+			configure: func(cfg *Config) { cfg.Protocol = "rfc5425" },
+			errMsg:    `unsupported protocol "rfc5425", must be "rfc5424" or "rfc3164"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:514"
+			require.NoError(t, cfg.Validate())
+
+			tt.configure(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.errMsg)
+		})
+	}
+}
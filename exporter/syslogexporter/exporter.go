@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type syslogExporter struct {
+	config    *Config
+	logger    *zap.Logger
+	format    formatter
+	tlsConfig *tls.Config
+	// octetCounting is whether the messages sent over tcp are framed with octet counting,
+	// which is always the case over TLS (RFC 5425).
+	octetCounting bool
+
+	// mu protects the connection, which is shared by the consumers of the queue.
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newLogsExporter(cfg *Config, logger *zap.Logger) (*syslogExporter, error) {
+	octetCounting := cfg.EnableOctetCounting || cfg.TLSSetting != nil
+	exp := &syslogExporter{
+		config:        cfg,
+		logger:        logger,
+		octetCounting: octetCounting,
+		// The messages terminated by a newline must not contain any other newline.
+		format: newFormatter(cfg.Protocol, cfg.Network == networkTCP && !octetCounting),
+	}
+
+	if cfg.TLSSetting != nil {
+		tlsConfig, err := cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		exp.tlsConfig = tlsConfig
+	}
+	return exp, nil
+}
+
+// pushLogs sends a syslog message for each log record. When a message cannot be sent,
+// the records that were not sent yet are returned to be retried.
+func (e *syslogExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sent := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resource := rls.At(i).Resource()
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				if err := e.send(e.format(resource, records.At(k))); err != nil {
+					return consumererror.NewLogs(err, unsentLogs(ld, sent))
+				}
+				sent++
+			}
+		}
+	}
+	return nil
+}
+
+// send writes a message to the connection, which is opened if needed, and closed on errors.
+func (e *syslogExporter) send(msg string) error {
+	if e.conn == nil {
+		conn, err := e.dial()
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", e.config.Endpoint, err)
+		}
+		e.conn = conn
+	}
+
+	if e.config.Timeout > 0 {
+		if err := e.conn.SetWriteDeadline(time.Now().Add(e.config.Timeout)); err != nil {
+			e.closeConn()
+			return fmt.Errorf("failed to set the write deadline: %w", err)
+		}
+	}
+
+	if _, err := e.conn.Write(e.frame(msg)); err != nil {
+		e.closeConn()
+		return fmt.Errorf("failed to send the message to %s: %w", e.config.Endpoint, err)
+	}
+	return nil
+}
+
+func (e *syslogExporter) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: e.config.Timeout}
+	if e.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, networkTCP, e.config.Endpoint, e.tlsConfig)
+	}
+	return dialer.Dial(e.config.Network, e.config.Endpoint)
+}
+
+// frame delimits a message: each udp datagram holds a message, while the messages sent over tcp
+// are prefixed by their length with the octet counting framing, or terminated by a newline.
+func (e *syslogExporter) frame(msg string) []byte {
+	switch {
+	case e.config.Network == networkUDP:
+		return []byte(msg)
+	case e.octetCounting:
+		return []byte(strconv.Itoa(len(msg)) + " " + msg)
+	default:
+		return []byte(msg + "\n")
+	}
+}
+
+func (e *syslogExporter) closeConn() {
+	if err := e.conn.Close(); err != nil {
+		e.logger.Debug("failed to close the connection", zap.Error(err))
+	}
+	e.conn = nil
+}
+
+func (e *syslogExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		e.closeConn()
+	}
+	return nil
+}
+
+// unsentLogs returns a copy of the logs without their first sent log records.
+func unsentLogs(ld plog.Logs, sent int) plog.Logs {
+	unsent := ld.Clone()
+
+	unsent.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				if sent > 0 {
+					sent--
+					return true
+				}
+				return false
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return unsent
+}
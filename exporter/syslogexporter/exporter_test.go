@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestLogs(bodies ...string) plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		record := records.AppendEmpty()
+		record.SetObservedTimestamp(1)
+		record.Body().SetStringVal(body)
+	}
+	return ld
+}
+
+func newTestExporter(t *testing.T, endpoint, network string, octetCounting bool) *syslogExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Network = network
+	cfg.EnableOctetCounting = octetCounting
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newLogsExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	})
+	return exp
+}
+
+// acceptLines accepts a tcp connection and sends the data it receives, split by the split function.
+func acceptLines(t *testing.T, listener net.Listener, split bufio.SplitFunc) <-chan string {
+	lines := make(chan string, 10)
+	go func() {
+		defer close(lines)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		scanner.Split(split)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+func expectMessage(t *testing.T, lines <-chan string, expected string) {
+	select {
+	case line := <-lines:
+		assert.Equal(t, expected, line)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for the message")
+	}
+}
+
+func TestPushLogsTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	lines := acceptLines(t, listener, bufio.ScanLines)
+
+	exp := newTestExporter(t, listener.Addr().String(), networkTCP, false)
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first", "multi\r\nline")))
+
+	expectMessage(t, lines, "<14>1 1970-01-01T00:00:00Z - - - - - first")
+	expectMessage(t, lines, `<14>1 1970-01-01T00:00:00Z - - - - - multi\r\nline`)
+}
+
+func TestPushLogsTCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	msg := "<14>1 1970-01-01T00:00:00Z - - - - - multi\nline"
+	expected := fmt.Sprintf("%d %s", len(msg), msg)
+	frames := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, len(expected))
+		if _, err := io.ReadFull(conn, buf); err == nil {
+			frames <- string(buf)
+		}
+	}()
+
+	exp := newTestExporter(t, listener.Addr().String(), networkTCP, true)
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("multi\nline")))
+
+	expectMessage(t, frames, expected)
+}
+
+func TestFrame(t *testing.T) {
+	msg := "<14>1 1970-01-01T00:00:00Z - - - - - message"
+	tests := []struct {
+		name      string
+		configure func(cfg *Config)
+		expected  string
+	}{
+		{
+			name:      "udp",
+			configure: func(cfg *Config) { cfg.Network = networkUDP },
+			expected:  msg,
+		},
+		{
+			name:      "tcp",
+			configure: func(cfg *Config) {},
+			expected:  msg + "\n",
+		},
+		{
+			name:      "tcp with octet counting",
+			configure: func(cfg *Config) { cfg.EnableOctetCounting = true },
+			expected:  "44 " + msg,
+		},
+		{
+			name:      "tls implies octet counting",
+			configure: func(cfg *Config) { cfg.TLSSetting = &configtls.TLSClientSetting{} },
+			expected:  "44 " + msg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:514"
+			tt.configure(cfg)
+			require.NoError(t, cfg.Validate())
+
+			exp, err := newLogsExporter(cfg, zap.NewNop())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(exp.frame(msg)))
+		})
+	}
+}
+
+func TestPushLogsUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp := newTestExporter(t, conn.LocalAddr().String(), networkUDP, false)
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first", "second")))
+
+	buf := make([]byte, 1024)
+	for _, expected := range []string{"first", "second"} {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "<14>1 1970-01-01T00:00:00Z - - - - - "+expected, string(buf[:n]))
+	}
+}
+
+func TestPushLogsConnectionFailure(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	exp := newTestExporter(t, endpoint, networkTCP, false)
+	err = exp.pushLogs(context.Background(), newTestLogs("first", "second"))
+	require.Error(t, err)
+
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	assert.Equal(t, 2, logsErr.GetLogs().LogRecordCount())
+}
+
+func TestUnsentLogs(t *testing.T) {
+	ld := newTestLogs("first", "second")
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStringVal("third")
+
+	unsent := unsentLogs(ld, 1)
+	require.Equal(t, 2, unsent.LogRecordCount())
+	assert.Equal(t, "second", unsent.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+	assert.Equal(t, "third", unsent.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+
+	unsent = unsentLogs(ld, 2)
+	require.Equal(t, 1, unsent.LogRecordCount())
+	assert.Equal(t, 1, unsent.ResourceLogs().Len())
+	assert.Equal(t, 3, ld.LogRecordCount())
+}
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// The attributes set by the syslog parser, which are used to preserve the fields of the received messages.
+const (
+	priorityAttribute       = "priority"
+	facilityAttribute       = "facility"
+	hostnameAttribute       = "hostname"
+	appnameAttribute        = "appname"
+	procIDAttribute         = "proc_id"
+	msgIDAttribute          = "msg_id"
+	messageAttribute        = "message"
+	structuredDataAttribute = "structured_data"
+)
+
+const (
+	nilValue = "-"
+
+	// defaultFacility is the facility of the messages that have no facility attribute, user-level messages.
+	defaultFacility = 1
+	// defaultSeverity is the severity of the messages that have no severity number, informational messages.
+	defaultSeverity = 6
+
+	rfc5424Timestamp = "2006-01-02T15:04:05.999999Z07:00"
+	rfc3164Timestamp = "Jan _2 15:04:05"
+)
+
+// formatter formats a log record as a syslog message.
+type formatter func(resource pcommon.Resource, record plog.LogRecord) string
+
+// message holds the fields of a syslog message.
+type message struct {
+	priority       int64
+	timestamp      time.Time
+	hostname       string
+	appname        string
+	procID         string
+	msgID          string
+	structuredData pcommon.Map
+	text           string
+}
+
+// newFormatter returns the formatter of the protocol. When escapeNewlines is set, the newlines
+// of the messages are escaped, since they would be taken for the end of the messages.
+func newFormatter(protocol string, escapeNewlines bool) formatter {
+	format := formatRFC5424
+	if protocol == protocolRFC3164 {
+		format = formatRFC3164
+	}
+	if !escapeNewlines {
+		return format
+	}
+	return func(resource pcommon.Resource, record plog.LogRecord) string {
+		return newlineEscaper.Replace(format(resource, record))
+	}
+}
+
+var newlineEscaper = strings.NewReplacer("\r", `\r`, "\n", `\n`)
+
+// newMessage extracts the fields of a syslog message from a log record, preferring the attributes
+// set by the syslog parser, and falling back to the resource attributes and the fields of the record.
+func newMessage(resource pcommon.Resource, record plog.LogRecord) message {
+	attrs := record.Attributes()
+	msg := message{
+		priority:  priority(attrs, record.SeverityNumber()),
+		timestamp: record.Timestamp().AsTime(),
+		hostname:  stringAttribute(attrs, hostnameAttribute, resource.Attributes(), conventions.AttributeHostName),
+		appname:   stringAttribute(attrs, appnameAttribute, resource.Attributes(), conventions.AttributeServiceName),
+		procID:    stringAttribute(attrs, procIDAttribute, resource.Attributes(), conventions.AttributeProcessPID),
+		text:      record.Body().AsString(),
+	}
+
+	if record.Timestamp() == 0 {
+		msg.timestamp = record.ObservedTimestamp().AsTime()
+		if record.ObservedTimestamp() == 0 {
+			msg.timestamp = time.Now()
+		}
+	}
+	if msgID, ok := attrs.Get(msgIDAttribute); ok {
+		msg.msgID = msgID.AsString()
+	}
+	if text, ok := attrs.Get(messageAttribute); ok {
+		msg.text = text.AsString()
+	}
+	if sd, ok := attrs.Get(structuredDataAttribute); ok && sd.Type() == pcommon.ValueTypeMap {
+		msg.structuredData = sd.MapVal()
+	} else {
+		msg.structuredData = pcommon.NewMap()
+	}
+	return msg
+}
+
+// priority returns the priority attribute, or computes the priority from the facility attribute and the severity number.
+func priority(attrs pcommon.Map, severityNumber plog.SeverityNumber) int64 {
+	if value, ok := attrs.Get(priorityAttribute); ok && value.Type() == pcommon.ValueTypeInt && value.IntVal() >= 0 && value.IntVal() < 192 {
+		return value.IntVal()
+	}
+
+	facility := int64(defaultFacility)
+	if value, ok := attrs.Get(facilityAttribute); ok && value.Type() == pcommon.ValueTypeInt && value.IntVal() >= 0 && value.IntVal() < 24 {
+		facility = value.IntVal()
+	}
+	return facility*8 + severity(severityNumber)
+}
+
+// severity maps a severity number to a syslog severity, as the inverse of the mapping of the syslog parser.
+func severity(severityNumber plog.SeverityNumber) int64 {
+	switch {
+	case severityNumber == plog.SeverityNumberUNDEFINED:
+		return defaultSeverity
+	case severityNumber >= plog.SeverityNumberFATAL:
+		return 0 // emerg
+	case severityNumber >= plog.SeverityNumberERROR3:
+		return 1 // alert
+	case severityNumber == plog.SeverityNumberERROR2:
+		return 2 // crit
+	case severityNumber == plog.SeverityNumberERROR:
+		return 3 // err
+	case severityNumber >= plog.SeverityNumberWARN:
+		return 4 // warning
+	case severityNumber >= plog.SeverityNumberINFO2:
+		return 5 // notice
+	case severityNumber == plog.SeverityNumberINFO:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
+
+// stringAttribute returns the record attribute, or the resource attribute, or an empty string.
+func stringAttribute(attrs pcommon.Map, key string, resourceAttrs pcommon.Map, resourceKey string) string {
+	if value, ok := attrs.Get(key); ok {
+		return value.AsString()
+	}
+	if value, ok := resourceAttrs.Get(resourceKey); ok {
+		return value.AsString()
+	}
+	return ""
+}
+
+// formatRFC5424 formats a log record as a syslog message of RFC 5424:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+func formatRFC5424(resource pcommon.Resource, record plog.LogRecord) string {
+	msg := newMessage(resource, record)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s %s",
+		msg.priority,
+		msg.timestamp.UTC().Format(rfc5424Timestamp),
+		headerField(msg.hostname, 255),
+		headerField(msg.appname, 48),
+		headerField(msg.procID, 128),
+		headerField(msg.msgID, 32),
+		structuredData(msg.structuredData),
+	)
+	if msg.text != "" {
+		b.WriteString(" ")
+		b.WriteString(msg.text)
+	}
+	return b.String()
+}
+
+// formatRFC3164 formats a log record as a syslog message of RFC 3164:
+// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+func formatRFC3164(resource pcommon.Resource, record plog.LogRecord) string {
+	msg := newMessage(resource, record)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>%s %s ", msg.priority, msg.timestamp.UTC().Format(rfc3164Timestamp), headerField(msg.hostname, 255))
+	if msg.appname != "" {
+		b.WriteString(headerField(msg.appname, 32))
+		if msg.procID != "" {
+			fmt.Fprintf(&b, "[%s]", headerField(msg.procID, 128))
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(msg.text)
+	return b.String()
+}
+
+// headerField returns the nil value for empty fields, and truncates the fields to their maximal length,
+// replacing the characters that are not printable ASCII characters.
+func headerField(value string, maxLength int) string {
+	if value == "" {
+		return nilValue
+	}
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+	return strings.Map(func(r rune) rune {
+		if r < '!' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+// sdName sanitizes the SD-IDs and the parameter names, which cannot contain '=', ']', '"' nor spaces.
+func sdName(name string) string {
+	name = headerField(name, 32)
+	return strings.Map(func(r rune) rune {
+		if r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+var sdValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// structuredData formats the structured data attribute, a map of the SD-IDs to the maps of their parameters.
+func structuredData(sd pcommon.Map) string {
+	if sd.Len() == 0 {
+		return nilValue
+	}
+
+	ids := make([]string, 0, sd.Len())
+	sd.Range(func(id string, _ pcommon.Value) bool {
+		ids = append(ids, id)
+		return true
+	})
+	sort.Strings(ids)
+
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString("[")
+		b.WriteString(sdName(id))
+		params, _ := sd.Get(id)
+		if params.Type() == pcommon.ValueTypeMap {
+			names := make([]string, 0, params.MapVal().Len())
+			params.MapVal().Range(func(name string, _ pcommon.Value) bool {
+				names = append(names, name)
+				return true
+			})
+			sort.Strings(names)
+			for _, name := range names {
+				value, _ := params.MapVal().Get(name)
+				fmt.Fprintf(&b, ` %s="%s"`, sdName(name), sdValueEscaper.Replace(value.AsString()))
+			}
+		}
+		b.WriteString("]")
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+var testTimestamp = time.Date(2022, time.August, 1, 10, 0, 0, 123456789, time.UTC)
+
+// newParsedRecord creates a log record with the attributes set by the syslog parser.
+func newParsedRecord() plog.LogRecord {
+	record := plog.NewLogRecord()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	record.SetSeverityNumber(plog.SeverityNumberERROR2)
+	record.Body().SetStringVal("<34>1 2022-08-01T10:00:00.123456Z server su 123 ID47 - 'su root' failed")
+	attrs := record.Attributes()
+	attrs.UpsertInt(priorityAttribute, 34)
+	attrs.UpsertInt(facilityAttribute, 4)
+	attrs.UpsertString(hostnameAttribute, "server")
+	attrs.UpsertString(appnameAttribute, "su")
+	attrs.UpsertString(procIDAttribute, "123")
+	attrs.UpsertString(msgIDAttribute, "ID47")
+	attrs.UpsertString(messageAttribute, "'su root' failed")
+	return record
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource func() pcommon.Resource
+		record   func() plog.LogRecord
+		expected string
+	}{
+		{
+			name:     "parsed",
+			resource: pcommon.NewResource,
+			record:   newParsedRecord,
+			expected: "<34>1 2022-08-01T10:00:00.123456Z server su 123 ID47 - 'su root' failed",
+		},
+		{
+			name:     "structured data",
+			resource: pcommon.NewResource,
+			record: func() plog.LogRecord {
+				record := newParsedRecord()
+				sd := pcommon.NewValueMap()
+				params := pcommon.NewValueMap()
+				params.MapVal().UpsertString("iut", "3")
+				params.MapVal().UpsertString("eventSource", `App"lication]`)
+				sd.MapVal().Upsert("exampleSDID@32473", params)
+				sd.MapVal().Upsert("examplePriority@32473", pcommon.NewValueMap())
+				record.Attributes().Upsert(structuredDataAttribute, sd)
+				return record
+			},
+			expected: `<34>1 2022-08-01T10:00:00.123456Z server su 123 ID47 [examplePriority@32473][exampleSDID@32473 eventSource="App\"lication\]" iut="3"] 'su root' failed`,
+		},
+		{
+			name: "from resource and severity",
+			resource: func() pcommon.Resource {
+				resource := pcommon.NewResource()
+				resource.Attributes().UpsertString(conventions.AttributeHostName, "my host")
+				resource.Attributes().UpsertString(conventions.AttributeServiceName, "checkout")
+				resource.Attributes().UpsertInt(conventions.AttributeProcessPID, 42)
+				return resource
+			},
+			record: func() plog.LogRecord {
+				record := plog.NewLogRecord()
+				record.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+				record.SetSeverityNumber(plog.SeverityNumberWARN)
+				record.Body().SetStringVal("low stock")
+				return record
+			},
+			expected: "<12>1 2022-08-01T10:00:00.123456Z my_host checkout 42 - - low stock",
+		},
+		{
+			name:     "empty",
+			resource: pcommon.NewResource,
+			record: func() plog.LogRecord {
+				record := plog.NewLogRecord()
+				record.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+				return record
+			},
+			expected: "<14>1 2022-08-01T10:00:00.123456Z - - - - -",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatRFC5424(tt.resource(), tt.record()))
+		})
+	}
+}
+
+func TestFormatRFC3164(t *testing.T) {
+	assert.Equal(t, "<34>Aug  1 10:00:00 server su[123]: 'su root' failed", formatRFC3164(pcommon.NewResource(), newParsedRecord()))
+
+	record := plog.NewLogRecord()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	record.SetSeverityNumber(plog.SeverityNumberDEBUG)
+	record.Body().SetStringVal("hello")
+	record.Attributes().UpsertInt(facilityAttribute, 16)
+	assert.Equal(t, "<135>Aug  1 10:00:00 - hello", formatRFC3164(pcommon.NewResource(), record))
+}
+
+func TestSeverity(t *testing.T) {
+	// the inverse of the mapping of the syslog parser
+	for expected, severityNumber := range []plog.SeverityNumber{
+		plog.SeverityNumberFATAL,
+		plog.SeverityNumberERROR3,
+		plog.SeverityNumberERROR2,
+		plog.SeverityNumberERROR,
+		plog.SeverityNumberWARN,
+		plog.SeverityNumberINFO2,
+		plog.SeverityNumberINFO,
+		plog.SeverityNumberDEBUG,
+	} {
+		assert.Equal(t, int64(expected), severity(severityNumber))
+	}
+	assert.Equal(t, int64(defaultSeverity), severity(plog.SeverityNumberUNDEFINED))
+	assert.Equal(t, int64(7), severity(plog.SeverityNumberTRACE))
+}
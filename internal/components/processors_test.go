@@ -93,6 +93,9 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "experimental_metricsgeneration",
 		},
+		{
+			processor: "metricsaggregation",
+		},
 		{
 			processor: "probabilistic_sampler",
 		},
@@ -128,6 +131,9 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "cumulativetodelta",
 		},
+		{
+			processor: "deltatocumulative",
+		},
 		{
 			processor: "tail_sampling",
 		},
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// HistogramSummary holds the fields shared by histograms and exponential histograms. The sum, min and max
+// are only kept while all the merged data points have them.
+type HistogramSummary struct {
+	Count  uint64
+	Sum    float64
+	Min    float64
+	Max    float64
+	HasSum bool
+	HasMin bool
+	HasMax bool
+}
+
+// NewHistogramSummary returns the summary of a histogram data point.
+func NewHistogramSummary(dp pmetric.HistogramDataPoint) HistogramSummary {
+	return HistogramSummary{
+		Count:  dp.Count(),
+		Sum:    dp.Sum(),
+		Min:    dp.Min(),
+		Max:    dp.Max(),
+		HasSum: dp.HasSum(),
+		HasMin: dp.HasMin(),
+		HasMax: dp.HasMax(),
+	}
+}
+
+// NewExponentialHistogramSummary returns the summary of an exponential histogram data point.
+func NewExponentialHistogramSummary(dp pmetric.ExponentialHistogramDataPoint) HistogramSummary {
+	return HistogramSummary{
+		Count:  dp.Count(),
+		Sum:    dp.Sum(),
+		Min:    dp.Min(),
+		Max:    dp.Max(),
+		HasSum: dp.HasSum(),
+		HasMin: dp.HasMin(),
+		HasMax: dp.HasMax(),
+	}
+}
+
+// Merge adds the other summary to s.
+func (s *HistogramSummary) Merge(other HistogramSummary) {
+	s.Count += other.Count
+	s.Sum += other.Sum
+	s.Min = math.Min(s.Min, other.Min)
+	s.Max = math.Max(s.Max, other.Max)
+	s.HasSum = s.HasSum && other.HasSum
+	s.HasMin = s.HasMin && other.HasMin
+	s.HasMax = s.HasMax && other.HasMax
+}
+
+// CopyToHistogram sets the count, and the sum, min and max that are kept, of the data point.
+func (s HistogramSummary) CopyToHistogram(dp pmetric.HistogramDataPoint) {
+	dp.SetCount(s.Count)
+	if s.HasSum {
+		dp.SetSum(s.Sum)
+	}
+	if s.HasMin {
+		dp.SetMin(s.Min)
+	}
+	if s.HasMax {
+		dp.SetMax(s.Max)
+	}
+}
+
+// CopyToExponentialHistogram sets the count, and the sum, min and max that are kept, of the data point.
+func (s HistogramSummary) CopyToExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetCount(s.Count)
+	if s.HasSum {
+		dp.SetSum(s.Sum)
+	}
+	if s.HasMin {
+		dp.SetMin(s.Min)
+	}
+	if s.HasMax {
+		dp.SetMax(s.Max)
+	}
+}
+
+// ExplicitBuckets are the bucket boundaries and counts of a histogram.
+type ExplicitBuckets struct {
+	Bounds []float64
+	Counts []uint64
+}
+
+// NewExplicitBuckets returns a copy of the buckets of a histogram data point.
+func NewExplicitBuckets(dp pmetric.HistogramDataPoint) ExplicitBuckets {
+	return ExplicitBuckets{Bounds: dp.ExplicitBounds().AsRaw(), Counts: dp.BucketCounts().AsRaw()}
+}
+
+// Clone returns a copy of the buckets whose counts can be merged without changing b.
+func (b ExplicitBuckets) Clone() ExplicitBuckets {
+	return ExplicitBuckets{Bounds: b.Bounds, Counts: append([]uint64(nil), b.Counts...)}
+}
+
+// HasSameBounds returns whether both buckets have the same boundaries, which is required to merge them.
+func (b ExplicitBuckets) HasSameBounds(other ExplicitBuckets) bool {
+	if len(b.Counts) != len(other.Counts) || len(b.Bounds) != len(other.Bounds) {
+		return false
+	}
+	for i, bound := range b.Bounds {
+		if other.Bounds[i] != bound {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds the counts of the other buckets, which must have the same boundaries, to b.
+func (b ExplicitBuckets) Merge(other ExplicitBuckets) {
+	for i := range b.Counts {
+		b.Counts[i] += other.Counts[i]
+	}
+}
+
+// CopyTo sets the bucket boundaries and counts of the data point.
+func (b ExplicitBuckets) CopyTo(dp pmetric.HistogramDataPoint) {
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(b.Bounds))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(b.Counts))
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newHistogramDataPoint(count uint64, sum, min, max float64, bounds []float64, counts []uint64) pmetric.HistogramDataPoint {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(min)
+	dp.SetMax(max)
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+	return dp
+}
+
+func TestHistogramSummaryMerge(t *testing.T) {
+	summary := NewHistogramSummary(newHistogramDataPoint(2, 3, 1, 2, nil, nil))
+	summary.Merge(NewHistogramSummary(newHistogramDataPoint(3, 10, 0.5, 5, nil, nil)))
+
+	dp := pmetric.NewHistogramDataPoint()
+	summary.CopyToHistogram(dp)
+	assert.Equal(t, newHistogramDataPoint(5, 13, 0.5, 5, nil, nil), dp)
+
+	// The sum, min and max are dropped once a merged data point doesn't have them.
+	other := pmetric.NewExponentialHistogramDataPoint()
+	other.SetCount(1)
+	summary.Merge(NewExponentialHistogramSummary(other))
+	edp := pmetric.NewExponentialHistogramDataPoint()
+	summary.CopyToExponentialHistogram(edp)
+	assert.EqualValues(t, 6, edp.Count())
+	assert.False(t, edp.HasSum())
+	assert.False(t, edp.HasMin())
+	assert.False(t, edp.HasMax())
+}
+
+func TestExplicitBucketsMerge(t *testing.T) {
+	buckets := NewExplicitBuckets(newHistogramDataPoint(3, 0, 0, 0, []float64{1, 2}, []uint64{1, 2, 0}))
+	other := NewExplicitBuckets(newHistogramDataPoint(4, 0, 0, 0, []float64{1, 2}, []uint64{0, 1, 3}))
+	assert.True(t, buckets.HasSameBounds(other))
+	assert.False(t, buckets.HasSameBounds(NewExplicitBuckets(newHistogramDataPoint(1, 0, 0, 0, []float64{1, 3}, []uint64{0, 1, 0}))))
+	assert.False(t, buckets.HasSameBounds(NewExplicitBuckets(newHistogramDataPoint(1, 0, 0, 0, []float64{1}, []uint64{0, 1}))))
+
+	merged := buckets.Clone()
+	merged.Merge(other)
+	assert.Equal(t, []uint64{1, 2, 0}, buckets.Counts)
+
+	dp := pmetric.NewHistogramDataPoint()
+	merged.CopyTo(dp)
+	assert.Equal(t, []float64{1, 2}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 3, 3}, dp.BucketCounts().AsRaw())
+}
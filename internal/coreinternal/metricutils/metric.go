@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// CopyMetricDescriptor copies everything but the data points of src to dest.
+func CopyMetricDescriptor(src pmetric.Metric, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	dest.SetDataType(src.DataType())
+
+	switch src.DataType() {
+	case pmetric.MetricDataTypeSum:
+		dest.Sum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		dest.Histogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dest.ExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	}
+}
+
+// DataPointCount returns the number of data points of m.
+func DataPointCount(m pmetric.Metric) int {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricDataTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricDataTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+// DataPointAt returns the data point at index i of m, e.g. a pmetric.NumberDataPoint for a gauge.
+func DataPointAt(m pmetric.Metric, i int) interface{} {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		return m.Gauge().DataPoints().At(i)
+	case pmetric.MetricDataTypeSum:
+		return m.Sum().DataPoints().At(i)
+	case pmetric.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().At(i)
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().At(i)
+	case pmetric.MetricDataTypeSummary:
+		return m.Summary().DataPoints().At(i)
+	}
+	return nil
+}
+
+// CopyDataPoint appends a copy of the data point at index i of src to dest, which must have the same type.
+func CopyDataPoint(src pmetric.Metric, i int, dest pmetric.Metric) {
+	switch src.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		src.Gauge().DataPoints().At(i).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+	case pmetric.MetricDataTypeSum:
+		src.Sum().DataPoints().At(i).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+	case pmetric.MetricDataTypeHistogram:
+		src.Histogram().DataPoints().At(i).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		src.ExponentialHistogram().DataPoints().At(i).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+	case pmetric.MetricDataTypeSummary:
+		src.Summary().DataPoints().At(i).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestCopyMetricDescriptor(t *testing.T) {
+	src := pmetric.NewMetric()
+	src.SetName("requests")
+	src.SetDescription("number of requests")
+	src.SetUnit("1")
+	src.SetDataType(pmetric.MetricDataTypeSum)
+	src.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	src.Sum().SetIsMonotonic(true)
+	src.Sum().DataPoints().AppendEmpty().SetIntVal(1)
+
+	dest := pmetric.NewMetric()
+	CopyMetricDescriptor(src, dest)
+	assert.Equal(t, "requests", dest.Name())
+	assert.Equal(t, "number of requests", dest.Description())
+	assert.Equal(t, "1", dest.Unit())
+	assert.Equal(t, pmetric.MetricDataTypeSum, dest.DataType())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, dest.Sum().AggregationTemporality())
+	assert.True(t, dest.Sum().IsMonotonic())
+	assert.Equal(t, 0, dest.Sum().DataPoints().Len())
+}
+
+func TestCopyDataPoint(t *testing.T) {
+	for _, dataType := range []pmetric.MetricDataType{
+		pmetric.MetricDataTypeGauge,
+		pmetric.MetricDataTypeSum,
+		pmetric.MetricDataTypeHistogram,
+		pmetric.MetricDataTypeExponentialHistogram,
+		pmetric.MetricDataTypeSummary,
+	} {
+		t.Run(dataType.String(), func(t *testing.T) {
+			src := pmetric.NewMetric()
+			src.SetDataType(dataType)
+			dest := pmetric.NewMetric()
+			CopyMetricDescriptor(src, dest)
+			assert.Equal(t, 0, DataPointCount(src))
+
+			switch dataType {
+			case pmetric.MetricDataTypeGauge:
+				dp := src.Gauge().DataPoints()
+				dp.AppendEmpty().Attributes().InsertString("a", "0")
+				dp.AppendEmpty().Attributes().InsertString("a", "1")
+			case pmetric.MetricDataTypeSum:
+				dp := src.Sum().DataPoints()
+				dp.AppendEmpty().Attributes().InsertString("a", "0")
+				dp.AppendEmpty().Attributes().InsertString("a", "1")
+			case pmetric.MetricDataTypeHistogram:
+				dp := src.Histogram().DataPoints()
+				dp.AppendEmpty().Attributes().InsertString("a", "0")
+				dp.AppendEmpty().Attributes().InsertString("a", "1")
+			case pmetric.MetricDataTypeExponentialHistogram:
+				dp := src.ExponentialHistogram().DataPoints()
+				dp.AppendEmpty().Attributes().InsertString("a", "0")
+				dp.AppendEmpty().Attributes().InsertString("a", "1")
+			case pmetric.MetricDataTypeSummary:
+				dp := src.Summary().DataPoints()
+				dp.AppendEmpty().Attributes().InsertString("a", "0")
+				dp.AppendEmpty().Attributes().InsertString("a", "1")
+			}
+			assert.Equal(t, 2, DataPointCount(src))
+			assert.NotNil(t, DataPointAt(src, 1))
+
+			CopyDataPoint(src, 1, dest)
+			assert.Equal(t, 1, DataPointCount(dest))
+			assert.Equal(t, DataPointAt(src, 1), DataPointAt(dest, 0))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/hetzner"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Hetzner Cloud metadata service endpoint, see
+	// https://docs.hetzner.cloud/#server-metadata
+	metadataEndpoint = "http://169.254.169.254/hetzner/v1/metadata"
+)
+
+// Provider gets metadata from the Hetzner Cloud metadata service.
+type Provider interface {
+	Metadata(context.Context) (*Metadata, error)
+}
+
+type hetznerProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ Provider = (*hetznerProviderImpl)(nil)
+
+// NewProvider creates a new metadata provider querying the metadata service with the given client
+func NewProvider(client *http.Client) Provider {
+	return &hetznerProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// Metadata holds the server metadata
+type Metadata struct {
+	InstanceID       string
+	Hostname         string
+	Region           string
+	AvailabilityZone string
+}
+
+// Metadata queries the metadata service for each of the values
+func (p *hetznerProviderImpl) Metadata(ctx context.Context) (*Metadata, error) {
+	metadata := &Metadata{}
+	for key, value := range map[string]*string{
+		"instance-id":       &metadata.InstanceID,
+		"hostname":          &metadata.Hostname,
+		"region":            &metadata.Region,
+		"availability-zone": &metadata.AvailabilityZone,
+	} {
+		var err error
+		if *value, err = p.get(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// get returns the plain text value of a single metadata key
+func (p *hetznerProviderImpl) get(ctx context.Context, key string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/"+key, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query Hetzner metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 Hetzner is a capitalized proper noun here
+		return "", fmt.Errorf("Hetzner metadata service replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Hetzner metadata service reply: %w", err)
+	}
+	return strings.TrimSpace(string(respBody)), nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(&http.Client{})
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{
+		endpoint: ts.URL,
+		client:   ts.Client(),
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	values := map[string]string{
+		"/hetzner/v1/metadata/instance-id":       "42",
+		"/hetzner/v1/metadata/hostname":          "server-1",
+		"/hetzner/v1/metadata/region":            "eu-central",
+		"/hetzner/v1/metadata/availability-zone": "fsn1-dc14",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := values[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, value)
+	}))
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{
+		endpoint: ts.URL + "/hetzner/v1/metadata",
+		client:   ts.Client(),
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		InstanceID:       "42",
+		Hostname:         "server-1",
+		Region:           "eu-central",
+		AvailabilityZone: "fsn1-dc14",
+	}, metadata)
+}
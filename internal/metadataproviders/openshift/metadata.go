@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openshift"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// infrastructurePath is the API path of the cluster infrastructure configuration, see
+	// https://docs.openshift.com/container-platform/4.11/rest_api/config_apis/infrastructure-config-openshift-io-v1.html
+	infrastructurePath = "/apis/config.openshift.io/v1/infrastructures/cluster"
+)
+
+// Provider gets metadata from the OpenShift API server.
+type Provider interface {
+	Infrastructure(context.Context) (*InfrastructureAPIResponse, error)
+}
+
+type openshiftProviderImpl struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+var _ Provider = (*openshiftProviderImpl)(nil)
+
+// NewProvider creates a new metadata provider querying the API server at address
+// with the given client, authenticating with the bearer token.
+func NewProvider(address, token string, client *http.Client) Provider {
+	return &openshiftProviderImpl{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+// InfrastructureAPIResponse is the subset of the cluster infrastructure configuration
+// used to describe the cluster
+type InfrastructureAPIResponse struct {
+	Status InfrastructureStatus `json:"status"`
+}
+
+// InfrastructureStatus holds the cluster name and the platform it runs on
+type InfrastructureStatus struct {
+	InfrastructureName string         `json:"infrastructureName"`
+	PlatformStatus     PlatformStatus `json:"platformStatus"`
+}
+
+// PlatformStatus holds the type of the platform and its details
+type PlatformStatus struct {
+	// Type is the underlying infrastructure provider, for example AWS, Azure, GCP, IBMCloud or OpenStack
+	Type     string                  `json:"type"`
+	AWS      *RegionPlatformStatus   `json:"aws,omitempty"`
+	GCP      *RegionPlatformStatus   `json:"gcp,omitempty"`
+	IBMCloud *LocationPlatformStatus `json:"ibmcloud,omitempty"`
+}
+
+// RegionPlatformStatus holds the region of the AWS and GCP platforms
+type RegionPlatformStatus struct {
+	Region string `json:"region"`
+}
+
+// LocationPlatformStatus holds the location of the IBM Cloud platform
+type LocationPlatformStatus struct {
+	Location string `json:"location"`
+}
+
+// Infrastructure queries the API server for the cluster infrastructure configuration
+func (p *openshiftProviderImpl) Infrastructure(ctx context.Context) (*InfrastructureAPIResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+infrastructurePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenShift API server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 OpenShift is a capitalized proper noun here
+		return nil, fmt.Errorf("OpenShift API server replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenShift API server reply: %w", err)
+	}
+
+	var infra InfrastructureAPIResponse
+	if err = json.Unmarshal(respBody, &infra); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenShift API server reply: %w", err)
+	}
+	return &infra, nil
+}
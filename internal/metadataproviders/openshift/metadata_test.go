@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("https://127.0.0.1:6443", "token", &http.Client{})
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	provider := NewProvider(ts.URL, "token", ts.Client())
+
+	_, err := provider.Infrastructure(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "null")
+	}))
+	defer ts.Close()
+
+	provider := NewProvider(ts.URL, "token", ts.Client())
+
+	infra, err := provider.Infrastructure(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InfrastructureAPIResponse{}, infra)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/config.openshift.io/v1/infrastructures/cluster" || r.Header.Get("Authorization") != "Bearer token" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{
+			"apiVersion": "config.openshift.io/v1",
+			"kind": "Infrastructure",
+			"status": {
+				"infrastructureName": "ocp-cluster-r4b9x",
+				"platform": "AWS",
+				"platformStatus": {"type": "AWS", "aws": {"region": "us-east-1"}}
+			}
+		}`)
+	}))
+	defer ts.Close()
+
+	provider := NewProvider(ts.URL+"/", "token", ts.Client())
+
+	infra, err := provider.Infrastructure(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InfrastructureAPIResponse{
+		Status: InfrastructureStatus{
+			InfrastructureName: "ocp-cluster-r4b9x",
+			PlatformStatus: PlatformStatus{
+				Type: "AWS",
+				AWS:  &RegionPlatformStatus{Region: "us-east-1"},
+			},
+		},
+	}, infra)
+}
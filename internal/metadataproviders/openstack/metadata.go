@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// OpenStack metadata service endpoint, see
+	// https://docs.openstack.org/nova/latest/user/metadata.html#metadata-service
+	metadataEndpoint = "http://169.254.169.254/openstack/latest/meta_data.json"
+)
+
+// Provider gets metadata from the OpenStack metadata service.
+type Provider interface {
+	Metadata(context.Context) (*Metadata, error)
+}
+
+type openstackProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ Provider = (*openstackProviderImpl)(nil)
+
+// NewProvider creates a new metadata provider querying the metadata service with the given client
+func NewProvider(client *http.Client) Provider {
+	return &openstackProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// Metadata is the OpenStack metadata service response format
+type Metadata struct {
+	UUID             string `json:"uuid"`
+	Name             string `json:"name"`
+	Hostname         string `json:"hostname"`
+	AvailabilityZone string `json:"availability_zone"`
+	ProjectID        string `json:"project_id"`
+}
+
+// Metadata queries the metadata service and parses its reply
+func (p *openstackProviderImpl) Metadata(ctx context.Context) (*Metadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenStack metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 OpenStack is a capitalized proper noun here
+		return nil, fmt.Errorf("OpenStack metadata service replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenStack metadata service reply: %w", err)
+	}
+
+	var metadata Metadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenStack metadata service reply: %w", err)
+	}
+	return &metadata, nil
+}
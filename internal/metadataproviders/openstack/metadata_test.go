@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(&http.Client{})
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   ts.Client(),
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{")
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   ts.Client(),
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "null")
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   ts.Client(),
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{}, metadata)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"uuid": "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+			"name": "test",
+			"hostname": "test.novalocal",
+			"availability_zone": "nova",
+			"project_id": "f7ac731cc11f40efbc03a9f9e1d1d21f",
+			"launch_index": 0,
+			"meta": {"role": "webserver"}
+		}`)
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   ts.Client(),
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		Name:             "test",
+		Hostname:         "test.novalocal",
+		AvailabilityZone: "nova",
+		ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+	}, metadata)
+}
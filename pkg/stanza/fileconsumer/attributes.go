@@ -25,6 +25,8 @@ type FileAttributes struct {
 	Path         string
 	NameResolved string
 	PathResolved string
+	// Header is the header of the file, when the header of the files is configured
+	Header string
 }
 
 // resolveFileAttributes resolves file attributes
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone  = ""
+	compressionAuto  = "auto"
+	compressionGzip  = "gzip"
+	compressionZstd  = "zstd"
+	compressionBzip2 = "bzip2"
+)
+
+var compressionExtensions = map[string]string{
+	".gz":   compressionGzip,
+	".gzip": compressionGzip,
+	".zst":  compressionZstd,
+	".zstd": compressionZstd,
+	".bz2":  compressionBzip2,
+}
+
+var compressionMagicBytes = map[string][]byte{
+	compressionGzip:  {0x1f, 0x8b},
+	compressionZstd:  {0x28, 0xb5, 0x2f, 0xfd},
+	compressionBzip2: []byte("BZh"),
+}
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionAuto, compressionGzip, compressionZstd, compressionBzip2:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// detectCompression returns the compression of the file, according to the configured compression.
+// In the auto mode, the compression is chosen by the file extension, or by the first bytes of the file
+// for the files without a known extension. An empty string is returned for the plain-text files.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != compressionAuto {
+		return compression, nil
+	}
+
+	if detected, ok := compressionExtensions[strings.ToLower(filepath.Ext(file.Name()))]; ok {
+		return detected, nil
+	}
+
+	buf := make([]byte, 4)
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic bytes: %w", err)
+	}
+	for _, detected := range []string{compressionGzip, compressionZstd, compressionBzip2} {
+		if bytes.HasPrefix(buf[:n], compressionMagicBytes[detected]) {
+			return detected, nil
+		}
+	}
+	return compressionNone, nil
+}
+
+// newDecompressor returns a reader of the decompressed content of the file, starting at its beginning,
+// without changing the current offset of the file.
+func newDecompressor(file *os.File, compression string) (io.ReadCloser, error) {
+	src := io.NewSectionReader(file, 0, math.MaxInt64)
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(src)
+	case compressionZstd:
+		decoder, err := zstd.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case compressionBzip2:
+		return io.NopCloser(bzip2.NewReader(src)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// truncatedStreamReader reports the end of a compressed stream that is still being written,
+// such as a file in the middle of its compression by logrotate, as a regular EOF. The rest
+// of the content is then read during a later poll, once the file is complete.
+type truncatedStreamReader struct {
+	io.ReadCloser
+	truncated bool
+}
+
+func (r *truncatedStreamReader) Read(dst []byte) (int, error) {
+	n, err := r.ReadCloser.Read(dst)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		r.truncated = true
+		return n, io.EOF
+	}
+	return n, err
+}
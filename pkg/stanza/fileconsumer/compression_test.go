@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+// bzip2Hello is "hello\n" compressed with bzip2, since the standard library can only decompress bzip2
+var bzip2Hello = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xc1, 0xc0, 0x80, 0xe2, 0x00, 0x00,
+	0x01, 0x41, 0x00, 0x00, 0x10, 0x02, 0x44, 0xa0, 0x00, 0x30, 0xcd, 0x00, 0xc3, 0x46, 0x29, 0x97,
+	0x17, 0x72, 0x45, 0x38, 0x50, 0x90, 0xc1, 0xc0, 0x80, 0xe2,
+}
+
+func gzipBytes(t testing.TB, content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t testing.TB, content string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeFile(t testing.TB, path string, content []byte) *os.File {
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	return openFile(t, path)
+}
+
+func TestDetectCompression(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fileName    string
+		content     []byte
+		compression string
+		expected    string
+	}{
+		{"none", "log.gz", gzipBytes(t, "hello\n"), compressionNone, compressionNone},
+		{"explicit", "log", gzipBytes(t, "hello\n"), compressionGzip, compressionGzip},
+		{"auto_plain", "log", []byte("hello\n"), compressionAuto, compressionNone},
+		{"auto_empty", "log", []byte{}, compressionAuto, compressionNone},
+		{"auto_gzip_extension", "log.GZ", []byte{}, compressionAuto, compressionGzip},
+		{"auto_zstd_extension", "log.zst", []byte{}, compressionAuto, compressionZstd},
+		{"auto_bzip2_extension", "log.bz2", []byte{}, compressionAuto, compressionBzip2},
+		{"auto_gzip_magic", "log.1", gzipBytes(t, "hello\n"), compressionAuto, compressionGzip},
+		{"auto_zstd_magic", "log.1", zstdBytes(t, "hello\n"), compressionAuto, compressionZstd},
+		{"auto_bzip2_magic", "log.1", bzip2Hello, compressionAuto, compressionBzip2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file := writeFile(t, filepath.Join(t.TempDir(), tc.fileName), tc.content)
+			compression, err := detectCompression(file, tc.compression)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, compression)
+		})
+	}
+}
+
+func TestDecompressedFingerprint(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     []byte
+		compression string
+	}{
+		{"gzip", gzipBytes(t, "hello\n"), compressionGzip},
+		{"zstd", zstdBytes(t, "hello\n"), compressionZstd},
+		{"bzip2", bzip2Hello, compressionBzip2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file := writeFile(t, filepath.Join(t.TempDir(), "log"), tc.content)
+			fp, err := newDecompressedFingerprint(file, tc.compression, DefaultFingerprintSize)
+			require.NoError(t, err)
+			require.Equal(t, []byte("hello\n"), fp.FirstBytes)
+		})
+	}
+}
+
+func TestDecompressedFingerprintIncompleteFile(t *testing.T) {
+	content := gzipBytes(t, "hello\n")
+
+	file := writeFile(t, filepath.Join(t.TempDir(), "log.gz"), content[:5])
+	fp, err := newDecompressedFingerprint(file, compressionGzip, DefaultFingerprintSize)
+	require.NoError(t, err)
+	require.Empty(t, fp.FirstBytes)
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	writeFile(t, filepath.Join(tempDir, "a.log.gz"), gzipBytes(t, "testlog1\n"))
+	writeFile(t, filepath.Join(tempDir, "b.log.zst"), zstdBytes(t, "testlog2\n"))
+	writeFile(t, filepath.Join(tempDir, "c.log.bz2"), bzip2Hello)
+	writeFile(t, filepath.Join(tempDir, "d.log"), []byte("testlog3\n"))
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2"), []byte("hello"), []byte("testlog3")})
+}
+
+// TestReadRotatedAndCompressedFile tests that a file that is compressed after its rotation
+// is recognized as the same file, and that only the lines that were not read yet are emitted
+func TestReadRotatedAndCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	logPath := filepath.Join(tempDir, "app.log")
+	temp := openFile(t, logPath)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	// Lines written just before the rotation are not read before the file is compressed
+	writeString(t, temp, "testlog3\n")
+	require.NoError(t, temp.Close())
+	writeFile(t, filepath.Join(tempDir, "app.log.1.gz"), gzipBytes(t, "testlog1\ntestlog2\ntestlog3\n"))
+	require.NoError(t, os.Remove(logPath))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedReadOnce(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	cfg.CompressedReadOnce = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	writeFile(t, filepath.Join(tempDir, "app.log.gz"), gzipBytes(t, "testlog1\ntestlog2\n"))
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	require.NotEmpty(t, operator.knownFiles)
+	require.True(t, operator.knownFiles[len(operator.knownFiles)-1].readCompletely)
+}
+
+// TestCompressedFileReadAgainWhenChanged tests that a compressed file that was read completely is
+// only decompressed again when its size changes, even if compressed_read_once is not set
+func TestCompressedFileReadAgainWhenChanged(t *testing.T) {
+	f, emitChan := testReaderFactory(t)
+	f.readerConfig.compression = compressionGzip
+
+	content := gzipBytes(t, "testlog1\n")
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	r, err := f.newReaderBuilder().withFile(writeFile(t, path, content)).build()
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	require.True(t, r.readCompletely)
+
+	// The content can't be decompressed anymore, which is only noticed if the file is read again
+	require.NoError(t, os.WriteFile(path, make([]byte, len(content)), 0600))
+	r.ReadToEnd(context.Background())
+	require.True(t, r.readCompletely, "a file whose size didn't change must not be decompressed again")
+
+	require.NoError(t, os.WriteFile(path, make([]byte, len(content)+1), 0600))
+	r.ReadToEnd(context.Background())
+	require.False(t, r.readCompletely, "a file whose size changed must be decompressed again")
+}
+
+// TestReadIncompleteCompressedFile tests that a file that is still being compressed is read
+// up to its last complete block, and that the rest of its content is read once it is complete
+func TestReadIncompleteCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	cfg.CompressedReadOnce = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	// The first member of the gzip stream is complete, the second one is not
+	first := gzipBytes(t, "testlog1\n")
+	second := gzipBytes(t, "testlog2\n")
+	path := filepath.Join(tempDir, "app.log.gz")
+	writeFile(t, path, append(append([]byte{}, first...), second[:12]...))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	expectNoTokens(t, emitCalls)
+
+	writeFile(t, path, append(append([]byte{}, first...), second...))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
@@ -58,6 +58,11 @@ type Config struct {
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"                   json:"max_log_size,omitempty"                  yaml:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"           json:"max_concurrent_files,omitempty"          yaml:"max_concurrent_files,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"                        json:",inline,omitempty"                       yaml:",inline,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"                    json:"compression,omitempty"                   yaml:"compression,omitempty"`
+	CompressedReadOnce      bool                  `mapstructure:"compressed_read_once,omitempty"           json:"compressed_read_once,omitempty"          yaml:"compressed_read_once,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"              json:"delete_after_read,omitempty"             yaml:"delete_after_read,omitempty"`
+	MoveAfterRead           string                `mapstructure:"move_after_read,omitempty"                json:"move_after_read,omitempty"               yaml:"move_after_read,omitempty"`
+	Header                  *HeaderConfig         `mapstructure:"header,omitempty"                         json:"header,omitempty"                        yaml:"header,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -106,6 +111,15 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, err
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return nil, err
+	}
+
+	header, err := c.Header.build()
+	if err != nil {
+		return nil, err
+	}
+
 	var startAtBeginning bool
 	switch c.StartAt {
 	case "beginning":
@@ -116,24 +130,39 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	if c.DeleteAfterRead && c.MoveAfterRead != "" {
+		return nil, fmt.Errorf("`delete_after_read` and `move_after_read` cannot be used together")
+	}
+
+	readOnce := c.DeleteAfterRead || c.MoveAfterRead != ""
+	if readOnce && !startAtBeginning {
+		return nil, fmt.Errorf("`delete_after_read` and `move_after_read` require `start_at: beginning`")
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
 		readerFactory: readerFactory{
 			SugaredLogger: logger.With("component", "fileconsumer"),
 			readerConfig: &readerConfig{
-				fingerprintSize: int(c.FingerprintSize),
-				maxLogSize:      int(c.MaxLogSize),
-				emit:            emit,
+				fingerprintSize:    int(c.FingerprintSize),
+				maxLogSize:         int(c.MaxLogSize),
+				compression:        c.Compression,
+				compressedReadOnce: c.CompressedReadOnce,
+				header:             header,
+				emit:               emit,
 			},
 			fromBeginning:  startAtBeginning,
+			flushAtEOF:     readOnce,
 			splitterConfig: c.Splitter,
 		},
-		finder:        c.Finder,
-		roller:        newRoller(),
-		pollInterval:  c.PollInterval.Raw(),
-		maxBatchFiles: c.MaxConcurrentFiles / 2,
-		knownFiles:    make([]*Reader, 0, 10),
-		seenPaths:     make(map[string]struct{}, 100),
+		finder:          c.Finder,
+		roller:          newRoller(),
+		pollInterval:    c.PollInterval.Raw(),
+		maxBatchFiles:   c.MaxConcurrentFiles / 2,
+		deleteAfterRead: c.DeleteAfterRead,
+		moveAfterRead:   c.MoveAfterRead,
+		knownFiles:      make([]*Reader, 0, 10),
+		seenPaths:       make(map[string]struct{}, 100),
 	}, nil
 }
@@ -383,6 +383,36 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "compression_auto",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Compression = "auto"
+				cfg.CompressedReadOnce = true
+				return cfg
+			}(),
+		},
+		{
+			Name:      "delete_after_read",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.StartAt = "beginning"
+				cfg.DeleteAfterRead = true
+				return cfg
+			}(),
+		},
+		{
+			Name:      "move_after_read",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.StartAt = "beginning"
+				cfg.MoveAfterRead = "/var/log/archive"
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -519,6 +549,67 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"ValidCompression",
+			func(f *Config) {
+				f.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "zstd", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "zip"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterRead",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.True(t, f.deleteAfterRead)
+				require.True(t, f.readerFactory.flushAtEOF)
+			},
+		},
+		{
+			"MoveAfterRead",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.MoveAfterRead = "/var/log/archive"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "/var/log/archive", f.moveAfterRead)
+				require.True(t, f.readerFactory.flushAtEOF)
+			},
+		},
+		{
+			"DeleteAfterReadStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.DeleteAfterRead = true
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAndMoveAfterRead",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+				f.MoveAfterRead = "/var/log/archive"
+			},
+			require.Error,
+			nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -18,8 +18,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -43,6 +46,11 @@ type Manager struct {
 	pollInterval  time.Duration
 	maxBatchFiles int
 
+	// deleteAfterRead and moveAfterRead remove the files once they are read to the end,
+	// instead of tailing them
+	deleteAfterRead bool
+	moveAfterRead   string
+
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
 }
@@ -142,6 +150,18 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	// Any new files that appear should be consumed entirely
 	m.readerFactory.fromBeginning = true
 
+	if m.deleteAfterRead || m.moveAfterRead != "" {
+		// The offsets are synced before the files are removed, so that a file that was
+		// not read completely, e.g. due to a crash, is resumed from the last checkpoint
+		m.saveCurrent(readers)
+		m.syncLastPollFiles(ctx)
+		if m.removeReadFiles(readers) {
+			// The removed files were forgotten, their offsets must not be applied to new files after a restart
+			m.syncLastPollFiles(ctx)
+		}
+		return
+	}
+
 	m.roller.roll(ctx, readers)
 	m.saveCurrent(readers)
 	m.syncLastPollFiles(ctx)
@@ -223,6 +243,78 @@ OUTER:
 	return readers
 }
 
+// removeReadFiles closes the readers, then deletes or moves to the archive directory
+// the files that were read to the end, and forgets them. The other files are read again
+// during the next poll. It returns whether any file was removed.
+func (m *Manager) removeReadFiles(readers []*Reader) bool {
+	removed := false
+	for _, reader := range readers {
+		path := reader.file.Name()
+		reader.Close()
+		if !reader.readCompletely {
+			continue
+		}
+
+		if m.deleteAfterRead {
+			if err := os.Remove(path); err != nil {
+				m.Errorw("Failed to delete file", "path", path, zap.Error(err))
+				continue
+			}
+			m.Debugw("Deleted file after reading it", "path", path)
+		} else {
+			if err := os.MkdirAll(m.moveAfterRead, 0750); err != nil {
+				m.Errorw("Failed to create archive directory", "directory", m.moveAfterRead, zap.Error(err))
+				continue
+			}
+			target, err := archivePath(m.moveAfterRead, path)
+			if err != nil {
+				m.Errorw("Failed to find a path in the archive directory", "path", path, zap.Error(err))
+				continue
+			}
+			if err := os.Rename(path, target); err != nil {
+				m.Errorw("Failed to move file", "path", path, "target", target, zap.Error(err))
+				continue
+			}
+			m.Debugw("Moved file after reading it", "path", path, "target", target)
+		}
+		delete(m.seenPaths, path)
+		m.forgetReader(reader)
+		removed = true
+	}
+	return removed
+}
+
+// archivePath returns the path to which a file is moved in the archive directory. A number
+// is added before the extension of the file when another file with the same name was archived.
+func archivePath(dir, path string) (string, error) {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+
+	target := filepath.Join(dir, base)
+	for i := 1; ; i++ {
+		_, err := os.Lstat(target)
+		if errors.Is(err, os.ErrNotExist) {
+			return target, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		target = filepath.Join(dir, fmt.Sprintf("%s.%d%s", name, i, ext))
+	}
+}
+
+// forgetReader removes the reader of a file that was removed from the known files,
+// so that a new file with the same fingerprint is read from its beginning
+func (m *Manager) forgetReader(reader *Reader) {
+	for i, known := range m.knownFiles {
+		if known == reader {
+			m.knownFiles = append(m.knownFiles[:i], m.knownFiles[i+1:]...)
+			return
+		}
+	}
+}
+
 // saveCurrent adds the readers from this polling interval to this list of
 // known files, then increments the generation of all tracked old readers
 // before clearing out readers that have existed for 3 generations.
@@ -1180,3 +1180,163 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2")
+	temp2 := openTemp(t, tempDir)
+	writeString(t, temp2, "testlog3\n")
+
+	operator.poll(context.Background())
+	// The last entry is emitted, even though it is not terminated
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2"), []byte("testlog3")})
+
+	require.NoFileExists(t, temp.Name())
+	require.NoFileExists(t, temp2.Name())
+	require.Empty(t, operator.seenPaths)
+}
+
+func TestMoveAfterRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	archiveDir := filepath.Join(t.TempDir(), "archive")
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MoveAfterRead = archiveDir
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	require.NoFileExists(t, temp.Name())
+	content, err := os.ReadFile(filepath.Join(archiveDir, filepath.Base(temp.Name())))
+	require.NoError(t, err)
+	require.Equal(t, "testlog1\n", string(content))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+// TestMoveAfterReadSameName tests that archived files with the same name are not overwritten
+func TestMoveAfterReadSameName(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	archiveDir := filepath.Join(t.TempDir(), "archive")
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MoveAfterRead = archiveDir
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "app.log")
+	writeFile(t, path, []byte("testlog1\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	writeFile(t, path, []byte("testlog2\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	content, err := os.ReadFile(filepath.Join(archiveDir, "app.log"))
+	require.NoError(t, err)
+	require.Equal(t, "testlog1\n", string(content))
+	content, err = os.ReadFile(filepath.Join(archiveDir, "app.1.log"))
+	require.NoError(t, err)
+	require.Equal(t, "testlog2\n", string(content))
+}
+
+// TestDeleteAfterReadForgetsFiles tests that a new file with the same fingerprint
+// as a deleted file is read from its beginning, instead of the offset of the deleted file
+func TestDeleteAfterReadForgetsFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "app.log")
+	writeFile(t, path, []byte("testlog1\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.Empty(t, operator.knownFiles)
+
+	writeFile(t, path, []byte("testlog1\ntestlog2\n"))
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+	require.NoFileExists(t, path)
+}
+
+// TestDeleteAfterReadKeepsIncompleteFiles tests that a file is not deleted
+// when its read is interrupted, and that it is read again during the next poll
+func TestDeleteAfterReadKeepsIncompleteFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	operator.poll(ctx)
+	expectNoTokens(t, emitCalls)
+	require.FileExists(t, temp.Name())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.NoFileExists(t, temp.Name())
+}
+
+// TestDeleteAfterReadResumesFromCheckpoint tests that the content of a file that was already
+// checkpointed before a restart is not emitted again before the file is deleted
+func TestDeleteAfterReadResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	persister := testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	operatorOne.persister = persister
+	operatorOne.poll(context.Background())
+	waitForToken(t, emitCallsOne, []byte("testlog1"))
+	require.NoError(t, operatorOne.Stop())
+
+	writeString(t, temp, "testlog2\n")
+
+	cfg.DeleteAfterRead = true
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	operatorTwo.persister = persister
+	require.NoError(t, operatorTwo.loadLastPollFiles(context.Background()))
+	operatorTwo.poll(context.Background())
+	waitForToken(t, emitCallsTwo, []byte("testlog2"))
+	expectNoTokens(t, emitCallsTwo)
+	require.NoFileExists(t, temp.Name())
+}
@@ -73,3 +73,28 @@ func (f Fingerprint) StartsWith(old *Fingerprint) bool {
 	}
 	return bytes.Equal(old.FirstBytes[:l0], f.FirstBytes[:l0])
 }
+
+// newDecompressedFingerprint creates a new fingerprint from the decompressed content of a compressed file,
+// so that a file that is compressed after rotation keeps the fingerprint of its plain-text content
+func newDecompressedFingerprint(file *os.File, compression string, size int) (*Fingerprint, error) {
+	decompressor, err := newDecompressor(file, compression)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// The compressed file is empty, or its header was not written yet
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+	defer decompressor.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(decompressor, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+
+	fp := &Fingerprint{
+		FirstBytes: buf[:n],
+	}
+
+	return fp, nil
+}
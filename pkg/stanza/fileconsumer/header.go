@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// HeaderConfig describes the lines of a file that are kept as its header, instead of being emitted.
+// The header of a file is passed along with each of its entries, in the FileAttributes.
+type HeaderConfig struct {
+	// FirstLine keeps the first line of each file as its header
+	FirstLine bool `mapstructure:"first_line,omitempty" json:"first_line,omitempty" yaml:"first_line,omitempty"`
+	// Pattern keeps the lines matching the regex as the header of the file. A group of consecutive
+	// matching lines replaces the previous header, so that the header can change in the middle of a file.
+	Pattern string `mapstructure:"pattern,omitempty" json:"pattern,omitempty" yaml:"pattern,omitempty"`
+}
+
+type headerMatcher struct {
+	firstLine bool
+	pattern   *regexp.Regexp
+}
+
+func (c *HeaderConfig) build() (*headerMatcher, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	switch {
+	case c.FirstLine && c.Pattern != "":
+		return nil, errors.New("only one of `header.first_line` or `header.pattern` can be set")
+	case c.FirstLine:
+		return &headerMatcher{firstLine: true}, nil
+	case c.Pattern != "":
+		pattern, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile `header.pattern`: %w", err)
+		}
+		return &headerMatcher{pattern: pattern}, nil
+	default:
+		return nil, errors.New("one of `header.first_line` or `header.pattern` must be set")
+	}
+}
+
+// isHeader returns whether the token read at the given offset of a file is a header line
+func (m *headerMatcher) isHeader(offset int64, token []byte) bool {
+	if m.firstLine {
+		return offset == 0
+	}
+	return m.pattern.Match(token)
+}
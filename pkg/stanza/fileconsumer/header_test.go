@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestHeaderConfigBuild(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *HeaderConfig
+		errMsg string
+	}{
+		{"first_line", &HeaderConfig{FirstLine: true}, ""},
+		{"pattern", &HeaderConfig{Pattern: "^#"}, ""},
+		{"empty", &HeaderConfig{}, "one of `header.first_line` or `header.pattern` must be set"},
+		{"both", &HeaderConfig{FirstLine: true, Pattern: "^#"}, "only one of `header.first_line` or `header.pattern` can be set"},
+		{"invalid_pattern", &HeaderConfig{Pattern: "("}, "compile `header.pattern`: error parsing regexp: missing closing ): `(`"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.config.build()
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestHeaderFirstLine(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = &HeaderConfig{FirstLine: true}
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "id,message\n1,first\n")
+
+	operator.poll(context.Background())
+	call := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("1,first"), call.token)
+	require.Equal(t, "id,message", call.attrs.Header)
+
+	// The header is kept for the entries read during the next polls
+	writeString(t, temp, "2,second\n")
+	operator.poll(context.Background())
+	call = waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("2,second"), call.token)
+	require.Equal(t, "id,message", call.attrs.Header)
+}
+
+// TestHeaderRestart tests that the header of a file is persisted along with its offset
+func TestHeaderRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	persister := testutil.NewMockPersister("test")
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = &HeaderConfig{Pattern: "^#"}
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: a b\n1 2\n")
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	call := waitForEmit(t, emitCallsOne)
+	require.Equal(t, []byte("1 2"), call.token)
+	require.Equal(t, "#Fields: a b", call.attrs.Header)
+	require.NoError(t, operatorOne.Stop())
+
+	writeString(t, temp, "3 4\n")
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	call = waitForEmit(t, emitCallsTwo)
+	require.Equal(t, []byte("3 4"), call.token)
+	require.Equal(t, "#Fields: a b", call.attrs.Header)
+	require.NoError(t, operatorTwo.Stop())
+}
@@ -17,6 +17,7 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -25,9 +26,12 @@ import (
 )
 
 type readerConfig struct {
-	fingerprintSize int
-	maxLogSize      int
-	emit            EmitFunc
+	fingerprintSize    int
+	maxLogSize         int
+	compression        string
+	compressedReadOnce bool
+	header             *headerMatcher
+	emit               EmitFunc
 }
 
 // Reader manages a single file
@@ -38,13 +42,41 @@ type Reader struct {
 
 	Fingerprint    *Fingerprint
 	Offset         int64
+	Header         string
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+
+	// fileCompression is the compression of the file, or an empty string for a plain-text file.
+	// The Offset and Fingerprint of a compressed file refer to its decompressed content.
+	fileCompression string
+	// src is the file, or the decompressed content of the file, being read
+	src io.Reader
+	// readCompletely is set when the last read of the file reached its end without error.
+	// For a compressed file, the end of its stream must have been reached as well.
+	readCompletely bool
+	// compressedSize is the size of the compressed file when it was last read completely
+	compressedSize int64
+	// inHeader is set while consecutive header lines are read
+	inHeader bool
 }
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.fileCompression != compressionNone {
+		decompressor, err := newDecompressor(r.file, r.fileCompression)
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		defer decompressor.Close()
+		size, err := io.Copy(io.Discard, &truncatedStreamReader{ReadCloser: decompressor})
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = size
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -55,18 +87,70 @@ func (r *Reader) offsetToEnd() error {
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	if r.fileCompression != compressionNone {
+		r.readCompressedToEnd(ctx)
+		return
+	}
+
+	r.readCompletely = false
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
 
+	r.src = r.file
+	r.readCompletely = r.readSourceToEnd(ctx)
+}
+
+// readCompressedToEnd decompresses the file from its beginning, skips the content that was
+// already read, and reads until the end of the decompressed content. A file that was read
+// completely is not decompressed again, unless its size changed and it may be tailed.
+func (r *Reader) readCompressedToEnd(ctx context.Context) {
+	if r.compressedReadOnce && r.readCompletely {
+		return
+	}
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	if r.readCompletely && info.Size() == r.compressedSize {
+		return
+	}
+
+	r.readCompletely = false
+	decompressor, err := newDecompressor(r.file, r.fileCompression)
+	if err != nil {
+		r.Errorw("Failed to decompress", zap.Error(err))
+		return
+	}
+	defer decompressor.Close()
+
+	src := &truncatedStreamReader{ReadCloser: decompressor}
+	if _, err = io.CopyN(io.Discard, src, r.Offset); err != nil {
+		if err != io.EOF {
+			r.Errorw("Failed to decompress", zap.Error(err))
+		}
+		return
+	}
+
+	r.src = src
+	r.readCompletely = r.readSourceToEnd(ctx) && !src.truncated
+	if r.readCompletely {
+		r.compressedSize = info.Size()
+	}
+}
+
+// readSourceToEnd will read until the end of the source of the reader.
+// It returns whether the end was reached, without error nor cancellation.
+func (r *Reader) readSourceToEnd(ctx context.Context) bool {
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return false
 		default:
 		}
 
@@ -74,14 +158,15 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := scanner.getError(); err != nil {
 				r.Errorw("Failed during scan", zap.Error(err))
+				return false
 			}
-			break
+			return true
 		}
 
 		token, err := r.splitter.Encoding.Decode(scanner.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
-		} else {
+		} else if !r.readHeader(token) {
 			r.emit(ctx, r.fileAttributes, token)
 		}
 
@@ -89,6 +174,29 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
+// readHeader keeps the token as the header of the file if it is a header line,
+// and returns whether it was, in which case the token must not be emitted
+func (r *Reader) readHeader(token []byte) bool {
+	if r.header == nil {
+		return false
+	}
+	if !r.header.isHeader(r.Offset, token) {
+		r.inHeader = false
+		return false
+	}
+
+	if r.inHeader {
+		r.Header += "\n" + string(token)
+	} else {
+		r.Header = string(token)
+		r.inHeader = true
+	}
+	if r.fileAttributes != nil {
+		r.fileAttributes.Header = r.Header
+	}
+	return true
+}
+
 // Close will close the file
 func (r *Reader) Close() {
 	if r.file != nil {
@@ -103,9 +211,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -24,8 +24,11 @@ import (
 
 type readerFactory struct {
 	*zap.SugaredLogger
-	readerConfig   *readerConfig
-	fromBeginning  bool
+	readerConfig  *readerConfig
+	fromBeginning bool
+	// flushAtEOF emits the last log entry of a file at its end, even when it is not terminated,
+	// for the files that are not read again after reaching their end
+	flushAtEOF     bool
 	splitterConfig helper.SplitterConfig
 }
 
@@ -38,12 +41,22 @@ func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, erro
 
 // copy creates a deep copy of a Reader
 func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
-	return f.newReaderBuilder().
+	r, err := f.newReaderBuilder().
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitter(old.splitter).
 		build()
+	if err != nil {
+		return nil, err
+	}
+	r.readCompletely = old.readCompletely
+	r.compressedSize = old.compressedSize
+	r.Header = old.Header
+	if r.fileAttributes != nil {
+		r.fileAttributes.Header = old.Header
+	}
+	return r, nil
 }
 
 func (f *readerFactory) unsafeReader() (*Reader, error) {
@@ -51,6 +64,13 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression, err := detectCompression(file, f.readerConfig.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression != compressionNone {
+		return newDecompressedFingerprint(file, compression, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
@@ -95,7 +115,7 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 	if b.splitter != nil {
 		r.splitter = b.splitter
 	} else {
-		r.splitter, err = b.splitterConfig.Build(false, b.readerConfig.maxLogSize)
+		r.splitter, err = b.splitterConfig.Build(b.flushAtEOF, b.readerConfig.maxLogSize)
 		if err != nil {
 			return
 		}
@@ -108,6 +128,10 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		r.fileCompression, err = detectCompression(b.file, b.readerConfig.compression)
+		if err != nil {
+			return nil, err
+		}
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
@@ -60,6 +60,9 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	if c.IncludeFilePathResolved {
 		preEmitOptions = append(preEmitOptions, setFilePathResolved)
 	}
+	if c.Header != nil {
+		preEmitOptions = append(preEmitOptions, setFileHeader)
+	}
 
 	var toBody toBodyFunc = func(token []byte) interface{} {
 		return string(token)
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
@@ -68,6 +69,31 @@ func TestAddFileFields(t *testing.T) {
 	require.Equal(t, temp.Name(), e.Attributes["log.file.path"])
 }
 
+// TestAddFileHeader tests that the `log.file.header` field is included when the header is configured,
+// and that it follows the header changes in the middle of the file
+func TestAddFileHeader(t *testing.T) {
+	t.Parallel()
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Header = &fileconsumer.HeaderConfig{Pattern: "^#"}
+	}, nil)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: a b\n1 2\n#Fields: a b c\n1 2 3\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, "1 2", e.Body)
+	require.Equal(t, "#Version: 1.0\n#Fields: a b", e.Attributes["log.file.header"])
+
+	e = waitForOne(t, logReceived)
+	require.Equal(t, "1 2 3", e.Body)
+	require.Equal(t, "#Fields: a b c", e.Attributes["log.file.header"])
+}
+
 // AddFileResolvedFields tests that the `log.file.name_resolved` and `log.file.path_resolved` fields are included
 // when IncludeFileNameResolved and IncludeFilePathResolved are set to true
 func TestAddFileResolvedFields(t *testing.T) {
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// OctetCountingSplitFuncBuilder builds the function splitting the messages framed with the
+// octet counting method of RFC 6587, which is also the framing of syslog over TLS (RFC 5425).
+func OctetCountingSplitFuncBuilder(_ helper.Encoding, maxLogSize int) (bufio.SplitFunc, error) {
+	return newOctetCountingSplitFunc(maxLogSize), nil
+}
+
+// newOctetCountingSplitFunc creates a function splitting the frames "MSG-LEN SP SYSLOG-MSG",
+// where MSG-LEN is the number of octets of SYSLOG-MSG, in the syslog messages.
+func newOctetCountingSplitFunc(maxLogSize int) bufio.SplitFunc {
+	maxLengthDigits := len(strconv.Itoa(maxLogSize))
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// Skip the line breaks between the frames, which some senders add
+		start := 0
+		for start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+			start++
+		}
+		if start == len(data) {
+			return start, nil, nil
+		}
+
+		end := start
+		for end < len(data) && end-start <= maxLengthDigits && data[end] >= '0' && data[end] <= '9' {
+			end++
+		}
+		if end == len(data) && end-start <= maxLengthDigits {
+			if atEOF {
+				return 0, nil, fmt.Errorf("truncated octet counting frame")
+			}
+			return start, nil, nil
+		}
+		if end == start || data[start] == '0' || data[end] != ' ' {
+			return 0, nil, fmt.Errorf("invalid octet counting frame: the message length must be followed by a space")
+		}
+
+		length, err := strconv.Atoi(string(data[start:end]))
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid octet counting frame: %w", err)
+		}
+		// The whole frame, including the length and the space, must fit in the buffer of the scanner
+		frameEnd := end + 1 + length
+		if frameEnd-start > maxLogSize {
+			return 0, nil, fmt.Errorf("invalid octet counting frame: the frame of %d octets exceeds the max_log_size %d", frameEnd-start, maxLogSize)
+		}
+
+		if frameEnd > len(data) {
+			if atEOF {
+				return 0, nil, fmt.Errorf("truncated octet counting frame")
+			}
+			return start, nil, nil
+		}
+		return frameEnd, data[end+1 : frameEnd], nil
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOctetCountingSplitFunc(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected []string
+		errMsg   string
+	}{
+		{
+			name:     "single",
+			input:    "11 hello world",
+			expected: []string{"hello world"},
+		},
+		{
+			name:     "multiple",
+			input:    "5 hello5 world",
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "newlines_in_message",
+			input:    "11 hello\nworld11 second\nline",
+			expected: []string{"hello\nworld", "second\nline"},
+		},
+		{
+			name:     "newlines_between_frames",
+			input:    "5 hello\n5 world\r\n",
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:   "missing_length",
+			input:  "hello",
+			errMsg: "invalid octet counting frame: the message length must be followed by a space",
+		},
+		{
+			name:   "leading_zero",
+			input:  "05 hello",
+			errMsg: "invalid octet counting frame: the message length must be followed by a space",
+		},
+		{
+			name:   "too_long",
+			input:  "100 hello",
+			errMsg: "invalid octet counting frame: the frame of 104 octets exceeds the max_log_size 64",
+		},
+		{
+			name:     "max_log_size",
+			input:    "\n61 " + strings.Repeat("a", 61),
+			expected: []string{strings.Repeat("a", 61)},
+		},
+		{
+			name:   "max_log_size_exceeded",
+			input:  "62 " + strings.Repeat("a", 62),
+			errMsg: "invalid octet counting frame: the frame of 65 octets exceeds the max_log_size 64",
+		},
+		{
+			name:     "truncated",
+			input:    "5 hello11 world",
+			expected: []string{"hello"},
+			errMsg:   "truncated octet counting frame",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(tc.input))
+			scanner.Buffer(make([]byte, 0, 64), 64)
+			scanner.Split(newOctetCountingSplitFunc(64))
+
+			var tokens []string
+			for scanner.Scan() {
+				tokens = append(tokens, scanner.Text())
+			}
+			require.Equal(t, tc.expected, tokens)
+			if tc.errMsg == "" {
+				require.NoError(t, scanner.Err())
+			} else {
+				require.EqualError(t, scanner.Err(), tc.errMsg)
+			}
+		})
+	}
+}
@@ -40,6 +40,9 @@ type Config struct {
 	syslog.BaseConfig  `mapstructure:",squash" yaml:",inline"`
 	TCP                *tcp.BaseConfig `mapstructure:"tcp" json:"tcp" yaml:"tcp"`
 	UDP                *udp.BaseConfig `mapstructure:"udp" json:"udp" yaml:"udp"`
+
+	// EnableOctetCounting enables the octet counting framing of RFC 6587 on the tcp listener
+	EnableOctetCounting bool `mapstructure:"enable_octet_counting,omitempty" json:"enable_octet_counting,omitempty" yaml:"enable_octet_counting,omitempty"`
 }
 
 func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
@@ -57,9 +60,16 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("failed to resolve syslog config: %w", err)
 	}
 
+	if c.EnableOctetCounting && c.TCP == nil {
+		return nil, fmt.Errorf("'enable_octet_counting' requires a tcp config")
+	}
+
 	if c.TCP != nil {
 		tcpInputCfg := tcp.NewConfig(inputBase.ID() + "_internal_tcp")
 		tcpInputCfg.BaseConfig = *c.TCP
+		if c.EnableOctetCounting {
+			tcpInputCfg.SplitFuncBuilder = OctetCountingSplitFuncBuilder
+		}
 
 		tcpInput, err := tcpInputCfg.Build(logger)
 		if err != nil {
@@ -96,6 +96,56 @@ func InputTest(t *testing.T, cfg *Config, tc syslog.Case) {
 	}
 }
 
+func TestInputOctetCounting(t *testing.T) {
+	syslogCfg := syslog.NewConfig("test_syslog_parser")
+	syslogCfg.Protocol = syslog.RFC5424
+	cfg := NewConfigWithTCP(&syslogCfg.BaseConfig)
+	cfg.EnableOctetCounting = true
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	p, err := pipeline.NewDirectedPipeline([]operator.Operator{op, fake})
+	require.NoError(t, err)
+	require.NoError(t, p.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, p.Stop())
+	}()
+
+	conn, err := net.Dial("tcp", cfg.TCP.ListenAddress)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	messages := []string{
+		"<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 - first\nline",
+		"<86>1 2015-08-05T21:58:59.694Z 192.168.2.132 SecureAuth0 23108 ID52020 - second line",
+	}
+	for _, message := range messages {
+		_, err = fmt.Fprintf(conn, "%d %s", len(message), message)
+		require.NoError(t, err)
+	}
+
+	for _, message := range messages {
+		select {
+		case e := <-fake.Received:
+			require.Equal(t, message, e.Body)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry to be processed")
+		}
+	}
+}
+
+func TestBuildOctetCountingWithUDP(t *testing.T) {
+	syslogCfg := syslog.NewConfig("test_syslog_parser")
+	syslogCfg.Protocol = syslog.RFC5424
+	cfg := NewConfigWithUDP(&syslogCfg.BaseConfig)
+	cfg.EnableOctetCounting = true
+
+	_, err := cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "'enable_octet_counting' requires a tcp config")
+}
+
 func TestSyslogIDs(t *testing.T) {
 	basicConfig := func() *syslog.BaseConfig {
 		cfg := syslog.NewConfig("test_syslog_parser")
@@ -163,6 +213,7 @@ udp:
 func TestConfigYamlUnmarshalTCP(t *testing.T) {
 	base := `type: syslog_input
 protocol: rfc5424
+enable_octet_counting: true
 tcp:
   listen_address: localhost:1234
   tls:
@@ -176,4 +227,5 @@ tcp:
 	require.NotNil(t, cfg.TCP)
 	require.Equal(t, "localhost:1234", cfg.TCP.ListenAddress)
 	require.Equal(t, "/tmp/test.ca", cfg.TCP.TLS.CAFile)
+	require.True(t, cfg.EnableOctetCounting)
 }
@@ -71,8 +71,15 @@ type BaseConfig struct {
 	AddAttributes bool                    `mapstructure:"add_attributes,omitempty"        json:"add_attributes,omitempty"       yaml:"add_attributes,omitempty"`
 	Encoding      helper.EncodingConfig   `mapstructure:",squash,omitempty"               json:",inline,omitempty"              yaml:",inline,omitempty"`
 	Multiline     helper.MultilineConfig  `mapstructure:"multiline,omitempty"             json:"multiline,omitempty"            yaml:"multiline,omitempty"`
+
+	// SplitFuncBuilder builds the function splitting the received data in log entries,
+	// instead of the multiline configuration, e.g. for the syslog framing
+	SplitFuncBuilder SplitFuncBuilder `mapstructure:"-" json:"-" yaml:"-"`
 }
 
+// SplitFuncBuilder builds a function splitting the received data in tokens of at most maxLogSize bytes.
+type SplitFuncBuilder func(encoding helper.Encoding, maxLogSize int) (bufio.SplitFunc, error)
+
 // Build will build a tcp input operator.
 func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	inputOperator, err := c.InputConfig.Build(logger)
@@ -103,8 +110,13 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
-	// Build multiline
-	splitFunc, err := c.Multiline.Build(encoding.Encoding, true, nil, int(c.MaxLogSize))
+	var splitFunc bufio.SplitFunc
+	if c.SplitFuncBuilder != nil {
+		splitFunc, err = c.SplitFuncBuilder(encoding, int(c.MaxLogSize))
+	} else {
+		// Build multiline
+		splitFunc, err = c.Multiline.Build(encoding.Encoding, true, nil, int(c.MaxLogSize))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,361 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	formatAuto       = "auto"
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	// filePathAttribute is the attribute in which the file_input operator sets the path of the file
+	filePathAttribute = "log.file.path"
+	streamAttribute   = "log.iostream"
+
+	namespaceResource    = "k8s.namespace.name"
+	podNameResource      = "k8s.pod.name"
+	podUIDResource       = "k8s.pod.uid"
+	containerResource    = "k8s.container.name"
+	restartCountResource = "k8s.container.restart_count"
+
+	// criPartialTag is the tag of the CRI log lines that are continued by the next line of the same stream
+	criPartialTag = "P"
+)
+
+// podLogPathRegexp matches the path of the log files written by the kubelet,
+// e.g. /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+var podLogPathRegexp = regexp.MustCompile(`^.*/([^_/]+)_([^_/]+)_([a-f0-9-]+)/([^/]+)/(\d+)\.log$`)
+
+func init() {
+	operator.Register("container", func() operator.Builder { return NewConfig("") })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, "container"),
+		ParseFrom:               entry.NewBodyField(),
+		Format:                  formatAuto,
+		AddMetadataFromFilePath: true,
+		MaxLogSize:              helper.ByteSize(1024 * 1024),
+		ForceFlushTimeout:       helper.Duration{Duration: 5 * time.Second},
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash" yaml:",inline"`
+
+	ParseFrom               entry.Field     `mapstructure:"parse_from"                  json:"parse_from"                  yaml:"parse_from"`
+	Format                  string          `mapstructure:"format"                      json:"format"                      yaml:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_file_path" json:"add_metadata_from_file_path" yaml:"add_metadata_from_file_path"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size"                json:"max_log_size"                yaml:"max_log_size"`
+	ForceFlushTimeout       helper.Duration `mapstructure:"force_flush_period"          json:"force_flush_period"          yaml:"force_flush_period"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	default:
+		return nil, fmt.Errorf("invalid 'format': '%s'", c.Format)
+	}
+
+	if c.MaxLogSize <= 0 {
+		return nil, errors.New("'max_log_size' must be positive")
+	}
+
+	if c.ForceFlushTimeout.Duration <= 0 {
+		return nil, errors.New("'force_flush_period' must be positive")
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		parseFrom:               c.ParseFrom,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		maxLogSize:              int(c.MaxLogSize),
+		forceFlushTimeout:       c.ForceFlushTimeout.Duration,
+		chClose:                 make(chan struct{}),
+		partials:                make(map[string]*partialLog),
+	}, nil
+}
+
+// Parser is an operator that parses the log lines written by the container runtimes,
+// and reassembles the lines they split.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom               entry.Field
+	format                  string
+	addMetadataFromFilePath bool
+	maxLogSize              int
+	forceFlushTimeout       time.Duration
+	chClose                 chan struct{}
+	wg                      sync.WaitGroup
+
+	sync.Mutex
+	// partials holds the partial log lines of each file and stream, until they are complete
+	partials map[string]*partialLog
+}
+
+// partialLog is a log line that has been split by the container runtime.
+type partialLog struct {
+	base    *entry.Entry
+	message strings.Builder
+	updated time.Time
+}
+
+// containerLog is a log line parsed from one of the container runtime formats.
+type containerLog struct {
+	timestamp time.Time
+	stream    string
+	message   string
+	partial   bool
+}
+
+// dockerLog is a log line of the docker json-file logging driver.
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start starts the goroutine that flushes the partial log lines that are not completed in time.
+func (p *Parser) Start(_ operator.Persister) error {
+	p.wg.Add(1)
+	go p.flushLoop()
+	return nil
+}
+
+// Stop flushes the partial log lines.
+func (p *Parser) Stop() error {
+	close(p.chClose)
+	p.wg.Wait()
+
+	p.Lock()
+	defer p.Unlock()
+	for key := range p.partials {
+		p.flushPartial(context.Background(), key)
+	}
+	return nil
+}
+
+func (p *Parser) flushLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.forceFlushTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.Lock()
+			now := time.Now()
+			for key, partial := range p.partials {
+				if now.Sub(partial.updated) >= p.forceFlushTimeout {
+					p.flushPartial(context.Background(), key)
+				}
+			}
+			p.Unlock()
+		case <-p.chClose:
+			return
+		}
+	}
+}
+
+// Process will parse an entry written by a container runtime.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	var raw string
+	if err = e.Read(p.parseFrom, &raw); err != nil {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("read parse_from: %w", err))
+	}
+
+	log, err := p.parse(raw)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err = addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	e.Delete(p.parseFrom)
+	e.Timestamp = log.timestamp
+	e.AddAttribute(streamAttribute, log.stream)
+	e.Body = log.message
+
+	p.Lock()
+	defer p.Unlock()
+	key := partialKey(e, log.stream)
+	partial, ok := p.partials[key]
+	switch {
+	case !ok && !log.partial:
+		p.Write(ctx, e)
+	case !ok:
+		partial = &partialLog{base: e}
+		partial.message.WriteString(log.message)
+		partial.updated = time.Now()
+		p.partials[key] = partial
+	default:
+		partial.message.WriteString(log.message)
+		partial.updated = time.Now()
+		if !log.partial || partial.message.Len() >= p.maxLogSize {
+			p.flushPartial(ctx, key)
+		}
+	}
+	return nil
+}
+
+// flushPartial emits the log line reassembled from the partial log lines of the key.
+func (p *Parser) flushPartial(ctx context.Context, key string) {
+	partial := p.partials[key]
+	delete(p.partials, key)
+	partial.base.Body = partial.message.String()
+	p.Write(ctx, partial.base)
+}
+
+// partialKey identifies the sequence of log lines a partial log line belongs to.
+func partialKey(e *entry.Entry, stream string) string {
+	var path string
+	if err := e.Read(entry.NewAttributeField(filePathAttribute), &path); err != nil {
+		return stream
+	}
+	return path + ":" + stream
+}
+
+// parse parses a log line in the configured format, or in the format detected from the line.
+func (p *Parser) parse(raw string) (containerLog, error) {
+	format := p.format
+	if format == formatAuto {
+		format = detectFormat(raw)
+	}
+
+	if format == formatDocker {
+		return parseDocker(raw)
+	}
+	return parseCRI(raw)
+}
+
+// detectFormat detects the format of a log line. CRI-O writes timestamps with the
+// local time offset, while containerd writes them in UTC.
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return formatDocker
+	}
+	if timestamp, _, ok := strings.Cut(raw, " "); ok && strings.HasSuffix(timestamp, "Z") {
+		return formatContainerd
+	}
+	return formatCRIO
+}
+
+// parseDocker parses a log line of the docker json-file logging driver, which
+// splits the lines longer than 16KiB, and terminates only the last part with a newline.
+func parseDocker(raw string) (containerLog, error) {
+	var parsed dockerLog
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	message := strings.TrimSuffix(parsed.Log, "\n")
+	return containerLog{
+		timestamp: timestamp,
+		stream:    parsed.Stream,
+		message:   message,
+		partial:   len(message) == len(parsed.Log),
+	}, nil
+}
+
+// parseCRI parses a log line in the CRI format used by CRI-O and containerd:
+// <timestamp> <stream> <tags> <message>, where the first tag is P for partial lines and F for full lines.
+func parseCRI(raw string) (containerLog, error) {
+	parts := strings.SplitN(raw, " ", 4)
+	if len(parts) < 3 {
+		return containerLog{}, errors.New("parse CRI log: missing fields")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse CRI log time: %w", err)
+	}
+
+	var message string
+	if len(parts) == 4 {
+		message = parts[3]
+	}
+	tag, _, _ := strings.Cut(parts[2], ":")
+	return containerLog{
+		timestamp: timestamp,
+		stream:    parts[1],
+		message:   message,
+		partial:   tag == criPartialTag,
+	}, nil
+}
+
+// addMetadataFromFilePath adds the kubernetes metadata in the path of the pod log file to the resource of the entry.
+func addMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(filePathAttribute), &path); err != nil {
+		return fmt.Errorf("read the '%s' attribute, which requires 'include_file_path': %w", filePathAttribute, err)
+	}
+
+	matches := podLogPathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("extract the metadata from the path '%s': not a pod log file", path)
+	}
+
+	e.AddResourceKey(namespaceResource, matches[1])
+	e.AddResourceKey(podNameResource, matches[2])
+	e.AddResourceKey(podUIDResource, matches[3])
+	e.AddResourceKey(containerResource, matches[4])
+	e.AddResourceKey(restartCountResource, matches[5])
+	return nil
+}
@@ -0,0 +1,304 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const podLogPath = "/var/log/pods/default_nginx-5bd4c9c8b6-2xgqs_49cc7c1f-a1e4-4a6e-8c0f-0e8d3bb23d57/nginx/1.log"
+
+var observedTimestamp = time.Date(2022, time.August, 1, 10, 0, 1, 0, time.UTC)
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfig("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.OnError = "drop"
+	if configure != nil {
+		configure(cfg)
+	}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.ObservedTimestamp = observedTimestamp
+	e.Body = body
+	e.Attributes = map[string]interface{}{
+		filePathAttribute: podLogPath,
+	}
+	return e
+}
+
+func expectedEntry(t *testing.T, timestamp, stream, body string) *entry.Entry {
+	ts, err := time.Parse(time.RFC3339Nano, timestamp)
+	require.NoError(t, err)
+
+	e := entry.New()
+	e.ObservedTimestamp = observedTimestamp
+	e.Timestamp = ts
+	e.Body = body
+	e.Attributes = map[string]interface{}{
+		filePathAttribute: podLogPath,
+		streamAttribute:   stream,
+	}
+	e.Resource = map[string]interface{}{
+		namespaceResource:    "default",
+		podNameResource:      "nginx-5bd4c9c8b6-2xgqs",
+		podUIDResource:       "49cc7c1f-a1e4-4a6e-8c0f-0e8d3bb23d57",
+		containerResource:    "nginx",
+		restartCountResource: "1",
+	}
+	return e
+}
+
+func TestBuildFailure(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(*Config)
+		errMsg    string
+	}{
+		{
+			"invalid_format",
+			func(cfg *Config) {
+				cfg.Format = "podman"
+			},
+			"invalid 'format': 'podman'",
+		},
+		{
+			"invalid_max_log_size",
+			func(cfg *Config) {
+				cfg.MaxLogSize = 0
+			},
+			"'max_log_size' must be positive",
+		},
+		{
+			"invalid_force_flush_period",
+			func(cfg *Config) {
+				cfg.ForceFlushTimeout = helper.Duration{}
+			},
+			"'force_flush_period' must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	require.Equal(t, formatDocker, detectFormat(`{"log":"hello\n","stream":"stdout","time":"2022-08-01T10:00:00.000000001Z"}`))
+	require.Equal(t, formatContainerd, detectFormat("2022-08-01T10:00:00.000000001Z stdout F hello"))
+	require.Equal(t, formatCRIO, detectFormat("2022-08-01T12:00:00.000000001+02:00 stdout F hello"))
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     []string
+		expected  []*entry.Entry
+	}{
+		{
+			"docker",
+			nil,
+			[]string{
+				`{"log":"hello\n","stream":"stdout","time":"2022-08-01T10:00:00.000000001Z"}`,
+				`{"log":"world\n","stream":"stderr","time":"2022-08-01T10:00:00.000000002Z"}`,
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"),
+				expectedEntry(t, "2022-08-01T10:00:00.000000002Z", "stderr", "world"),
+			},
+		},
+		{
+			"docker_partial",
+			nil,
+			[]string{
+				`{"log":"hel","stream":"stdout","time":"2022-08-01T10:00:00.000000001Z"}`,
+				`{"log":"error\n","stream":"stderr","time":"2022-08-01T10:00:00.000000002Z"}`,
+				`{"log":"lo\n","stream":"stdout","time":"2022-08-01T10:00:00.000000003Z"}`,
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T10:00:00.000000002Z", "stderr", "error"),
+				expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"),
+			},
+		},
+		{
+			"containerd",
+			nil,
+			[]string{
+				"2022-08-01T10:00:00.000000001Z stdout F hello world",
+				"2022-08-01T10:00:00.000000002Z stderr F ",
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello world"),
+				expectedEntry(t, "2022-08-01T10:00:00.000000002Z", "stderr", ""),
+			},
+		},
+		{
+			"crio_partial",
+			nil,
+			[]string{
+				"2022-08-01T12:00:00.000000001+02:00 stdout P hel",
+				"2022-08-01T12:00:00.000000002+02:00 stdout P l",
+				"2022-08-01T12:00:00.000000003+02:00 stdout F o",
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T12:00:00.000000001+02:00", "stdout", "hello"),
+			},
+		},
+		{
+			"max_log_size",
+			func(cfg *Config) {
+				cfg.MaxLogSize = 4
+			},
+			[]string{
+				"2022-08-01T10:00:00.000000001Z stdout P hel",
+				"2022-08-01T10:00:00.000000002Z stdout P lo",
+				"2022-08-01T10:00:00.000000003Z stdout F world",
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"),
+				expectedEntry(t, "2022-08-01T10:00:00.000000003Z", "stdout", "world"),
+			},
+		},
+		{
+			"explicit_format",
+			func(cfg *Config) {
+				cfg.Format = formatCRIO
+			},
+			[]string{
+				"2022-08-01T10:00:00.000000001Z stdout F hello",
+				`{"log":"hello\n","stream":"stdout","time":"2022-08-01T10:00:00.000000001Z"}`,
+			},
+			[]*entry.Entry{
+				expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"),
+			},
+		},
+		{
+			"invalid",
+			nil,
+			[]string{
+				"hello",
+				`{"log":"hello\n","stream":"stdout","time":"yesterday"}`,
+				"yesterday stdout F hello",
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, fake := newTestParser(t, tc.configure)
+			for _, line := range tc.input {
+				err := op.Process(context.Background(), newTestEntry(line))
+				if tc.expected == nil {
+					require.Error(t, err)
+				}
+			}
+
+			for _, expected := range tc.expected {
+				fake.ExpectEntry(t, expected)
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestParserMetadata(t *testing.T) {
+	op, fake := newTestParser(t, nil)
+
+	e := entry.New()
+	e.Body = "2022-08-01T10:00:00.000000001Z stdout F hello"
+	require.Error(t, op.Process(context.Background(), e))
+
+	e = entry.New()
+	e.Body = "2022-08-01T10:00:00.000000001Z stdout F hello"
+	e.Attributes = map[string]interface{}{
+		filePathAttribute: "/var/log/messages",
+	}
+	require.Error(t, op.Process(context.Background(), e))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	op, fake = newTestParser(t, func(cfg *Config) {
+		cfg.AddMetadataFromFilePath = false
+	})
+
+	e = entry.New()
+	e.Body = "2022-08-01T10:00:00.000000001Z stdout F hello"
+	require.NoError(t, op.Process(context.Background(), e))
+	fake.ExpectBody(t, "hello")
+}
+
+func TestParserParseFrom(t *testing.T) {
+	op, fake := newTestParser(t, func(cfg *Config) {
+		cfg.ParseFrom = entry.NewAttributeField("raw")
+	})
+
+	e := newTestEntry("")
+	e.Attributes["raw"] = "2022-08-01T10:00:00.000000001Z stdout F hello"
+	require.NoError(t, op.Process(context.Background(), e))
+	fake.ExpectEntry(t, expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"))
+}
+
+func TestParserForceFlush(t *testing.T) {
+	op, fake := newTestParser(t, func(cfg *Config) {
+		cfg.ForceFlushTimeout = helper.Duration{Duration: 100 * time.Millisecond}
+	})
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2022-08-01T10:00:00.000000001Z stdout P hel")))
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2022-08-01T10:00:00.000000002Z stdout P lo")))
+	fake.ExpectEntry(t, expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"))
+}
+
+func TestParserFlushOnStop(t *testing.T) {
+	cfg := NewConfig("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2022-08-01T10:00:00.000000001Z stdout P hello")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, op.Stop())
+	fake.ExpectEntry(t, expectedEntry(t, "2022-08-01T10:00:00.000000001Z", "stdout", "hello"))
+}
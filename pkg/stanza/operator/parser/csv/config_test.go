@@ -62,6 +62,14 @@ func TestConfig(t *testing.T) {
 				return p
 			}(),
 		},
+		{
+			Name: "header_from_file",
+			Expect: func() *Config {
+				p := defaultCfg()
+				p.HeaderFromFile = true
+				return p
+			}(),
+		},
 		{
 			Name: "timestamp",
 			Expect: func() *Config {
@@ -28,6 +28,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
+// fileHeaderAttribute is the attribute in which the file_input operator sets the header of the file
+const fileHeaderAttribute = "log.file.header"
+
 func init() {
 	operator.Register("csv_parser", func() operator.Builder { return NewConfig("") })
 }
@@ -45,6 +48,7 @@ type Config struct {
 
 	Header          string `json:"header" yaml:"header"`
 	HeaderAttribute string `json:"header_attribute" yaml:"header_attribute"`
+	HeaderFromFile  bool   `json:"header_from_file,omitempty" yaml:"header_from_file,omitempty"`
 	FieldDelimiter  string `json:"delimiter,omitempty" yaml:"delimiter,omitempty"`
 	LazyQuotes      bool   `json:"lazy_quotes,omitempty" yaml:"lazy_quotes,omitempty"`
 }
@@ -68,22 +72,30 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 	headers := make([]string, 0)
 	switch {
-	case c.Header == "" && c.HeaderAttribute == "":
-		return nil, errors.New("missing required field 'header' or 'header_attribute'")
+	case c.Header == "" && c.HeaderAttribute == "" && !c.HeaderFromFile:
+		return nil, errors.New("missing required field 'header', 'header_attribute' or 'header_from_file'")
 	case c.Header != "" && c.HeaderAttribute != "":
 		return nil, errors.New("only one header parameter can be set: 'header' or 'header_attribute'")
+	case c.HeaderFromFile && (c.Header != "" || c.HeaderAttribute != ""):
+		return nil, errors.New("'header_from_file' cannot be used with 'header' or 'header_attribute'")
 	case c.Header != "" && !strings.Contains(c.Header, c.FieldDelimiter):
 		return nil, errors.New("missing field delimiter in header")
 	case c.Header != "":
 		headers = strings.Split(c.Header, c.FieldDelimiter)
 	}
 
+	headerAttribute := c.HeaderAttribute
+	if c.HeaderFromFile {
+		headerAttribute = fileHeaderAttribute
+	}
+
 	return &Parser{
-		ParserOperator:  parserOperator,
-		header:          headers,
-		headerAttribute: c.HeaderAttribute,
-		fieldDelimiter:  fieldDelimiter,
-		lazyQuotes:      c.LazyQuotes,
+		ParserOperator:        parserOperator,
+		header:                headers,
+		headerAttribute:       headerAttribute,
+		removeHeaderAttribute: c.HeaderFromFile,
+		fieldDelimiter:        fieldDelimiter,
+		lazyQuotes:            c.LazyQuotes,
 
 		parse: generateParseFunc(headers, fieldDelimiter, c.LazyQuotes),
 	}, nil
@@ -95,8 +107,11 @@ type Parser struct {
 	fieldDelimiter  rune
 	header          []string
 	headerAttribute string
-	lazyQuotes      bool
-	parse           parseFunc
+	// removeHeaderAttribute removes the header attribute from the entry once it is parsed,
+	// when it only carries the header of the file the entry was read from
+	removeHeaderAttribute bool
+	lazyQuotes            bool
+	parse                 parseFunc
 }
 
 type parseFunc func(interface{}) (interface{}, error)
@@ -121,6 +136,9 @@ func (r *Parser) Process(ctx context.Context, e *entry.Entry) error {
 		}
 		headers := strings.Split(headerString, string([]rune{r.fieldDelimiter}))
 		parse = generateParseFunc(headers, r.fieldDelimiter, r.lazyQuotes)
+		if r.removeHeaderAttribute {
+			delete(e.Attributes, r.headerAttribute)
+		}
 	}
 
 	return r.ParserOperator.ProcessWith(ctx, e, parse)
@@ -61,6 +61,15 @@ func TestParserBuildFailureBadHeaderConfig(t *testing.T) {
 	require.Contains(t, err.Error(), "only one header parameter can be set: 'header' or 'header_attribute'")
 }
 
+func TestParserBuildFailureHeaderFromFileConfig(t *testing.T) {
+	cfg := NewConfig("test")
+	cfg.HeaderAttribute = "testheader"
+	cfg.HeaderFromFile = true
+	_, err := cfg.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'header_from_file' cannot be used with 'header' or 'header_attribute'")
+}
+
 func TestParserByteFailure(t *testing.T) {
 	parser := newTestParser(t)
 	_, err := parser.parse([]byte("invalid"))
@@ -608,6 +617,74 @@ func TestParserCSV(t *testing.T) {
 			false,
 			false,
 		},
+		{
+			"header-from-file",
+			func(p *Config) {
+				p.HeaderFromFile = true
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "name,age",
+						"log.file.name":   "users.csv",
+					},
+					Body: "stanza,1",
+				},
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "x,y,z",
+						"log.file.name":   "points.csv",
+					},
+					Body: "1,2,3",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.name": "users.csv",
+						"name":          "stanza",
+						"age":           "1",
+					},
+					Body: "stanza,1",
+				},
+				{
+					Attributes: map[string]interface{}{
+						"log.file.name": "points.csv",
+						"x":             "1",
+						"y":             "2",
+						"z":             "3",
+					},
+					Body: "1,2,3",
+				},
+			},
+			false,
+			false,
+		},
+		{
+			"header-from-file-missing-header",
+			func(p *Config) {
+				p.HeaderFromFile = true
+			},
+			[]entry.Entry{
+				{
+					Body: "stanza,1",
+				},
+			},
+			nil,
+			false,
+			true,
+		},
+		{
+			"header-from-file-with-header",
+			func(p *Config) {
+				p.Header = testHeader
+				p.HeaderFromFile = true
+			},
+			nil,
+			nil,
+			true,
+			false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,166 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package w3c // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/w3c"
+
+import (
+	"context"
+	csvparser "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	// defaultHeaderAttribute is the attribute in which the file_input operator sets the header of the file
+	defaultHeaderAttribute = "log.file.header"
+	fieldsDirective        = "#Fields:"
+	// missingValue is the value of the fields that are not available
+	missingValue = "-"
+)
+
+func init() {
+	operator.Register("w3c_parser", func() operator.Builder { return NewConfig("") })
+}
+
+// NewConfig creates a new W3C parser config with default values
+func NewConfig(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, "w3c_parser"),
+		HeaderAttribute: defaultHeaderAttribute,
+		Delimiter:       " ",
+	}
+}
+
+// Config is the configuration of a W3C parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	HeaderAttribute string `mapstructure:"header_attribute" yaml:"header_attribute"`
+	Delimiter       string `mapstructure:"delimiter" yaml:"delimiter"`
+}
+
+// Build will build a W3C parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.HeaderAttribute == "" {
+		return nil, errors.New("header_attribute is a required parameter")
+	}
+
+	if len([]rune(c.Delimiter)) != 1 {
+		return nil, fmt.Errorf("invalid 'delimiter': '%s'", c.Delimiter)
+	}
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		headerAttribute: c.HeaderAttribute,
+		delimiter:       []rune(c.Delimiter)[0],
+	}, nil
+}
+
+// Parser is an operator that parses entries in the W3C extended log file format,
+// with the fields listed by the last #Fields directive of the header of the file.
+type Parser struct {
+	helper.ParserOperator
+	headerAttribute string
+	delimiter       rune
+}
+
+// Process will parse an entry with the fields of the header attribute.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	h, ok := e.Attributes[p.headerAttribute]
+	if !ok {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("failed to read header attribute %s", p.headerAttribute))
+	}
+	header, ok := h.(string)
+	if !ok {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("header is expected to be a string but is %T", h))
+	}
+	fields, err := parseFieldsDirective(header)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	// The header only describes the file the entry was read from
+	delete(e.Attributes, p.headerAttribute)
+
+	return p.ParserOperator.ProcessWith(ctx, e, p.generateParseFunc(fields))
+}
+
+// parseFieldsDirective returns the field names of the last #Fields directive of the header.
+// A later #Fields directive replaces the earlier ones, since it applies to the entries that follow it.
+func parseFieldsDirective(header string) ([]string, error) {
+	var fields []string
+	for _, line := range strings.Split(header, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, fieldsDirective) {
+			fields = strings.Fields(strings.TrimPrefix(line, fieldsDirective))
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("missing '#Fields' directive in header")
+	}
+	return fields, nil
+}
+
+// generateParseFunc returns a parse function for the given field names
+func (p *Parser) generateParseFunc(fields []string) helper.ParseFunction {
+	return func(value interface{}) (interface{}, error) {
+		var line string
+		switch t := value.(type) {
+		case string:
+			line = t
+		case []byte:
+			line = string(t)
+		default:
+			return nil, fmt.Errorf("type '%T' cannot be parsed as w3c", value)
+		}
+
+		reader := csvparser.NewReader(strings.NewReader(line))
+		reader.Comma = p.delimiter
+		reader.LazyQuotes = true
+		reader.FieldsPerRecord = -1
+
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty entry")
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse entry: %w", err)
+		}
+
+		if len(values) != len(fields) {
+			return nil, fmt.Errorf("wrong number of fields: expected %d, found %d", len(fields), len(values))
+		}
+
+		parsedValues := make(map[string]interface{}, len(fields))
+		for i, val := range values {
+			if val == missingValue {
+				continue
+			}
+			parsedValues[fields[i]] = val
+		}
+		return parsedValues, nil
+	}
+}
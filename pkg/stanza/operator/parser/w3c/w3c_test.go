@@ -0,0 +1,274 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package w3c
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const iisHeader = "#Software: Microsoft Internet Information Services 10.0\n" +
+	"#Version: 1.0\n" +
+	"#Date: 2022-08-01 00:00:00\n" +
+	"#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query sc-status"
+
+func TestBuildFailure(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(*Config)
+		errMsg    string
+	}{
+		{
+			"empty_header_attribute",
+			func(cfg *Config) {
+				cfg.HeaderAttribute = ""
+			},
+			"header_attribute is a required parameter",
+		},
+		{
+			"invalid_delimiter",
+			func(cfg *Config) {
+				cfg.Delimiter = ";;"
+			},
+			"invalid 'delimiter': ';;'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestParseFieldsDirective(t *testing.T) {
+	fields, err := parseFieldsDirective(iisHeader)
+	require.NoError(t, err)
+	require.Equal(t, []string{"date", "time", "s-ip", "cs-method", "cs-uri-stem", "cs-uri-query", "sc-status"}, fields)
+
+	// The last directive applies
+	fields, err = parseFieldsDirective("#Fields: a b\n#Fields: c d e")
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d", "e"}, fields)
+
+	_, err = parseFieldsDirective("#Version: 1.0")
+	require.EqualError(t, err, "missing '#Fields' directive in header")
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name             string
+		configure        func(*Config)
+		inputEntries     []entry.Entry
+		expectedEntries  []entry.Entry
+		expectProcessErr bool
+	}{
+		{
+			"iis",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": iisHeader,
+						"log.file.name":   "u_ex220801.log",
+					},
+					Body: "2022-08-01 00:00:01 10.0.0.1 GET /index.html - 200",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.name": "u_ex220801.log",
+						"date":          "2022-08-01",
+						"time":          "00:00:01",
+						"s-ip":          "10.0.0.1",
+						"cs-method":     "GET",
+						"cs-uri-stem":   "/index.html",
+						"sc-status":     "200",
+					},
+					Body: "2022-08-01 00:00:01 10.0.0.1 GET /index.html - 200",
+				},
+			},
+			false,
+		},
+		{
+			"fields_change",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Fields: date time",
+					},
+					Body: "2022-08-01 00:00:01",
+				},
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0\n#Fields: date time cs-method",
+					},
+					Body: "2022-08-01 00:00:02 POST",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"date": "2022-08-01",
+						"time": "00:00:01",
+					},
+					Body: "2022-08-01 00:00:01",
+				},
+				{
+					Attributes: map[string]interface{}{
+						"date":      "2022-08-01",
+						"time":      "00:00:02",
+						"cs-method": "POST",
+					},
+					Body: "2022-08-01 00:00:02 POST",
+				},
+			},
+			false,
+		},
+		{
+			"cloudfront",
+			func(cfg *Config) {
+				cfg.Delimiter = "\t"
+				cfg.HeaderAttribute = "header"
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"header": "#Version: 1.0\n#Fields: date time x-edge-location cs(User-Agent)",
+					},
+					Body: "2022-08-01\t00:00:01\tFRA2-C1\tMozilla/5.0%20(X11)",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"date":            "2022-08-01",
+						"time":            "00:00:01",
+						"x-edge-location": "FRA2-C1",
+						"cs(User-Agent)":  "Mozilla/5.0%20(X11)",
+					},
+					Body: "2022-08-01\t00:00:01\tFRA2-C1\tMozilla/5.0%20(X11)",
+				},
+			},
+			false,
+		},
+		{
+			"quoted",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Fields: date cs(User-Agent) sc-status",
+					},
+					Body: `2022-08-01 "Mozilla/5.0 (X11)" 200`,
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"date":           "2022-08-01",
+						"cs(User-Agent)": "Mozilla/5.0 (X11)",
+						"sc-status":      "200",
+					},
+					Body: `2022-08-01 "Mozilla/5.0 (X11)" 200`,
+				},
+			},
+			false,
+		},
+		{
+			"wrong_number_of_fields",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Fields: date time",
+					},
+					Body: "2022-08-01 00:00:01 GET",
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"missing_header",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Body: "2022-08-01 00:00:01",
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"missing_fields_directive",
+			func(cfg *Config) {},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0",
+					},
+					Body: "2022-08-01 00:00:01",
+				},
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig("test")
+			cfg.OutputIDs = []string{"fake"}
+			cfg.OnError = "drop"
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			for i := range tc.inputEntries {
+				inputEntry := tc.inputEntries[i]
+				inputEntry.ObservedTimestamp = ots
+				err = op.Process(context.Background(), &inputEntry)
+				if tc.expectProcessErr {
+					require.Error(t, err)
+					fake.ExpectNoEntry(t, 100*time.Millisecond)
+					return
+				}
+				require.NoError(t, err)
+
+				expectedEntry := tc.expectedEntries[i]
+				expectedEntry.ObservedTimestamp = ots
+				fake.ExpectEntry(t, &expectedEntry)
+			}
+		})
+	}
+}
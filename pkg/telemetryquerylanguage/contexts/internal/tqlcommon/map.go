@@ -0,0 +1,107 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// GetMapValue returns the value in attrs that is found by following keys through nested maps and slices.
+// The first key must be a string. nil is returned if any of the keys does not exist.
+func GetMapValue(attrs pcommon.Map, keys []tql.Key) interface{} {
+	if len(keys) == 0 || keys[0].String == nil {
+		return nil
+	}
+	val, ok := attrs.Get(*keys[0].String)
+	if !ok {
+		return nil
+	}
+	return GetIndexedValue(val, keys[1:])
+}
+
+// GetIndexedValue returns the value that is found by following keys from value through nested maps and slices.
+// String keys index into maps and int keys into slices. nil is returned if any of the keys does not exist.
+func GetIndexedValue(value pcommon.Value, keys []tql.Key) interface{} {
+	for _, key := range keys {
+		switch {
+		case key.String != nil && value.Type() == pcommon.ValueTypeMap:
+			val, ok := value.MapVal().Get(*key.String)
+			if !ok {
+				return nil
+			}
+			value = val
+		case key.Int != nil && value.Type() == pcommon.ValueTypeSlice:
+			if *key.Int < 0 || *key.Int >= int64(value.SliceVal().Len()) {
+				return nil
+			}
+			value = value.SliceVal().At(int(*key.Int))
+		default:
+			return nil
+		}
+	}
+	return GetValue(value)
+}
+
+// SetMapValue sets the value in attrs that is found by following keys through nested maps and slices to val.
+// The first key must be a string. Missing maps along the way are created, but slices are not, and the write is
+// dropped if an existing value has the wrong type for the next key or a slice index is out of range.
+func SetMapValue(attrs pcommon.Map, keys []tql.Key, val interface{}) {
+	if len(keys) == 0 || keys[0].String == nil {
+		return
+	}
+	key := *keys[0].String
+	if len(keys) == 1 {
+		if newValue, ok := NewValue(val); ok {
+			attrs.Upsert(key, newValue)
+		}
+		return
+	}
+
+	current, ok := attrs.Get(key)
+	if !ok {
+		if keys[1].String == nil {
+			return
+		}
+		attrs.Upsert(key, pcommon.NewValueMap())
+		current, _ = attrs.Get(key)
+	}
+	SetIndexedValue(current, keys[1:], val)
+}
+
+// SetIndexedValue sets the value that is found by following keys from value through nested maps and slices to
+// val, the same way SetMapValue does. An empty value is turned into a map if it is indexed by a string key.
+func SetIndexedValue(value pcommon.Value, keys []tql.Key, val interface{}) {
+	if len(keys) == 0 {
+		SetValue(value, val)
+		return
+	}
+
+	key := keys[0]
+	switch {
+	case key.String != nil:
+		if value.Type() == pcommon.ValueTypeEmpty {
+			pcommon.NewValueMap().CopyTo(value)
+		}
+		if value.Type() == pcommon.ValueTypeMap {
+			SetMapValue(value.MapVal(), keys, val)
+		}
+	case key.Int != nil:
+		if value.Type() == pcommon.ValueTypeSlice && *key.Int >= 0 && *key.Int < int64(value.SliceVal().Len()) {
+			SetIndexedValue(value.SliceVal().At(int(*key.Int)), keys[1:], val)
+		}
+	}
+}
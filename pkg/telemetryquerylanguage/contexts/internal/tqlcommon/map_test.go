@@ -0,0 +1,222 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func strKey(s string) tql.Key {
+	return tql.Key{String: tqltest.Strp(s)}
+}
+
+func intKey(i int64) tql.Key {
+	return tql.Key{Int: tqltest.Intp(i)}
+}
+
+func createNestedMap() pcommon.Map {
+	return pcommon.NewMapFromRaw(map[string]interface{}{
+		"str": "val",
+		"http": map[string]interface{}{
+			"request": map[string]interface{}{
+				"method": "GET",
+			},
+		},
+		"items": []interface{}{
+			map[string]interface{}{
+				"name": "first",
+			},
+			"second",
+		},
+	})
+}
+
+func Test_GetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []tql.Key
+		expected interface{}
+	}{
+		{
+			name:     "single key",
+			keys:     []tql.Key{strKey("str")},
+			expected: "val",
+		},
+		{
+			name:     "nested maps",
+			keys:     []tql.Key{strKey("http"), strKey("request"), strKey("method")},
+			expected: "GET",
+		},
+		{
+			name:     "map in slice",
+			keys:     []tql.Key{strKey("items"), intKey(0), strKey("name")},
+			expected: "first",
+		},
+		{
+			name:     "slice element",
+			keys:     []tql.Key{strKey("items"), intKey(1)},
+			expected: "second",
+		},
+		{
+			name:     "missing key",
+			keys:     []tql.Key{strKey("http"), strKey("response")},
+			expected: nil,
+		},
+		{
+			name:     "index out of range",
+			keys:     []tql.Key{strKey("items"), intKey(2)},
+			expected: nil,
+		},
+		{
+			name:     "int key on map",
+			keys:     []tql.Key{strKey("http"), intKey(0)},
+			expected: nil,
+		},
+		{
+			name:     "string key on slice",
+			keys:     []tql.Key{strKey("items"), strKey("name")},
+			expected: nil,
+		},
+		{
+			name:     "key on string",
+			keys:     []tql.Key{strKey("str"), strKey("name")},
+			expected: nil,
+		},
+		{
+			name:     "int first key",
+			keys:     []tql.Key{intKey(0)},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetMapValue(createNestedMap(), tt.keys))
+		})
+	}
+}
+
+func Test_SetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []tql.Key
+		val      interface{}
+		modified func(m pcommon.Map)
+	}{
+		{
+			name: "single key",
+			keys: []tql.Key{strKey("str")},
+			val:  "new",
+			modified: func(m pcommon.Map) {
+				m.UpsertString("str", "new")
+			},
+		},
+		{
+			name: "nested maps",
+			keys: []tql.Key{strKey("http"), strKey("request"), strKey("method")},
+			val:  "POST",
+			modified: func(m pcommon.Map) {
+				http, _ := m.Get("http")
+				request, _ := http.MapVal().Get("request")
+				request.MapVal().UpsertString("method", "POST")
+			},
+		},
+		{
+			name: "creates intermediate maps",
+			keys: []tql.Key{strKey("http"), strKey("response"), strKey("headers"), strKey("status")},
+			val:  int64(200),
+			modified: func(m pcommon.Map) {
+				http, _ := m.Get("http")
+				response := pcommon.NewValueMap()
+				headers := pcommon.NewValueMap()
+				headers.MapVal().UpsertInt("status", 200)
+				response.MapVal().Upsert("headers", headers)
+				http.MapVal().Upsert("response", response)
+			},
+		},
+		{
+			name: "map in slice",
+			keys: []tql.Key{strKey("items"), intKey(0), strKey("name")},
+			val:  "updated",
+			modified: func(m pcommon.Map) {
+				items, _ := m.Get("items")
+				items.SliceVal().At(0).MapVal().UpsertString("name", "updated")
+			},
+		},
+		{
+			name: "slice element",
+			keys: []tql.Key{strKey("items"), intKey(1)},
+			val:  []string{"a", "b"},
+			modified: func(m pcommon.Map) {
+				items, _ := m.Get("items")
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("a")
+				arr.SliceVal().AppendEmpty().SetStringVal("b")
+				arr.CopyTo(items.SliceVal().At(1))
+			},
+		},
+		{
+			name:     "index out of range",
+			keys:     []tql.Key{strKey("items"), intKey(2)},
+			val:      "new",
+			modified: func(m pcommon.Map) {},
+		},
+		{
+			name:     "missing slice is not created",
+			keys:     []tql.Key{strKey("list"), intKey(0)},
+			val:      "new",
+			modified: func(m pcommon.Map) {},
+		},
+		{
+			name:     "existing value is not a map",
+			keys:     []tql.Key{strKey("str"), strKey("name")},
+			val:      "new",
+			modified: func(m pcommon.Map) {},
+		},
+		{
+			name:     "unsupported value",
+			keys:     []tql.Key{strKey("str")},
+			val:      int32(1),
+			modified: func(m pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createNestedMap()
+			SetMapValue(m, tt.keys, tt.val)
+
+			expected := createNestedMap()
+			tt.modified(expected)
+
+			assert.Equal(t, expected.AsRaw(), m.AsRaw())
+		})
+	}
+}
+
+func Test_SetIndexedValue_empty(t *testing.T) {
+	value := pcommon.NewValueEmpty()
+	SetIndexedValue(value, []tql.Key{strKey("a"), strKey("b")}, "c")
+
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": "c",
+		},
+	}, value.MapVal().AsRaw())
+}
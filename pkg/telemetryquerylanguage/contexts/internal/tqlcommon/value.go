@@ -0,0 +1,102 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// GetValue returns the content of val as the type the TQL uses for it, or nil if val is empty.
+func GetValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeString:
+		return val.StringVal()
+	case pcommon.ValueTypeBool:
+		return val.BoolVal()
+	case pcommon.ValueTypeInt:
+		return val.IntVal()
+	case pcommon.ValueTypeDouble:
+		return val.DoubleVal()
+	case pcommon.ValueTypeMap:
+		return val.MapVal()
+	case pcommon.ValueTypeSlice:
+		return val.SliceVal()
+	case pcommon.ValueTypeBytes:
+		return val.BytesVal().AsRaw()
+	}
+	return nil
+}
+
+// SetValue replaces the content of value with val. value is left unchanged if val has an unsupported type.
+func SetValue(value pcommon.Value, val interface{}) {
+	if newValue, ok := NewValue(val); ok {
+		newValue.CopyTo(value)
+	}
+}
+
+// NewValue creates a pcommon.Value holding a copy of val. false is returned if val has an unsupported type.
+func NewValue(val interface{}) (pcommon.Value, bool) {
+	switch v := val.(type) {
+	case string:
+		return pcommon.NewValueString(v), true
+	case bool:
+		return pcommon.NewValueBool(v), true
+	case int64:
+		return pcommon.NewValueInt(v), true
+	case float64:
+		return pcommon.NewValueDouble(v), true
+	case []byte:
+		return pcommon.NewValueBytes(pcommon.NewImmutableByteSlice(v)), true
+	case []string:
+		arr := pcommon.NewValueSlice()
+		for _, str := range v {
+			arr.SliceVal().AppendEmpty().SetStringVal(str)
+		}
+		return arr, true
+	case []bool:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBoolVal(b)
+		}
+		return arr, true
+	case []int64:
+		arr := pcommon.NewValueSlice()
+		for _, i := range v {
+			arr.SliceVal().AppendEmpty().SetIntVal(i)
+		}
+		return arr, true
+	case []float64:
+		arr := pcommon.NewValueSlice()
+		for _, f := range v {
+			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
+		}
+		return arr, true
+	case [][]byte:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
+		}
+		return arr, true
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		return m, true
+	case pcommon.Slice:
+		arr := pcommon.NewValueSlice()
+		v.CopyTo(arr.SliceVal())
+		return arr, true
+	}
+	return pcommon.Value{}, false
+}
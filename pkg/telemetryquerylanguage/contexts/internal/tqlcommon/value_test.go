@@ -0,0 +1,121 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_NewValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		val      interface{}
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			val:      "str",
+			expected: "str",
+		},
+		{
+			name:     "bool",
+			val:      true,
+			expected: true,
+		},
+		{
+			name:     "int",
+			val:      int64(1),
+			expected: int64(1),
+		},
+		{
+			name:     "double",
+			val:      1.5,
+			expected: 1.5,
+		},
+		{
+			name:     "bytes",
+			val:      []byte{1, 2},
+			expected: []byte{1, 2},
+		},
+		{
+			name:     "string slice",
+			val:      []string{"a", "b"},
+			expected: []interface{}{"a", "b"},
+		},
+		{
+			name:     "bool slice",
+			val:      []bool{true, false},
+			expected: []interface{}{true, false},
+		},
+		{
+			name:     "int slice",
+			val:      []int64{1, 2},
+			expected: []interface{}{int64(1), int64(2)},
+		},
+		{
+			name:     "double slice",
+			val:      []float64{1.5, 2.5},
+			expected: []interface{}{1.5, 2.5},
+		},
+		{
+			name:     "bytes slice",
+			val:      [][]byte{{1}, {2}},
+			expected: []interface{}{[]byte{1}, []byte{2}},
+		},
+		{
+			name:     "map",
+			val:      pcommon.NewMapFromRaw(map[string]interface{}{"k": "v"}),
+			expected: map[string]interface{}{"k": "v"},
+		},
+		{
+			name:     "slice",
+			val:      pcommon.NewSliceFromRaw([]interface{}{"a", int64(1)}),
+			expected: []interface{}{"a", int64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := NewValue(tt.val)
+			assert.True(t, ok)
+			m := pcommon.NewMap()
+			m.Upsert("v", value)
+			assert.Equal(t, tt.expected, m.AsRaw()["v"])
+		})
+	}
+}
+
+func Test_NewValue_unsupported(t *testing.T) {
+	_, ok := NewValue(nil)
+	assert.False(t, ok)
+
+	_, ok = NewValue(int32(1))
+	assert.False(t, ok)
+}
+
+func Test_SetValue(t *testing.T) {
+	value := pcommon.NewValueString("original")
+
+	SetValue(value, []string{"a"})
+	assert.Equal(t, pcommon.ValueTypeSlice, value.Type())
+
+	SetValue(value, nil)
+	assert.Equal(t, pcommon.ValueTypeSlice, value.Type())
+
+	SetValue(value, int64(1))
+	assert.Equal(t, int64(1), GetValue(value))
+}
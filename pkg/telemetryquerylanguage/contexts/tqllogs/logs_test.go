@@ -127,6 +127,21 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.Body().SetStringVal("head")
 			},
 		},
+		{
+			name: "body map",
+			path: []tql.Field{
+				{
+					Name: "body",
+				},
+			},
+			orig:   "body",
+			newVal: pcommon.NewMapFromRaw(map[string]interface{}{"k": "v"}),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				m := pcommon.NewValueMap()
+				m.MapVal().UpsertString("k", "v")
+				m.CopyTo(log.Body())
+			},
+		},
 		{
 			name: "flags",
 			path: []tql.Field{
@@ -216,8 +231,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -230,8 +249,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -244,8 +267,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -258,8 +285,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -272,8 +303,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -286,8 +321,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -303,8 +342,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -320,8 +363,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -337,8 +384,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -354,8 +405,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -367,6 +422,51 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.Attributes().Upsert("arr_bytes", newArrBytes)
 			},
 		},
+		{
+			name: "attributes nested slice index",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+						{
+							Int: tqltest.Intp(1),
+						},
+					},
+				},
+			},
+			orig:   "two",
+			newVal: "three",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				val, _ := log.Attributes().Get("arr_str")
+				val.SliceVal().At(1).SetStringVal("three")
+			},
+		},
+		{
+			name: "attributes nested map created",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("http"),
+						},
+						{
+							String: tqltest.Strp("request"),
+						},
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "GET",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				m := pcommon.NewValueMap()
+				m.MapVal().UpsertString("request", "GET")
+				log.Attributes().Upsert("http", m)
+			},
+		},
 		{
 			name: "dropped_attributes_count",
 			path: []tql.Field{
@@ -436,8 +536,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -453,8 +557,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -470,8 +578,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -487,8 +599,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -504,8 +620,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -521,8 +641,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -541,8 +665,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -561,8 +689,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -581,8 +713,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -601,8 +737,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -645,6 +785,105 @@ func Test_newPathGetSetter(t *testing.T) {
 	}
 }
 
+func Test_newPathGetSetter_bodyKeys(t *testing.T) {
+	createLog := func() plog.LogRecord {
+		log := plog.NewLogRecord()
+		pcommon.NewValueMap().CopyTo(log.Body())
+		pcommon.NewMapFromRaw(map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"name": "first",
+				},
+			},
+		}).CopyTo(log.Body().MapVal())
+		return log
+	}
+
+	tests := []struct {
+		name     string
+		keys     []tql.Key
+		orig     interface{}
+		newVal   interface{}
+		modified func(log plog.LogRecord)
+	}{
+		{
+			name: "map in slice",
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("items"),
+				},
+				{
+					Int: tqltest.Intp(0),
+				},
+				{
+					String: tqltest.Strp("name"),
+				},
+			},
+			orig:   "first",
+			newVal: "updated",
+			modified: func(log plog.LogRecord) {
+				items, _ := log.Body().MapVal().Get("items")
+				items.SliceVal().At(0).MapVal().UpsertString("name", "updated")
+			},
+		},
+		{
+			name: "new nested key",
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("http"),
+				},
+				{
+					String: tqltest.Strp("status"),
+				},
+			},
+			orig:   nil,
+			newVal: int64(200),
+			modified: func(log plog.LogRecord) {
+				http := pcommon.NewValueMap()
+				http.MapVal().UpsertInt("status", 200)
+				log.Body().MapVal().Upsert("http", http)
+			},
+		},
+		{
+			name: "index out of range",
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("items"),
+				},
+				{
+					Int: tqltest.Intp(1),
+				},
+			},
+			orig:     nil,
+			newVal:   "ignored",
+			modified: func(log plog.LogRecord) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter([]tql.Field{
+				{
+					Name: "body",
+					Keys: tt.keys,
+				},
+			})
+			assert.NoError(t, err)
+
+			log := createLog()
+			ctx := LogTransformContext{
+				Log: log,
+			}
+			assert.Equal(t, tt.orig, accessor.Get(ctx))
+
+			accessor.Set(ctx, tt.newVal)
+
+			expected := createLog()
+			tt.modified(expected)
+			assert.Equal(t, expected.Body().MapVal().AsRaw(), log.Body().MapVal().AsRaw())
+		})
+	}
+}
+
 func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Resource) {
 	log := plog.NewLogRecord()
 	log.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
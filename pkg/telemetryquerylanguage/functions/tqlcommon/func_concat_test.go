@@ -0,0 +1,97 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_concat(t *testing.T) {
+	tests := []struct {
+		name      string
+		delimiter string
+		vals      []tql.Getter
+		expected  interface{}
+	}{
+		{
+			name:      "concat strings",
+			delimiter: " ",
+			vals: []tql.Getter{
+				tql.Literal{Value: "hello"},
+				tql.Literal{Value: "world"},
+			},
+			expected: "hello world",
+		},
+		{
+			name:      "empty delimiter",
+			delimiter: "",
+			vals: []tql.Getter{
+				tql.Literal{Value: "hello"},
+				tql.Literal{Value: "world"},
+			},
+			expected: "helloworld",
+		},
+		{
+			name:      "mixed types",
+			delimiter: ",",
+			vals: []tql.Getter{
+				tql.Literal{Value: "a"},
+				tql.Literal{Value: int64(1)},
+				tql.Literal{Value: 1.5},
+				tql.Literal{Value: true},
+				tql.Literal{Value: []byte{0x01, 0xff}},
+			},
+			expected: "a,1,1.5,true,01ff",
+		},
+		{
+			name:      "nil values are empty",
+			delimiter: "-",
+			vals: []tql.Getter{
+				tql.Literal{Value: "a"},
+				tql.Literal{Value: nil},
+				tql.Literal{Value: "b"},
+			},
+			expected: "a--b",
+		},
+		{
+			name:      "map value",
+			delimiter: " ",
+			vals: []tql.Getter{
+				tql.Literal{Value: "attrs:"},
+				tql.Literal{Value: pcommon.NewMapFromRaw(map[string]interface{}{"k": "v"})},
+			},
+			expected: `attrs: {"k":"v"}`,
+		},
+		{
+			name:      "no values",
+			delimiter: " ",
+			vals:      []tql.Getter{},
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Concat(tt.delimiter, tt.vals)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_convertCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   tql.Getter
+		toCase   string
+		expected interface{}
+	}{
+		{
+			name:     "lower",
+			target:   tql.Literal{Value: "Hello World"},
+			toCase:   "lower",
+			expected: "hello world",
+		},
+		{
+			name:     "upper",
+			target:   tql.Literal{Value: "Hello World"},
+			toCase:   "upper",
+			expected: "HELLO WORLD",
+		},
+		{
+			name:     "snake from camel",
+			target:   tql.Literal{Value: "HTTPRequestCount"},
+			toCase:   "snake",
+			expected: "http_request_count",
+		},
+		{
+			name:     "snake from separators",
+			target:   tql.Literal{Value: "http.request-count total"},
+			toCase:   "snake",
+			expected: "http_request_count_total",
+		},
+		{
+			name:     "camel from snake",
+			target:   tql.Literal{Value: "http_request_count"},
+			toCase:   "camel",
+			expected: "HttpRequestCount",
+		},
+		{
+			name:     "camel with digits",
+			target:   tql.Literal{Value: "status2xx.count"},
+			toCase:   "camel",
+			expected: "Status2xxCount",
+		},
+		{
+			name:     "empty string",
+			target:   tql.Literal{Value: ""},
+			toCase:   "snake",
+			expected: "",
+		},
+		{
+			name:     "target not a string",
+			target:   tql.Literal{Value: int64(1)},
+			toCase:   "upper",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ConvertCase(tt.target, tt.toCase)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_convertCase_validation(t *testing.T) {
+	_, err := ConvertCase(tql.Literal{Value: "anything"}, "kebab")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_int(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   tql.Getter
+		expected interface{}
+	}{
+		{
+			name:     "int",
+			target:   tql.Literal{Value: int64(10)},
+			expected: int64(10),
+		},
+		{
+			name:     "float is truncated",
+			target:   tql.Literal{Value: -1.9},
+			expected: int64(-1),
+		},
+		{
+			name:     "true",
+			target:   tql.Literal{Value: true},
+			expected: int64(1),
+		},
+		{
+			name:     "false",
+			target:   tql.Literal{Value: false},
+			expected: int64(0),
+		},
+		{
+			name:     "string",
+			target:   tql.Literal{Value: "-42"},
+			expected: int64(-42),
+		},
+		{
+			name:     "invalid string",
+			target:   tql.Literal{Value: "1.5"},
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			target:   tql.Literal{Value: nil},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Int(tt.target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func ParseJSON(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		valStr, ok := target.Get(ctx).(string)
+		if !ok {
+			return nil
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(valStr))
+		decoder.UseNumber()
+		var parsed map[string]interface{}
+		if err := decoder.Decode(&parsed); err != nil || parsed == nil {
+			return nil
+		}
+		result := pcommon.NewMapFromRaw(normalizeJSONNumbers(parsed).(map[string]interface{}))
+		sortMap(result)
+		return result
+	}, nil
+}
+
+// sortMap sorts m and all maps nested in it by key, so that the result does not depend on the
+// iteration order of the Go map the JSON was decoded into.
+func sortMap(m pcommon.Map) {
+	m.Sort()
+	m.Range(func(_ string, v pcommon.Value) bool {
+		sortValue(v)
+		return true
+	})
+}
+
+func sortValue(v pcommon.Value) {
+	switch v.Type() {
+	case pcommon.ValueTypeMap:
+		sortMap(v.MapVal())
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < v.SliceVal().Len(); i++ {
+			sortValue(v.SliceVal().At(i))
+		}
+	}
+}
+
+// normalizeJSONNumbers replaces the json.Numbers in val with an int64 if the number is an integer
+// and a float64 otherwise, so that integers survive the conversion into a pcommon.Map.
+func normalizeJSONNumbers(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = normalizeJSONNumbers(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeJSONNumbers(elem)
+		}
+	}
+	return val
+}
@@ -0,0 +1,113 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_parseJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   tql.Getter
+		expected interface{}
+	}{
+		{
+			name:   "flat object",
+			target: tql.Literal{Value: `{"string":"value","int":1,"float":1.5,"bool":true,"null":null}`},
+			expected: pcommon.NewMapFromRaw(map[string]interface{}{
+				"string": "value",
+				"int":    int64(1),
+				"float":  1.5,
+				"bool":   true,
+				"null":   nil,
+			}),
+		},
+		{
+			name:   "nested object and array",
+			target: tql.Literal{Value: `{"nested":{"key":"value"},"array":[1,"two",{"three":3}]}`},
+			expected: pcommon.NewMapFromRaw(map[string]interface{}{
+				"nested": map[string]interface{}{
+					"key": "value",
+				},
+				"array": []interface{}{
+					int64(1),
+					"two",
+					map[string]interface{}{
+						"three": int64(3),
+					},
+				},
+			}),
+		},
+		{
+			name:     "not an object",
+			target:   tql.Literal{Value: `[1, 2]`},
+			expected: nil,
+		},
+		{
+			name:     "null",
+			target:   tql.Literal{Value: `null`},
+			expected: nil,
+		},
+		{
+			name:     "invalid json",
+			target:   tql.Literal{Value: `{"key":`},
+			expected: nil,
+		},
+		{
+			name:     "target not a string",
+			target:   tql.Literal{Value: int64(1)},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON(tt.target)
+			assert.NoError(t, err)
+			actual := exprFunc(tqltest.TestTransformContext{})
+			if tt.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Equal(t, tt.expected.(pcommon.Map).AsRaw(), actual.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_parseJSON_sortsKeys(t *testing.T) {
+	exprFunc, err := ParseJSON(tql.Literal{Value: `{"b":1,"c":{"z":1,"y":2},"a":[{"k2":1,"k1":2}]}`})
+	assert.NoError(t, err)
+
+	expected := pcommon.NewMap()
+	a := pcommon.NewValueSlice()
+	elem := a.SliceVal().AppendEmpty()
+	pcommon.NewValueMap().CopyTo(elem)
+	elem.MapVal().InsertInt("k1", 2)
+	elem.MapVal().InsertInt("k2", 1)
+	expected.Insert("a", a)
+	expected.InsertInt("b", 1)
+	c := pcommon.NewValueMap()
+	c.MapVal().InsertInt("y", 2)
+	c.MapVal().InsertInt("z", 1)
+	expected.Insert("c", c)
+
+	assert.Equal(t, expected, exprFunc(tqltest.TestTransformContext{}))
+}
@@ -0,0 +1,71 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_split(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    tql.Getter
+		delimiter string
+		expected  interface{}
+	}{
+		{
+			name:      "split string",
+			target:    tql.Literal{Value: "A|B|C"},
+			delimiter: "|",
+			expected:  []string{"A", "B", "C"},
+		},
+		{
+			name:      "delimiter not found",
+			target:    tql.Literal{Value: "A|B|C"},
+			delimiter: ",",
+			expected:  []string{"A|B|C"},
+		},
+		{
+			name:      "empty string",
+			target:    tql.Literal{Value: ""},
+			delimiter: ",",
+			expected:  []string{""},
+		},
+		{
+			name:      "target not a string",
+			target:    tql.Literal{Value: int64(1)},
+			delimiter: ",",
+			expected:  nil,
+		},
+		{
+			name:      "target nil",
+			target:    tql.Literal{Value: nil},
+			delimiter: ",",
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Split(tt.target, tt.delimiter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
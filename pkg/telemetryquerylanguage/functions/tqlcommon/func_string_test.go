@@ -0,0 +1,92 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_string(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   tql.Getter
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			target:   tql.Literal{Value: "hello"},
+			expected: "hello",
+		},
+		{
+			name:     "int",
+			target:   tql.Literal{Value: int64(-42)},
+			expected: "-42",
+		},
+		{
+			name:     "float",
+			target:   tql.Literal{Value: 3.25},
+			expected: "3.25",
+		},
+		{
+			name:     "bool",
+			target:   tql.Literal{Value: false},
+			expected: "false",
+		},
+		{
+			name:     "bytes",
+			target:   tql.Literal{Value: []byte{0xde, 0xad}},
+			expected: "dead",
+		},
+		{
+			name:     "map",
+			target:   tql.Literal{Value: pcommon.NewMapFromRaw(map[string]interface{}{"a": int64(1), "b": "c"})},
+			expected: `{"a":1,"b":"c"}`,
+		},
+		{
+			name:     "slice",
+			target:   tql.Literal{Value: pcommon.NewSliceFromRaw([]interface{}{"a", int64(1)})},
+			expected: `["a",1]`,
+		},
+		{
+			name:     "stringer",
+			target:   tql.Literal{Value: 2 * time.Second},
+			expected: "2s",
+		},
+		{
+			name:     "nil",
+			target:   tql.Literal{Value: nil},
+			expected: nil,
+		},
+		{
+			name:     "unsupported type",
+			target:   tql.Literal{Value: int32(1)},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := String(tt.target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func Substring(target tql.Getter, start int64, length int64) (tql.ExprFunc, error) {
+	if start < 0 {
+		return nil, fmt.Errorf("invalid start for Substring function, %d cannot be negative", start)
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length for Substring function, %d cannot be negative or zero", length)
+	}
+
+	return func(ctx tql.TransformContext) interface{} {
+		if valStr, ok := target.Get(ctx).(string); ok {
+			if start+length > int64(len(valStr)) {
+				return nil
+			}
+			return valStr[start : start+length]
+		}
+		return nil
+	}, nil
+}
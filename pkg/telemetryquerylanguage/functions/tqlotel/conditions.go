@@ -0,0 +1,36 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+)
+
+// ConditionFunctions returns the functions that can be used in conditions parsed with tql.ParseConditions.
+// Only functions that return a value without changing the telemetry are included. A new map is returned
+// on each call, so the functions of one component are not affected by another.
+func ConditionFunctions() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID":     TraceID,
+		"SpanID":      SpanID,
+		"IsMatch":     tqlcommon.IsMatch,
+		"Concat":      tqlcommon.Concat,
+		"Substring":   tqlcommon.Substring,
+		"Int":         tqlcommon.Int,
+		"Double":      tqlcommon.Double,
+		"String":      tqlcommon.String,
+		"ConvertCase": tqlcommon.ConvertCase,
+	}
+}
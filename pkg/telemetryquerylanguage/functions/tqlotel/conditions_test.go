@@ -0,0 +1,67 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+type itemGetSetter struct{}
+
+func (itemGetSetter) Get(ctx tql.TransformContext) interface{} {
+	return ctx.GetItem()
+}
+
+func (itemGetSetter) Set(tql.TransformContext, interface{}) {}
+
+func Test_ConditionFunctions(t *testing.T) {
+	parsePath := func(*tql.Path) (tql.GetSetter, error) {
+		return itemGetSetter{}, nil
+	}
+	parseEnum := func(*tql.EnumSymbol) (*tql.Enum, error) {
+		return nil, nil
+	}
+
+	evaluators, err := tql.ParseConditions(
+		[]string{
+			`Substring(name, 1, 2) == "id"`,
+			`Concat("-", name, "1") == "fido-1"`,
+		},
+		ConditionFunctions(),
+		parsePath,
+		parseEnum,
+	)
+	require.NoError(t, err)
+	require.Len(t, evaluators, 2)
+	ctx := tqltest.TestTransformContext{Item: "fido"}
+	assert.True(t, evaluators[0](ctx))
+	assert.True(t, evaluators[1](ctx))
+
+	// Functions changing the telemetry are not available.
+	_, err = tql.ParseConditions([]string{`set(name, "rex")`}, ConditionFunctions(), parsePath, parseEnum)
+	assert.Error(t, err)
+}
+
+func Test_ConditionFunctions_copy(t *testing.T) {
+	functions := ConditionFunctions()
+	delete(functions, "Concat")
+	assert.Contains(t, ConditionFunctions(), "Concat")
+}
@@ -16,6 +16,8 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // BoolExpressionEvaluator is a function that returns the result.
@@ -82,11 +84,88 @@ func newComparisonEvaluator(comparison *Comparison, functions map[string]interfa
 			b := right.Get(ctx)
 			return a != b
 		}, nil
+	case "<", "<=", ">", ">=":
+		op := comparison.Op
+		return func(ctx TransformContext) bool {
+			return compareOrdered(op, left.Get(ctx), right.Get(ctx))
+		}, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized boolean operation %v", comparison.Op)
 }
 
+// compareOrdered applies one of the ordering operators to a and b. Ints and floats can be compared with each
+// other, strings are compared lexicographically and durations can only be compared with durations.
+// Values of any other combination of types are not ordered, so the comparison is false.
+func compareOrdered(op string, a interface{}, b interface{}) bool {
+	cmp, ok := compareValues(a, b)
+	if !ok {
+		return false
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func compareValues(a interface{}, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return compareInts(av, bv), true
+		case float64:
+			return compareFloats(float64(av), bv)
+		}
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return compareFloats(av, float64(bv))
+		case float64:
+			return compareFloats(av, bv)
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), true
+		}
+	case time.Duration:
+		if bv, ok := b.(time.Duration); ok {
+			return compareInts(int64(av), int64(bv)), true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a float64, b float64) (int, bool) {
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	case a == b:
+		return 0, true
+	}
+	// NaN is not ordered
+	return 0, false
+}
+
 func newBooleanExpressionEvaluator(expr *BooleanExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (BoolExpressionEvaluator, error) {
 	if expr == nil {
 		return alwaysTrue, nil
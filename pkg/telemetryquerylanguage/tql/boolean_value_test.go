@@ -360,3 +360,43 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 		})
 	}
 }
+
+func Test_newComparisonEvaluator_ordered(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{`1 < 2`, true},
+		{`2 <= 2`, true},
+		{`2 > 2`, false},
+		{`3 >= 2`, true},
+		{`1 < 1.5`, true},
+		{`2.5 > 2`, true},
+		{`"a" < "b"`, true},
+		{`"b" <= "a"`, false},
+		{`end_time_unix_nano > start_time_unix_nano`, true},
+		{`(end_time_unix_nano - start_time_unix_nano) / 1000000 >= 250`, true},
+		{`duration > duration / 2`, true},
+		{`TEST_ENUM_TWO >= TEST_ENUM_ONE`, true},
+		{`name > 1`, false},
+		{`duration < 5`, false},
+		{`duration >= 3s`, true},
+		{`duration * 2 < 1m`, true},
+		{`1h > 59m59.5s`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			evaluators, err := ParseConditions([]string{tt.condition}, mathFunctions(), mathParsePath, testParseEnum)
+			assert.NoError(t, err)
+			assert.Len(t, evaluators, 1)
+			assert.Equal(t, tt.expected, evaluators[0](tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_compareValues_precision(t *testing.T) {
+	// timestamps in nanoseconds are not exactly representable as float64
+	cmp, ok := compareValues(int64(1660000000000000001), int64(1660000000000000000))
+	assert.True(t, ok)
+	assert.Equal(t, 1, cmp)
+}
@@ -16,6 +16,7 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
@@ -80,6 +81,9 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 	if s := val.String; s != nil {
 		return &Literal{Value: *s}, nil
 	}
+	if d := val.Duration; d != nil {
+		return &Literal{Value: time.Duration(*d)}, nil
+	}
 	if f := val.Float; f != nil {
 		return &Literal{Value: *f}, nil
 	}
@@ -105,6 +109,10 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		return pathParser(val.Path)
 	}
 
+	if val.MathExpression != nil {
+		return newMathGetter(val.MathExpression, functions, pathParser, enumParser)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the Telemetry Query Language")
@@ -23,10 +23,20 @@ type PathExpressionParser func(*Path) (GetSetter, error)
 
 type EnumParser func(*EnumSymbol) (*Enum, error)
 
+var (
+	exprFuncType = reflect.TypeOf((*ExprFunc)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
 // NewFunctionCall Visible for testing
 func NewFunctionCall(inv Invocation, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (ExprFunc, error) {
 	if f, ok := functions[inv.Function]; ok {
-		args, err := buildArgs(inv, reflect.TypeOf(f), functions, pathParser, enumParser)
+		fType := reflect.TypeOf(f)
+		if err := validateFunctionType(inv.Function, fType); err != nil {
+			return nil, err
+		}
+
+		args, err := buildArgs(inv, fType, functions, pathParser, enumParser)
 		if err != nil {
 			return nil, err
 		}
@@ -44,12 +54,31 @@ func NewFunctionCall(inv Invocation, functions map[string]interface{}, pathParse
 	return nil, fmt.Errorf("undefined function %v", inv.Function)
 }
 
+// validateFunctionType checks that a registered function can be invoked by the TQL: it must return an ExprFunc
+// and an error, and may only have a slice parameter in the last position.
+func validateFunctionType(name string, fType reflect.Type) error {
+	if fType == nil || fType.Kind() != reflect.Func {
+		return fmt.Errorf("function %v is not a func", name)
+	}
+	if fType.NumOut() != 2 || fType.Out(0) != exprFuncType || fType.Out(1) != errorType {
+		return fmt.Errorf("function %v must return (ExprFunc, error)", name)
+	}
+	for i := 0; i < fType.NumIn()-1; i++ {
+		if fType.In(i).Kind() == reflect.Slice {
+			return fmt.Errorf("function %v has a slice parameter at position %v, slice parameters must be the last parameter", name, i)
+		}
+	}
+	return nil
+}
+
 func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]reflect.Value, error) {
 	args := make([]reflect.Value, 0)
+	hasSliceArg := false
 	for i := 0; i < fType.NumIn(); i++ {
 		argType := fType.In(i)
 
 		if argType.Kind() == reflect.Slice {
+			hasSliceArg = true
 			err := buildSliceArg(inv, argType, i, &args, functions, pathParser, enumParser)
 			if err != nil {
 				return nil, err
@@ -66,6 +95,9 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 			}
 		}
 	}
+	if !hasSliceArg && len(inv.Arguments) > fType.NumIn() {
+		return nil, fmt.Errorf("too many arguments for function %v", inv.Function)
+	}
 	return args, nil
 }
 
@@ -100,7 +132,7 @@ func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Uint8.String():
-		if inv.Arguments[startingIndex].Bytes == nil {
+		if startingIndex >= len(inv.Arguments) || inv.Arguments[startingIndex].Bytes == nil {
 			return fmt.Errorf("invalid argument for slice parameter at position %v, must be a byte slice literal", startingIndex)
 		}
 		*args = append(*args, reflect.ValueOf(([]byte)(*inv.Arguments[startingIndex].Bytes)))
@@ -163,6 +195,8 @@ func buildArg(argDef Value, argType reflect.Type, index int, args *[]reflect.Val
 			return fmt.Errorf("invalid argument at position %v, must be a bool", index)
 		}
 		*args = append(*args, reflect.ValueOf(bool(*argDef.Bool)))
+	default:
+		return fmt.Errorf("unsupported argument type '%s' at position %v", argType.Name(), index)
 	}
 	return nil
 }
@@ -32,6 +32,10 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 	functions["testing_string"] = functionWithString
 	functions["testing_byte_slice"] = functionWithByteSlice
 	functions["testing_enum"] = functionWithEnum
+	functions["testing_unsupported_arg"] = functionWithUnsupportedArg
+	functions["testing_slice_not_last"] = functionWithSliceNotLast
+	functions["testing_invalid_return"] = functionWithInvalidReturn
+	functions["testing_not_a_func"] = "not a func"
 
 	tests := []struct {
 		name string
@@ -133,6 +137,63 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "too many args",
+			inv: Invocation{
+				Function: "testing_string",
+				Arguments: []Value{
+					{
+						String: tqltest.Strp("test"),
+					},
+					{
+						String: tqltest.Strp("test"),
+					},
+				},
+			},
+		},
+		{
+			name: "missing byte slice arg",
+			inv: Invocation{
+				Function: "testing_byte_slice",
+			},
+		},
+		{
+			name: "unsupported arg type",
+			inv: Invocation{
+				Function: "testing_unsupported_arg",
+				Arguments: []Value{
+					{
+						Int: tqltest.Intp(10),
+					},
+				},
+			},
+		},
+		{
+			name: "slice arg not last",
+			inv: Invocation{
+				Function: "testing_slice_not_last",
+				Arguments: []Value{
+					{
+						String: tqltest.Strp("test"),
+					},
+					{
+						String: tqltest.Strp("test"),
+					},
+				},
+			},
+		},
+		{
+			name: "invalid return type",
+			inv: Invocation{
+				Function: "testing_invalid_return",
+			},
+		},
+		{
+			name: "not a function",
+			inv: Invocation{
+				Function: "testing_not_a_func",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -508,6 +569,22 @@ func functionWithEnum(_ Enum) (ExprFunc, error) {
 	}, nil
 }
 
+func functionWithUnsupportedArg(_ int32) (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return "anything"
+	}, nil
+}
+
+func functionWithSliceNotLast(_ []string, _ string) (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return "anything"
+	}, nil
+}
+
+func functionWithInvalidReturn() (string, error) {
+	return "anything", nil
+}
+
 func DefaultFunctionsForTests() map[string]interface{} {
 	functions := make(map[string]interface{})
 	functions["testing_string_slice"] = functionWithStringSlice
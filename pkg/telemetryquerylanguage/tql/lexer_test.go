@@ -57,6 +57,13 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"basic_ordering", "3>=4.9 < 5", false, []result{
+			{"Int", "3"},
+			{"OpComparison", ">="},
+			{"Float", "4.9"},
+			{"OpComparison", "<"},
+			{"Int", "5"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
@@ -97,6 +104,31 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"basic_math", `1+2-3*4/5`, false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"Int", "2"},
+			{"OpAddSub", "-"},
+			{"Int", "3"},
+			{"OpMultDiv", "*"},
+			{"Int", "4"},
+			{"OpMultDiv", "/"},
+			{"Int", "5"},
+		}},
+		{"signed_numbers", `-1 +.5 -2.5e-3`, false, []result{
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"Float", ".5"},
+			{"OpAddSub", "-"},
+			{"Float", "2.5e-3"},
+		}},
+		{"durations", `1h30m -.5s 10ms`, false, []result{
+			{"Duration", "1h30m"},
+			{"OpAddSub", "-"},
+			{"Duration", ".5s"},
+			{"Duration", "10ms"},
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"fmt"
+	"time"
+)
+
+// mathType is the type of a math operand that is known while parsing. Paths and invocations
+// are only resolved during execution, so their type is unknown.
+type mathType int
+
+const (
+	mathTypeUnknown mathType = iota
+	mathTypeInt
+	mathTypeFloat
+	mathTypeString
+	mathTypeDuration
+)
+
+func (t mathType) String() string {
+	switch t {
+	case mathTypeInt:
+		return "int"
+	case mathTypeFloat:
+		return "float"
+	case mathTypeString:
+		return "string"
+	case mathTypeDuration:
+		return "duration"
+	}
+	return "unknown"
+}
+
+// mathGetter is a Getter for a math operand along with its type as far as it is known while parsing.
+type mathGetter struct {
+	Getter
+	typ mathType
+}
+
+type mathOpGetter struct {
+	op    string
+	left  Getter
+	right Getter
+}
+
+func (g mathOpGetter) Get(ctx TransformContext) interface{} {
+	return performOp(g.op, g.left.Get(ctx), g.right.Get(ctx))
+}
+
+func newMathGetter(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	result, err := newMathExpressionGetter(expr, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	return result.Getter, nil
+}
+
+func newMathExpressionGetter(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (*mathGetter, error) {
+	left, err := newAddSubTermGetter(expr.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		right, err := newAddSubTermGetter(rhs.Term, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		if left, err = combineMathGetters(rhs.Operator, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func newAddSubTermGetter(term *AddSubTerm, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (*mathGetter, error) {
+	left, err := newMathValueGetter(term.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		right, err := newMathValueGetter(rhs.Value, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		if left, err = combineMathGetters(rhs.Operator, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func newMathValueGetter(value *MathValue, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (*mathGetter, error) {
+	if value.SubExpression != nil {
+		return newMathExpressionGetter(value.SubExpression, functions, pathParser, enumParser)
+	}
+
+	literal := value.Literal
+	switch {
+	case literal.String != nil:
+		return &mathGetter{Getter: &Literal{Value: *literal.String}, typ: mathTypeString}, nil
+	case literal.Duration != nil:
+		return &mathGetter{Getter: &Literal{Value: time.Duration(*literal.Duration)}, typ: mathTypeDuration}, nil
+	case literal.Float != nil:
+		return &mathGetter{Getter: &Literal{Value: *literal.Float}, typ: mathTypeFloat}, nil
+	case literal.Int != nil:
+		return &mathGetter{Getter: &Literal{Value: *literal.Int}, typ: mathTypeInt}, nil
+	case literal.Path != nil:
+		getter, err := pathParser(literal.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &mathGetter{Getter: getter}, nil
+	case literal.Invocation != nil:
+		call, err := NewFunctionCall(*literal.Invocation, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		return &mathGetter{Getter: &exprGetter{expr: call}}, nil
+	}
+	// In practice, can't happen since the DSL grammar guarantees one is set
+	return nil, fmt.Errorf("no math value field set. This is a bug in the Telemetry Query Language")
+}
+
+// combineMathGetters type checks the operands of op as far as their types are known while
+// parsing and returns a getter that applies op to them.
+func combineMathGetters(op string, left *mathGetter, right *mathGetter) (*mathGetter, error) {
+	if (left.typ == mathTypeString || right.typ == mathTypeString) && op != "+" {
+		return nil, fmt.Errorf("operator %v is not supported for strings", op)
+	}
+	typ, ok := mathResultType(op, left.typ, right.typ)
+	if !ok {
+		return nil, fmt.Errorf("mismatched types in math expression: %v %v %v", left.typ, op, right.typ)
+	}
+	if op == "/" && right.typ == mathTypeInt {
+		if l, ok := right.Getter.(*Literal); ok && l.Value == int64(0) {
+			return nil, fmt.Errorf("division by zero")
+		}
+	}
+
+	return &mathGetter{
+		Getter: mathOpGetter{op: op, left: left.Getter, right: right.Getter},
+		typ:    typ,
+	}, nil
+}
+
+// mathResultType returns the type of applying op to operands of the given types, and false if
+// the types can't be combined with op. Unknown types are assumed to be valid operands.
+func mathResultType(op string, left mathType, right mathType) (mathType, bool) {
+	if left != mathTypeDuration && right != mathTypeDuration {
+		if left == mathTypeUnknown {
+			return right, true
+		}
+		return left, right == mathTypeUnknown || left == right
+	}
+
+	// Durations are added to and subtracted from durations, and multiplied or divided by ints.
+	isDuration := func(t mathType) bool { return t == mathTypeDuration || t == mathTypeUnknown }
+	isInt := func(t mathType) bool { return t == mathTypeInt || t == mathTypeUnknown }
+	switch op {
+	case "+", "-":
+		return mathTypeDuration, isDuration(left) && isDuration(right)
+	case "*":
+		return mathTypeDuration, (left == mathTypeDuration && isInt(right)) || (isInt(left) && right == mathTypeDuration)
+	case "/":
+		return mathTypeDuration, left == mathTypeDuration && isInt(right)
+	}
+	return mathTypeUnknown, false
+}
+
+// performOp applies op to the operands. Ints and floats support all operators, durations can be
+// added to and subtracted from durations and multiplied or divided by ints, and strings are
+// concatenated with +.
+// nil is returned if the operand types are not supported or an int is divided by zero.
+func performOp(op string, left interface{}, right interface{}) interface{} {
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return performIntOp(op, l, r)
+		case time.Duration:
+			if op == "*" {
+				return time.Duration(l) * r
+			}
+		}
+	case float64:
+		if r, ok := right.(float64); ok {
+			return performFloatOp(op, l, r)
+		}
+	case time.Duration:
+		switch r := right.(type) {
+		case time.Duration:
+			switch op {
+			case "+":
+				return l + r
+			case "-":
+				return l - r
+			}
+		case int64:
+			switch op {
+			case "*":
+				return l * time.Duration(r)
+			case "/":
+				if r != 0 {
+					return l / time.Duration(r)
+				}
+			}
+		}
+	case string:
+		if r, ok := right.(string); ok && op == "+" {
+			return l + r
+		}
+	}
+	return nil
+}
+
+func performIntOp(op string, left int64, right int64) interface{} {
+	switch op {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		if right != 0 {
+			return left / right
+		}
+	}
+	return nil
+}
+
+func performFloatOp(op string, left float64, right float64) interface{} {
+	switch op {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		return left / right
+	}
+	return nil
+}
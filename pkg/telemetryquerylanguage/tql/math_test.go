@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func mathParsePath(val *Path) (GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		switch val.Fields[0].Name {
+		case "start_time_unix_nano":
+			return &StandardGetSetter{
+				Getter: func(ctx TransformContext) interface{} {
+					return int64(1000000000)
+				},
+			}, nil
+		case "end_time_unix_nano":
+			return &StandardGetSetter{
+				Getter: func(ctx TransformContext) interface{} {
+					return int64(1250000000)
+				},
+			}, nil
+		case "name":
+			return &StandardGetSetter{
+				Getter: func(ctx TransformContext) interface{} {
+					return "operation"
+				},
+			}, nil
+		case "duration":
+			return &StandardGetSetter{
+				Getter: func(ctx TransformContext) interface{} {
+					return 3 * time.Second
+				},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func mathFunctions() map[string]interface{} {
+	return map[string]interface{}{
+		"hello": hello,
+		"set": func(setter Setter, getter Getter) (ExprFunc, error) {
+			return func(ctx TransformContext) interface{} {
+				setter.Set(ctx, getter.Get(ctx))
+				return nil
+			}, nil
+		},
+		"echo": func(getter Getter) (ExprFunc, error) {
+			return func(ctx TransformContext) interface{} {
+				return getter.Get(ctx)
+			}, nil
+		},
+	}
+}
+
+func Test_mathExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{
+			name:     "int addition",
+			input:    `1 + 1`,
+			expected: int64(2),
+		},
+		{
+			name:     "int subtraction without spaces",
+			input:    `10-4`,
+			expected: int64(6),
+		},
+		{
+			name:     "negative operand",
+			input:    `10 - -4`,
+			expected: int64(14),
+		},
+		{
+			name:     "precedence",
+			input:    `1 + 2 * 3 - 4 / 2`,
+			expected: int64(5),
+		},
+		{
+			name:     "parentheses",
+			input:    `(1 + 2) * (3 - 1)`,
+			expected: int64(6),
+		},
+		{
+			name:     "nested parentheses",
+			input:    `((1 + 2) * 3) / (2 - 1)`,
+			expected: int64(9),
+		},
+		{
+			name:     "left associativity",
+			input:    `10 - 4 - 3`,
+			expected: int64(3),
+		},
+		{
+			name:     "float math",
+			input:    `1.5 * 2.0 + .5`,
+			expected: 3.5,
+		},
+		{
+			name:     "float division",
+			input:    `1.0 / 4.0`,
+			expected: 0.25,
+		},
+		{
+			name:     "paths",
+			input:    `(end_time_unix_nano - start_time_unix_nano) / 1000000`,
+			expected: int64(250),
+		},
+		{
+			name:     "string concatenation",
+			input:    `"GET " + name + "/" + hello()`,
+			expected: "GET operation/world",
+		},
+		{
+			name:     "invocation with math argument",
+			input:    `echo(2 * 3) * 2`,
+			expected: int64(12),
+		},
+		{
+			name:     "duration arithmetic",
+			input:    `duration * 2 - duration / 3`,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "duration literals",
+			input:    `duration + 1m30s - 500ms * 2`,
+			expected: 92 * time.Second,
+		},
+		{
+			name:     "int times duration literal",
+			input:    `2 * 1h / 4`,
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "mismatched runtime types",
+			input:    `name - 1`,
+			expected: nil,
+		},
+		{
+			name:     "runtime division by zero",
+			input:    `start_time_unix_nano / (end_time_unix_nano - end_time_unix_nano)`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery(`set(name, ` + tt.input + `)`)
+			require.NoError(t, err)
+			require.NotNil(t, parsed.Invocation.Arguments[1].MathExpression)
+
+			getter, err := NewGetter(parsed.Invocation.Arguments[1], mathFunctions(), mathParsePath, testParseEnum)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, getter.Get(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_mathExpression_typeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "int and float",
+			input: `1 + 1.0`,
+			err:   "mismatched types in math expression: int + float",
+		},
+		{
+			name:  "string and int",
+			input: `"a" + 1`,
+			err:   "mismatched types in math expression: string + int",
+		},
+		{
+			name:  "subtract strings",
+			input: `"a" - "b"`,
+			err:   "operator - is not supported for strings",
+		},
+		{
+			name:  "multiply string path",
+			input: `name * "b"`,
+			err:   "operator * is not supported for strings",
+		},
+		{
+			name:  "type propagated from subexpression",
+			input: `(end_time_unix_nano - 1) * 2.5`,
+			err:   "mismatched types in math expression: int * float",
+		},
+		{
+			name:  "int and duration",
+			input: `1 + 1s`,
+			err:   "mismatched types in math expression: int + duration",
+		},
+		{
+			name:  "multiply durations",
+			input: `1s * 2s`,
+			err:   "mismatched types in math expression: duration * duration",
+		},
+		{
+			name:  "divide by duration",
+			input: `end_time_unix_nano / 1s`,
+			err:   "mismatched types in math expression: unknown / duration",
+		},
+		{
+			name:  "float and duration",
+			input: `1s * 1.5`,
+			err:   "mismatched types in math expression: duration * float",
+		},
+		{
+			name:  "duration type propagated from subexpression",
+			input: `(2 * 1s) + 1`,
+			err:   "mismatched types in math expression: duration + int",
+		},
+		{
+			name:  "duration division by zero",
+			input: `1s / 0`,
+			err:   "division by zero",
+		},
+		{
+			name:  "division by zero",
+			input: `end_time_unix_nano / 0`,
+			err:   "division by zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery(`set(name, ` + tt.input + `)`)
+			require.NoError(t, err)
+
+			_, err = NewGetter(parsed.Invocation.Arguments[1], mathFunctions(), mathParsePath, testParseEnum)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_mathExpression_where(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{
+			name:      "math on the left",
+			condition: `(end_time_unix_nano - start_time_unix_nano) / 1000000 == 250`,
+			expected:  true,
+		},
+		{
+			name:      "math on both sides",
+			condition: `end_time_unix_nano - start_time_unix_nano != 250 * 1000000`,
+			expected:  false,
+		},
+		{
+			name:      "string concatenation",
+			condition: `name + "!" == "operation!"`,
+			expected:  true,
+		},
+		{
+			name:      "combined with boolean subexpressions",
+			condition: `(name == "other" or 2 * 2 == 4) and (1 + 1 == 2)`,
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery(`set(name, "test") where ` + tt.condition)
+			require.NoError(t, err)
+
+			evaluate, err := newBooleanExpressionEvaluator(parsed.WhereClause, mathFunctions(), mathParsePath, testParseEnum)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, evaluate(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_mathExpression_parseQueries(t *testing.T) {
+	_, err := ParseQueries(
+		[]string{`set(name, "a" * 2)`},
+		mathFunctions(),
+		mathParsePath,
+		testParseEnum,
+	)
+	assert.EqualError(t, err, "invalid argument at position 1 operator * is not supported for strings")
+}
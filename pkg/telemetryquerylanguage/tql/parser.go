@@ -16,6 +16,8 @@ package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"encoding/hex"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
@@ -29,6 +31,13 @@ type ParsedQuery struct {
 	WhereClause *BooleanExpression `( "where" @@ )?`
 }
 
+// ParsedCondition represents a parsed condition. It is the entry point into the DSL for conditions,
+// which are written like the where clause of a query but without the "where" keyword.
+// nolint:govet
+type ParsedCondition struct {
+	Condition *BooleanExpression `@@`
+}
+
 // BooleanValue represents something that evaluates to a boolean --
 // either an equality or inequality, explicit true or false, or
 // a parenthesized subexpression.
@@ -84,17 +93,66 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal, or math expression.
 // nolint:govet
 type Value struct {
+	IsNil          *IsNil          `( @"nil"`
+	Invocation     *Invocation     `| ( @@`
+	String         *string         `| @String`
+	Duration       *Duration       `| @(OpAddSub? Duration)`
+	Float          *float64        `| @(OpAddSub? Float)`
+	Int            *int64          `| @(OpAddSub? Int)`
+	Path           *Path           `| @@ ) (?! OpAddSub | OpMultDiv)`
+	MathExpression *MathExpression `| @@`
+	Bytes          *Bytes          `| @Bytes`
+	Bool           *Boolean        `| @Boolean`
+	Enum           *EnumSymbol     `| @Uppercase )`
+}
+
+// MathExpression represents an arbitrary number of AddSubTerms joined by + or -.
+// nolint:govet
+type MathExpression struct {
+	Left  *AddSubTerm     `@@`
+	Right []*OpAddSubTerm `@@*`
+}
+
+// OpAddSubTerm represents the right side of an addition or subtraction.
+// nolint:govet
+type OpAddSubTerm struct {
+	Operator string      `@OpAddSub`
+	Term     *AddSubTerm `@@`
+}
+
+// AddSubTerm represents an arbitrary number of MathValues joined by * or /.
+// Multiplication and division therefore have higher precedence than addition and subtraction.
+// nolint:govet
+type AddSubTerm struct {
+	Left  *MathValue        `@@`
+	Right []*OpMultDivValue `@@*`
+}
+
+// OpMultDivValue represents the right side of a multiplication or division.
+// nolint:govet
+type OpMultDivValue struct {
+	Operator string     `@OpMultDiv`
+	Value    *MathValue `@@`
+}
+
+// MathValue represents an operand of a MathExpression, either a literal or a parenthesized subexpression.
+// nolint:govet
+type MathValue struct {
+	Literal       *MathExprLiteral `( @@`
+	SubExpression *MathExpression  `| "(" @@ ")" )`
+}
+
+// MathExprLiteral represents the Values that can be used as operands of a MathExpression.
+// nolint:govet
+type MathExprLiteral struct {
 	Invocation *Invocation `( @@`
-	Bytes      *Bytes      `| @Bytes`
 	String     *string     `| @String`
-	Float      *float64    `| @Float`
-	Int        *int64      `| @Int`
-	Bool       *Boolean    `| @Boolean`
-	IsNil      *IsNil      `| @"nil"`
-	Enum       *EnumSymbol `| @Uppercase`
+	Duration   *Duration   `| @(OpAddSub? Duration)`
+	Float      *float64    `| @(OpAddSub? Float)`
+	Int        *int64      `| @(OpAddSub? Int)`
 	Path       *Path       `| @@ )`
 }
 
@@ -107,8 +165,15 @@ type Path struct {
 // Field is an item within a Path.
 // nolint:govet
 type Field struct {
-	Name   string  `@Lowercase`
-	MapKey *string `( "[" @String "]" )?`
+	Name string `@Lowercase`
+	Keys []Key  `( "[" @@ "]" )*`
+}
+
+// Key represents a map key or slice index following a Field. Chained Keys index into nested maps and slices.
+// nolint:govet
+type Key struct {
+	String *string `( @String`
+	Int    *int64  `| @Int )`
 }
 
 // Query holds a top level Query for processing telemetry data. A Query is a combination of a function
@@ -140,6 +205,18 @@ func (b *Boolean) Capture(values []string) error {
 	return nil
 }
 
+// Duration type for capturing duration literals such as 1h30m, in the format of time.ParseDuration.
+type Duration time.Duration
+
+func (d *Duration) Capture(values []string) error {
+	duration, err := time.ParseDuration(strings.Join(values, ""))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
 type IsNil bool
 
 func (n *IsNil) Capture(_ []string) error {
@@ -181,7 +258,38 @@ func ParseQueries(statements []string, functions map[string]interface{}, pathPar
 	return queries, nil
 }
 
-var parser = newParser()
+// ParseConditions parses conditions into BoolExpressionEvaluators, which can be used to decide whether
+// telemetry matches without invoking a function.
+func ParseConditions(conditions []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]BoolExpressionEvaluator, error) {
+	evaluators := make([]BoolExpressionEvaluator, 0, len(conditions))
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluator, err := newBooleanExpressionEvaluator(parsed.Condition, functions, pathParser, enumParser)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluators = append(evaluators, evaluator)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return evaluators, nil
+}
+
+// maxLookahead is the number of tokens the parser may backtrack over.
+const maxLookahead = 1 << 16
+
+var parser = newParser(&ParsedQuery{})
+
+var conditionParser = newParser(&ParsedCondition{})
 
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed := &ParsedQuery{}
@@ -192,18 +300,30 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*ParsedCondition, error) {
+	parsed := &ParsedCondition{}
+	err := conditionParser.ParseString("", raw, parsed)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Duration`, Pattern: `((\d*\.)?\d+(ns|us|µs|ms|s|m|h))+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
-		{Name: `OpComparison`, Pattern: `==|!=`},
+		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -214,14 +334,17 @@ func buildLexer() *lexer.StatefulDefinition {
 	})
 }
 
-// newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser {
+// newParser returns a parser that can be used to read a string into the given grammar, either a ParsedQuery
+// or a ParsedCondition. An error will be returned if the string is not formatted for the DSL.
+func newParser(grammar interface{}) *participle.Parser {
 	lex := buildLexer()
-	parser, err := participle.Build(&ParsedQuery{},
+	parser, err := participle.Build(grammar,
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// Values are tried in order and only fall back to a MathExpression once the negative lookahead
+		// for a math operator fails, which requires backtracking over arbitrarily long paths and invocations.
+		participle.UseLookahead(maxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
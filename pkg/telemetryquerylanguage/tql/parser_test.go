@@ -18,8 +18,11 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
 )
@@ -128,8 +131,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -159,8 +166,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -210,8 +221,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -261,8 +276,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -360,8 +379,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bytes"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bytes"),
+											},
+										},
 									},
 								},
 							},
@@ -374,6 +397,82 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "Invocation with duration",
+			query: `set(name, -1m30s)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							Duration: (*Duration)(tqltest.Durationp(-90 * time.Second)),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "chained map keys and slice indexes",
+			query: `set(body["items"][0]["name"], resource.attributes["http"]["request"])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "body",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("items"),
+											},
+											{
+												Int: tqltest.Intp(0),
+											},
+											{
+												String: tqltest.Strp("name"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "resource",
+									},
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("http"),
+											},
+											{
+												String: tqltest.Strp("request"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:  "Invocation with nil",
 			query: `set(attributes["test"], nil)`,
@@ -385,8 +484,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -410,8 +513,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -424,6 +531,164 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "invocation with negative numbers",
+			query: `fff(-12, -1.5)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "fff",
+					Arguments: []Value{
+						{
+							Int: tqltest.Intp(-12),
+						},
+						{
+							Float: tqltest.Floatp(-1.5),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with math expression",
+			query: `set(attributes["test"], (end_time_unix_nano - start_time_unix_nano) / 1000000 + 1)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										SubExpression: &MathExpression{
+											Left: &AddSubTerm{
+												Left: &MathValue{
+													Literal: &MathExprLiteral{
+														Path: &Path{
+															Fields: []Field{
+																{
+																	Name: "end_time_unix_nano",
+																},
+															},
+														},
+													},
+												},
+											},
+											Right: []*OpAddSubTerm{
+												{
+													Operator: "-",
+													Term: &AddSubTerm{
+														Left: &MathValue{
+															Literal: &MathExprLiteral{
+																Path: &Path{
+																	Fields: []Field{
+																		{
+																			Name: "start_time_unix_nano",
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+									Right: []*OpMultDivValue{
+										{
+											Operator: "/",
+											Value: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1000000),
+												},
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: "+",
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(1),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with string concatenation",
+			query: `set(name, Concat("a") + "b")`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Invocation: &Invocation{
+												Function: "Concat",
+												Arguments: []Value{
+													{
+														String: tqltest.Strp("a"),
+													},
+												},
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: "+",
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													String: tqltest.Strp("b"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -457,6 +722,16 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set(name, 1 +)`,
+		`set(name, 1d)`,
+		`set(name, * 2)`,
+		`set(name, (1 + 2)`,
+		`set(name, 1 + 2))`,
+		`set(name, TEST_ENUM + 1)`,
+		`set(attributes[], "foo")`,
+		`set(attributes[-1], "foo")`,
+		`set(attributes[1.5], "foo")`,
+		`set(attributes["foo"]["bar"`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -787,6 +1062,45 @@ func Test_parseWhere(t *testing.T) {
 	}
 }
 
+func Test_ParseConditions(t *testing.T) {
+	evaluators, err := ParseConditions(
+		[]string{
+			`name == "fido"`,
+			`name != "fido" and (name == "rex" or TEST_ENUM_ONE > 0)`,
+		},
+		DefaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+	)
+	require.NoError(t, err)
+	require.Len(t, evaluators, 2)
+
+	ctx := tqltest.TestTransformContext{Item: "fido"}
+	assert.True(t, evaluators[0](ctx))
+	assert.False(t, evaluators[1](ctx))
+
+	ctx = tqltest.TestTransformContext{Item: "rex"}
+	assert.False(t, evaluators[0](ctx))
+	assert.True(t, evaluators[1](ctx))
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	_, err := ParseConditions(
+		[]string{
+			`where name == "fido"`,
+			`set(name, "fido")`,
+			`name ==`,
+			`unknown == "fido"`,
+			`name == "fido"`,
+		},
+		DefaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+	)
+	require.Error(t, err)
+	assert.Len(t, multierr.Errors(err), 4)
+}
+
 var testSymbolTable = map[EnumSymbol]Enum{
 	"TEST_ENUM":     0,
 	"TEST_ENUM_ONE": 1,
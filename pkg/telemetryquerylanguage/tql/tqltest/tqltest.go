@@ -15,6 +15,8 @@
 package tqltest // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -34,6 +36,10 @@ func Boolp(b bool) *bool {
 	return &b
 }
 
+func Durationp(d time.Duration) *time.Duration {
+	return &d
+}
+
 type TestTransformContext struct {
 	Item interface{}
 }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+// ExponentialHistogramValue is the value of an exponential histogram data point.
+type ExponentialHistogramValue struct {
+	Count     uint64
+	Sum       float64
+	ZeroCount uint64
+	Scale     int32
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+// ExponentialBuckets are the positive or negative buckets of an exponential histogram.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+// delta returns the difference between the value and the previous value of the series. Both values are
+// compared at the lowest of their scales. The value itself is returned when it is lower than the previous
+// one, as the series was reset.
+func (v *ExponentialHistogramValue) delta(prev *ExponentialHistogramValue) *ExponentialHistogramValue {
+	scale := v.Scale
+	if prev.Scale < scale {
+		scale = prev.Scale
+	}
+
+	positive, ok := v.Positive.downscale(v.Scale - scale).delta(prev.Positive.downscale(prev.Scale - scale))
+	if !ok {
+		return v
+	}
+	negative, ok := v.Negative.downscale(v.Scale - scale).delta(prev.Negative.downscale(prev.Scale - scale))
+	if !ok {
+		return v
+	}
+	if v.Count < prev.Count || v.ZeroCount < prev.ZeroCount {
+		return v
+	}
+
+	return &ExponentialHistogramValue{
+		Count:     v.Count - prev.Count,
+		Sum:       v.Sum - prev.Sum,
+		ZeroCount: v.ZeroCount - prev.ZeroCount,
+		Scale:     scale,
+		Positive:  positive,
+		Negative:  negative,
+	}
+}
+
+// downscale merges the buckets for a scale lowered by the given amount. The bucket at index i
+// becomes the bucket at index i >> by, rounding towards negative infinity.
+func (b ExponentialBuckets) downscale(by int32) ExponentialBuckets {
+	if by == 0 || len(b.BucketCounts) == 0 {
+		return b
+	}
+	offset := b.Offset >> by
+	last := (b.Offset + int32(len(b.BucketCounts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.BucketCounts {
+		counts[((b.Offset+int32(i))>>by)-offset] += count
+	}
+	return ExponentialBuckets{Offset: offset, BucketCounts: counts}
+}
+
+// delta returns the difference between the buckets and the previous buckets, which must have the same scale.
+// It returns false if a bucket count is lower than the previous one, including when the bucket isn't
+// covered anymore after a shift of the offset.
+func (b ExponentialBuckets) delta(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	counts := make([]uint64, len(b.BucketCounts))
+	copy(counts, b.BucketCounts)
+	for i, prevCount := range prev.BucketCounts {
+		if prevCount == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i) - b.Offset
+		if index < 0 || index >= int32(len(counts)) || counts[index] < prevCount {
+			return ExponentialBuckets{}, false
+		}
+		counts[index] -= prevCount
+	}
+	return ExponentialBuckets{Offset: b.Offset, BucketCounts: counts}, true
+}
@@ -94,5 +94,7 @@ func (mi *MetricIdentity) IsFloatVal() bool {
 }
 
 func (mi *MetricIdentity) IsSupportedMetricType() bool {
-	return mi.MetricDataType == pmetric.MetricDataTypeSum || mi.MetricDataType == pmetric.MetricDataTypeHistogram
+	return mi.MetricDataType == pmetric.MetricDataTypeSum ||
+		mi.MetricDataType == pmetric.MetricDataTypeHistogram ||
+		mi.MetricDataType == pmetric.MetricDataTypeExponentialHistogram
 }
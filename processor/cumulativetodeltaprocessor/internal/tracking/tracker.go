@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 )
 
@@ -44,6 +45,8 @@ type DeltaValue struct {
 	StartTimestamp pcommon.Timestamp
 	FloatValue     float64
 	IntValue       int64
+
+	ExponentialHistogramValue *ExponentialHistogramValue
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -95,6 +98,8 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 				StartTimestamp: metricPoint.ObservedTimestamp,
 				FloatValue:     metricPoint.FloatValue,
 				IntValue:       metricPoint.IntValue,
+
+				ExponentialHistogramValue: metricPoint.ExponentialHistogramValue,
 			}
 			valid = true
 		}
@@ -108,7 +113,10 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
-	if metricID.IsFloatVal() {
+	switch {
+	case metricID.MetricDataType == pmetric.MetricDataTypeExponentialHistogram:
+		out.ExponentialHistogramValue = metricPoint.ExponentialHistogramValue.delta(state.PrevPoint.ExponentialHistogramValue)
+	case metricID.IsFloatVal():
 		value := metricPoint.FloatValue
 		prevValue := state.PrevPoint.FloatValue
 		delta := value - prevValue
@@ -119,7 +127,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.FloatValue = delta
-	} else {
+	default:
 		value := metricPoint.IntValue
 		prevValue := state.PrevPoint.IntValue
 		delta := value - prevValue
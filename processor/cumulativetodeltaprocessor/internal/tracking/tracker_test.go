@@ -151,6 +151,154 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	miExponentialHistogram := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricDataType:         pmetric.MetricDataTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name      string
+		timestamp pcommon.Timestamp
+		value     ExponentialHistogramValue
+		wantOut   DeltaValue
+	}{
+		{
+			name:      "Initial Value recorded",
+			timestamp: 10,
+			value: ExponentialHistogramValue{
+				Count:     6,
+				Sum:       20,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{2, 3}},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 10,
+				ExponentialHistogramValue: &ExponentialHistogramValue{
+					Count:     6,
+					Sum:       20,
+					ZeroCount: 1,
+					Scale:     1,
+					Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{2, 3}},
+				},
+			},
+		},
+		{
+			name:      "Higher Value Recorded with a lower offset",
+			timestamp: 50,
+			value: ExponentialHistogramValue{
+				Count:     10,
+				Sum:       30,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 4, 4}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 10,
+				ExponentialHistogramValue: &ExponentialHistogramValue{
+					Count:     4,
+					Sum:       10,
+					ZeroCount: 0,
+					Scale:     1,
+					Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2, 1}},
+					Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+				},
+			},
+		},
+		{
+			name:      "Higher Value Recorded with a lower scale",
+			timestamp: 100,
+			value: ExponentialHistogramValue{
+				Count:     13,
+				Sum:       45,
+				ZeroCount: 2,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{2, 9}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 50,
+				ExponentialHistogramValue: &ExponentialHistogramValue{
+					Count:     3,
+					Sum:       15,
+					ZeroCount: 1,
+					Scale:     0,
+					Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 1}},
+					Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{0}},
+				},
+			},
+		},
+		{
+			name:      "Lower bucket count recorded",
+			timestamp: 150,
+			value: ExponentialHistogramValue{
+				Count:     14,
+				Sum:       50,
+				ZeroCount: 2,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 11}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 100,
+				ExponentialHistogramValue: &ExponentialHistogramValue{
+					Count:     14,
+					Sum:       50,
+					ZeroCount: 2,
+					Scale:     0,
+					Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 11}},
+					Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+				},
+			},
+		},
+		{
+			name:      "Bucket no longer covered after an offset shift",
+			timestamp: 200,
+			value: ExponentialHistogramValue{
+				Count:     15,
+				Sum:       60,
+				ZeroCount: 2,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{12}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 150,
+				ExponentialHistogramValue: &ExponentialHistogramValue{
+					Count:     15,
+					Sum:       60,
+					ZeroCount: 2,
+					Scale:     0,
+					Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{12}},
+					Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: miExponentialHistogram,
+				Value: ValuePoint{
+					ObservedTimestamp:         tt.timestamp,
+					ExponentialHistogramValue: &value,
+				},
+			})
+			if !valid || !reflect.DeepEqual(gotOut, tt.wantOut) {
+				t.Errorf("MetricTracker.Convert(MetricDataTypeExponentialHistogram) = %v, want %v", gotOut, tt.wantOut)
+			}
+		})
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
@@ -20,4 +20,7 @@ type ValuePoint struct {
 	ObservedTimestamp pcommon.Timestamp
 	FloatValue        float64
 	IntValue          int64
+
+	// ExponentialHistogramValue is only set for exponential histograms.
+	ExponentialHistogramValue *ExponentialHistogramValue
 }
@@ -139,6 +139,28 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ctdp.convertHistogramDataPoints(ms.DataPoints(), &histogramIdentities)
 
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeExponentialHistogram:
+					if !ctdp.histogramSupportEnabled {
+						return false
+					}
+
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricDataType:         m.DataType(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+					}
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
 					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
 				default:
@@ -282,3 +304,55 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+
+		hasSum := dp.HasSum() && !math.IsNaN(dp.Sum())
+		value := &tracking.ExponentialHistogramValue{
+			Count:     dp.Count(),
+			ZeroCount: dp.ZeroCount(),
+			Scale:     dp.Scale(),
+			Positive:  exponentialBuckets(dp.Positive()),
+			Negative:  exponentialBuckets(dp.Negative()),
+		}
+		if hasSum {
+			value.Sum = dp.Sum()
+		}
+
+		delta, valid := ctdp.deltaCalculator.Convert(tracking.MetricPoint{
+			Identity: id,
+			Value: tracking.ValuePoint{
+				ObservedTimestamp:         dp.Timestamp(),
+				ExponentialHistogramValue: value,
+			},
+		})
+		if !valid {
+			return true
+		}
+
+		deltaValue := delta.ExponentialHistogramValue
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(deltaValue.Count)
+		if hasSum {
+			dp.SetSum(deltaValue.Sum)
+		}
+		dp.SetZeroCount(deltaValue.ZeroCount)
+		dp.SetScale(deltaValue.Scale)
+		dp.Positive().SetOffset(deltaValue.Positive.Offset)
+		dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(deltaValue.Positive.BucketCounts))
+		dp.Negative().SetOffset(deltaValue.Negative.Offset)
+		dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice(deltaValue.Negative.BucketCounts))
+		return false
+	})
+}
+
+func exponentialBuckets(b pmetric.Buckets) tracking.ExponentialBuckets {
+	return tracking.ExponentialBuckets{
+		Offset:       b.Offset(),
+		BucketCounts: b.BucketCounts().AsRaw(),
+	}
+}
@@ -48,6 +48,16 @@ type testHistogramMetric struct {
 	isCumulative  []bool
 }
 
+type testExponentialHistogramMetric struct {
+	metricNames   []string
+	metricCounts  [][]uint64
+	metricSums    [][]float64
+	metricScales  [][]int32
+	metricOffsets [][]int32
+	metricBuckets [][][]uint64
+	isCumulative  []bool
+}
+
 type cumulativeToDeltaTest struct {
 	name                    string
 	include                 MatchMetrics
@@ -270,6 +280,70 @@ var (
 			}),
 			histogramSupportEnabled: false,
 		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{10, 20, 50, 5}, {4}},
+				metricSums:    [][]float64{{10, 20, 50, 5}, {4}},
+				metricScales:  [][]int32{{2, 2, 1, 1}, {2}},
+				metricOffsets: [][]int32{{4, 3, 1, 2}, {4}},
+				metricBuckets: [][][]uint64{
+					{{5, 5}, {2, 8, 10}, {12, 38}, {5}},
+					{{2, 2}},
+				},
+				isCumulative: []bool{true, true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{10, 10, 30, 5}, {4}},
+				metricSums:    [][]float64{{10, 10, 30, 5}, {4}},
+				metricScales:  [][]int32{{2, 2, 1, 1}, {2}},
+				metricOffsets: [][]int32{{4, 3, 1, 2}, {4}},
+				metricBuckets: [][][]uint64{
+					{{5, 5}, {2, 3, 5}, {10, 20}, {5}},
+					{{2, 2}},
+				},
+				isCumulative: []bool{false, true},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_ignored_without_feature",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{10, 20}},
+				metricSums:    [][]float64{{10, 20}},
+				metricScales:  [][]int32{{2, 2}},
+				metricOffsets: [][]int32{{4, 4}},
+				metricBuckets: [][][]uint64{{{5, 5}, {10, 10}}},
+				isCumulative:  []bool{true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{10, 20}},
+				metricSums:    [][]float64{{10, 20}},
+				metricScales:  [][]int32{{2, 2}},
+				metricOffsets: [][]int32{{4, 4}},
+				metricBuckets: [][][]uint64{{{5, 5}, {10, 10}}},
+				isCumulative:  []bool{true},
+			}),
+			histogramSupportEnabled: false,
+		},
 	}
 )
 
@@ -364,6 +438,22 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 						require.Equal(t, eDataPoints.At(j).BucketCounts().AsRaw(), aDataPoints.At(j).BucketCounts().AsRaw())
 					}
 				}
+
+				if eM.DataType() == pmetric.MetricDataTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts().AsRaw(), aDataPoints.At(j).Positive().BucketCounts().AsRaw())
+					}
+				}
 			}
 
 			require.NoError(t, mgp.Shutdown(ctx))
@@ -436,6 +526,39 @@ func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
 	return md
 }
 
+func generateTestExponentialHistogramMetrics(tm testExponentialHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+
+		hist := m.ExponentialHistogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(tm.metricBuckets[i][index]))
+		}
+	}
+
+	return md
+}
+
 func BenchmarkConsumeMetrics(b *testing.B) {
 	c := consumertest.NewNop()
 	params := component.ProcessorCreateSettings{
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func TestLoadingFullConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	assert.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	expected := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Include: MatchMetrics{
+			Metrics: []string{"metric1", "metric2"},
+			Config:  filterset.Config{MatchType: "strict"},
+		},
+		Exclude: MatchMetrics{
+			Metrics: []string{"metric3", "metric4"},
+			Config:  filterset.Config{MatchType: "strict"},
+		},
+		MaxStaleness: 10 * time.Second,
+	}
+	assert.Equal(t, expected, cfg.Processors[expected.ID()])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          Config
+		errorMessage string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "regexp",
+			cfg: Config{
+				Include: MatchMetrics{Metrics: []string{"metric.*"}, Config: filterset.Config{MatchType: "regexp"}},
+			},
+		},
+		{
+			name: "missing match type",
+			cfg: Config{
+				Exclude: MatchMetrics{Metrics: []string{"metric1"}},
+			},
+			errorMessage: "match_type must be set if metrics are supplied",
+		},
+		{
+			name: "missing metrics",
+			cfg: Config{
+				Include: MatchMetrics{Config: filterset.Config{MatchType: "strict"}},
+			},
+			errorMessage: "metrics must be supplied if match_type is set",
+		},
+		{
+			name: "negative max staleness",
+			cfg: Config{
+				MaxStaleness: -time.Second,
+			},
+			errorMessage: "max_staleness must not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.Validate()
+			if test.errorMessage == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.errorMessage)
+			}
+		})
+	}
+}
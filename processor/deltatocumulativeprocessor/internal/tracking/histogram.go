@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+)
+
+// histogramValue is the accumulated value of a histogram series.
+type histogramValue struct {
+	summary metricutils.HistogramSummary
+	buckets metricutils.ExplicitBuckets
+}
+
+func newHistogramValue(dp pmetric.HistogramDataPoint) histogramValue {
+	return histogramValue{
+		summary: metricutils.NewHistogramSummary(dp),
+		buckets: metricutils.NewExplicitBuckets(dp),
+	}
+}
+
+// hasSameBuckets returns whether the data point has the same bucket boundaries as the accumulated value.
+func (h *histogramValue) hasSameBuckets(dp pmetric.HistogramDataPoint) bool {
+	return h.buckets.HasSameBounds(metricutils.NewExplicitBuckets(dp))
+}
+
+func (h *histogramValue) add(dp pmetric.HistogramDataPoint) {
+	h.summary.Merge(metricutils.NewHistogramSummary(dp))
+	h.buckets.Merge(metricutils.NewExplicitBuckets(dp))
+}
+
+// writeTo replaces the values of the data point with the accumulated ones. The data point is rebuilt,
+// as its sum, min and max can't be removed.
+func (h *histogramValue) writeTo(start pcommon.Timestamp, dp pmetric.HistogramDataPoint) {
+	out := pmetric.NewHistogramDataPoint()
+	dp.Attributes().CopyTo(out.Attributes())
+	dp.Exemplars().CopyTo(out.Exemplars())
+	dp.Flags().CopyTo(out.Flags())
+	out.SetStartTimestamp(start)
+	out.SetTimestamp(dp.Timestamp())
+	h.summary.CopyToHistogram(out)
+	h.buckets.CopyTo(out)
+	out.MoveTo(dp)
+}
+
+// exponentialBuckets are the positive or negative buckets of an exponential histogram.
+type exponentialBuckets struct {
+	offset int32
+	counts []uint64
+}
+
+func newExponentialBuckets(b pmetric.Buckets) exponentialBuckets {
+	return exponentialBuckets{offset: b.Offset(), counts: b.BucketCounts().AsRaw()}
+}
+
+// downscale merges the buckets for a scale lowered by the given amount. The bucket at index i
+// becomes the bucket at index i >> by, rounding towards negative infinity.
+func (b exponentialBuckets) downscale(by int32) exponentialBuckets {
+	if by == 0 || len(b.counts) == 0 {
+		return b
+	}
+	offset := b.offset >> by
+	last := (b.offset + int32(len(b.counts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.counts {
+		counts[((b.offset+int32(i))>>by)-offset] += count
+	}
+	return exponentialBuckets{offset: offset, counts: counts}
+}
+
+// merge returns the sum of the buckets, which must have the same scale.
+func (b exponentialBuckets) merge(other exponentialBuckets) exponentialBuckets {
+	if len(other.counts) == 0 {
+		return b
+	}
+	if len(b.counts) == 0 {
+		return other
+	}
+	offset := b.offset
+	if other.offset < offset {
+		offset = other.offset
+	}
+	end := b.offset + int32(len(b.counts))
+	if otherEnd := other.offset + int32(len(other.counts)); otherEnd > end {
+		end = otherEnd
+	}
+	counts := make([]uint64, end-offset)
+	for i, count := range b.counts {
+		counts[b.offset+int32(i)-offset] += count
+	}
+	for i, count := range other.counts {
+		counts[other.offset+int32(i)-offset] += count
+	}
+	return exponentialBuckets{offset: offset, counts: counts}
+}
+
+func (b exponentialBuckets) writeTo(dest pmetric.Buckets) {
+	dest.SetOffset(b.offset)
+	dest.SetBucketCounts(pcommon.NewImmutableUInt64Slice(b.counts))
+}
+
+// exponentialHistogramValue is the accumulated value of an exponential histogram series.
+type exponentialHistogramValue struct {
+	summary   metricutils.HistogramSummary
+	scale     int32
+	zeroCount uint64
+	positive  exponentialBuckets
+	negative  exponentialBuckets
+}
+
+func newExponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) exponentialHistogramValue {
+	return exponentialHistogramValue{
+		summary:   metricutils.NewExponentialHistogramSummary(dp),
+		scale:     dp.Scale(),
+		zeroCount: dp.ZeroCount(),
+		positive:  newExponentialBuckets(dp.Positive()),
+		negative:  newExponentialBuckets(dp.Negative()),
+	}
+}
+
+// add accumulates the data point. When the scales differ, the buckets are merged at the lowest of them.
+func (h *exponentialHistogramValue) add(dp pmetric.ExponentialHistogramDataPoint) {
+	other := newExponentialHistogramValue(dp)
+	if other.scale < h.scale {
+		h.positive = h.positive.downscale(h.scale - other.scale)
+		h.negative = h.negative.downscale(h.scale - other.scale)
+		h.scale = other.scale
+	} else if other.scale > h.scale {
+		other.positive = other.positive.downscale(other.scale - h.scale)
+		other.negative = other.negative.downscale(other.scale - h.scale)
+	}
+
+	h.summary.Merge(other.summary)
+	h.zeroCount += other.zeroCount
+	h.positive = h.positive.merge(other.positive)
+	h.negative = h.negative.merge(other.negative)
+}
+
+// writeTo replaces the values of the data point with the accumulated ones. The data point is rebuilt,
+// as its sum, min and max can't be removed.
+func (h *exponentialHistogramValue) writeTo(start pcommon.Timestamp, dp pmetric.ExponentialHistogramDataPoint) {
+	out := pmetric.NewExponentialHistogramDataPoint()
+	dp.Attributes().CopyTo(out.Attributes())
+	dp.Exemplars().CopyTo(out.Exemplars())
+	dp.Flags().CopyTo(out.Flags())
+	out.SetStartTimestamp(start)
+	out.SetTimestamp(dp.Timestamp())
+	h.summary.CopyToExponentialHistogram(out)
+	out.SetScale(h.scale)
+	out.SetZeroCount(h.zeroCount)
+	h.positive.writeTo(out.Positive())
+	h.negative.writeTo(out.Negative())
+	out.MoveTo(dp)
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// Allocate a minimum of 64 bytes to the builder initially
+const initialBytes = 64
+
+var identityBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, initialBytes))
+	},
+}
+
+// state is the accumulated value of a series. Only the value matching the data type of the series is used.
+type state struct {
+	sync.Mutex
+	startTimestamp       pcommon.Timestamp
+	lastTimestamp        pcommon.Timestamp
+	intValue             int64
+	floatValue           float64
+	histogram            histogramValue
+	exponentialHistogram exponentialHistogramValue
+}
+
+// restart starts a new cumulative series with a data point covering the time between start and timestamp.
+func (s *state) restart(start, timestamp pcommon.Timestamp) {
+	s.startTimestamp = start
+	if start == 0 {
+		s.startTimestamp = timestamp
+	}
+}
+
+// MetricTracker keeps the accumulated values of the delta series, and converts their data points to cumulative.
+type MetricTracker struct {
+	logger       *zap.Logger
+	maxStaleness time.Duration
+	states       sync.Map
+}
+
+// NewMetricTracker creates a MetricTracker. When maxStaleness is positive, the series that didn't receive
+// data points for this duration are removed until the context is done.
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
+	t := &MetricTracker{logger: logger, maxStaleness: maxStaleness}
+	if maxStaleness > 0 {
+		go t.sweeper(ctx, t.removeStale)
+	}
+	return t
+}
+
+// ConvertNumber converts a delta sum data point to cumulative. It returns false if the data point must be dropped.
+func (t *MetricTracker) ConvertNumber(id MetricIdentity, dp pmetric.NumberDataPoint) bool {
+	// NaN is used to signal "stale" metrics, these are passed through without being accumulated.
+	if id.IsFloatVal() && math.IsNaN(dp.DoubleVal()) {
+		return true
+	}
+
+	return t.convert(id, dp.StartTimestamp(), dp.Timestamp(), func(s *state, reset bool) {
+		if reset {
+			s.intValue = 0
+			s.floatValue = 0
+		}
+		dp.SetStartTimestamp(s.startTimestamp)
+		if id.IsFloatVal() {
+			s.floatValue += dp.DoubleVal()
+			dp.SetDoubleVal(s.floatValue)
+		} else {
+			s.intValue += dp.IntVal()
+			dp.SetIntVal(s.intValue)
+		}
+	})
+}
+
+// ConvertHistogram converts a delta histogram data point to cumulative. It returns false if the data point must be dropped.
+func (t *MetricTracker) ConvertHistogram(id MetricIdentity, dp pmetric.HistogramDataPoint) bool {
+	return t.convert(id, dp.StartTimestamp(), dp.Timestamp(), func(s *state, reset bool) {
+		if !reset && !s.histogram.hasSameBuckets(dp) {
+			t.logger.Debug("resetting histogram after a change of its bucket boundaries", zap.String("metric", id.MetricName))
+			s.restart(dp.StartTimestamp(), dp.Timestamp())
+			reset = true
+		}
+		if reset {
+			s.histogram = newHistogramValue(dp)
+		} else {
+			s.histogram.add(dp)
+		}
+		s.histogram.writeTo(s.startTimestamp, dp)
+	})
+}
+
+// ConvertExponentialHistogram converts a delta exponential histogram data point to cumulative. It returns false
+// if the data point must be dropped.
+func (t *MetricTracker) ConvertExponentialHistogram(id MetricIdentity, dp pmetric.ExponentialHistogramDataPoint) bool {
+	return t.convert(id, dp.StartTimestamp(), dp.Timestamp(), func(s *state, reset bool) {
+		if reset {
+			s.exponentialHistogram = newExponentialHistogramValue(dp)
+		} else {
+			s.exponentialHistogram.add(dp)
+		}
+		s.exponentialHistogram.writeTo(s.startTimestamp, dp)
+	})
+}
+
+// convert finds the state of the series and decides how a delta data point covering the time between
+// start and timestamp continues it:
+//   - a data point of a new series, or starting after the last data point of the series, starts a new
+//     cumulative series at its start timestamp, or at its timestamp if the start timestamp isn't set;
+//   - a data point starting where the last one ended, or without start timestamp, is accumulated;
+//   - a data point that isn't more recent than the last one, or overlaps it, is dropped.
+//
+// accumulate is called with the state locked, and with reset set when the accumulated value must be discarded.
+func (t *MetricTracker) convert(id MetricIdentity, start, timestamp pcommon.Timestamp, accumulate func(s *state, reset bool)) bool {
+	b := identityBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	id.Write(b)
+	hashableID := b.String()
+	identityBufferPool.Put(b)
+
+	var v interface{}
+	var loaded bool
+	if v, loaded = t.states.Load(hashableID); !loaded {
+		v, loaded = t.states.LoadOrStore(hashableID, &state{})
+	}
+
+	s := v.(*state)
+	s.Lock()
+	defer s.Unlock()
+
+	reset := !loaded
+	if loaded {
+		switch {
+		case timestamp <= s.lastTimestamp:
+			t.logger.Debug("dropping out of order data point", zap.String("metric", id.MetricName))
+			return false
+		case start != 0 && start < s.lastTimestamp:
+			t.logger.Debug("dropping data point overlapping the previous one", zap.String("metric", id.MetricName))
+			return false
+		case start > s.lastTimestamp:
+			t.logger.Debug("resetting series after a gap", zap.String("metric", id.MetricName))
+			reset = true
+		}
+	}
+
+	if reset {
+		s.restart(start, timestamp)
+	}
+	accumulate(s, reset)
+	s.lastTimestamp = timestamp
+	return true
+}
+
+func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*state)
+
+		// As in the cumulativetodelta processor, a state being updated while it
+		// is removed may lose this update, the next data point of the series then
+		// starts a new cumulative series.
+		s.Lock()
+		lastObserved := s.lastTimestamp
+		s.Unlock()
+		if lastObserved < staleBefore {
+			t.logger.Debug("removing stale state key", zap.String("key", key.(string)))
+			t.states.Delete(key)
+		}
+		return true
+	})
+}
+
+func (t *MetricTracker) sweeper(ctx context.Context, remove func(pcommon.Timestamp)) {
+	ticker := time.NewTicker(t.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			staleBefore := pcommon.NewTimestampFromTime(currentTime.Add(-t.maxStaleness))
+			remove(staleBefore)
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestMetricTracker_removeStale(t *testing.T) {
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	newID := func(host string) MetricIdentity {
+		attrs := pcommon.NewMap()
+		attrs.InsertString("host", host)
+		return MetricIdentity{
+			Resource:               pcommon.NewResource(),
+			InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+			MetricDataType:         pmetric.MetricDataTypeSum,
+			MetricName:             "requests",
+			Attributes:             attrs,
+			MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		}
+	}
+	newPoint := func(start, timestamp pcommon.Timestamp, value int64) pmetric.NumberDataPoint {
+		dp := pmetric.NewNumberDataPoint()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		dp.SetIntVal(value)
+		return dp
+	}
+
+	assert.True(t, tracker.ConvertNumber(newID("a"), newPoint(100, 200, 1)))
+	assert.True(t, tracker.ConvertNumber(newID("b"), newPoint(200, 300, 1)))
+
+	tracker.removeStale(250)
+
+	// the stale series starts over, without being considered as a gap of the removed one
+	dp := newPoint(200, 300, 2)
+	assert.True(t, tracker.ConvertNumber(newID("a"), dp))
+	assert.Equal(t, pcommon.Timestamp(200), dp.StartTimestamp())
+	assert.Equal(t, int64(2), dp.IntVal())
+
+	dp = newPoint(300, 400, 2)
+	assert.True(t, tracker.ConvertNumber(newID("b"), dp))
+	assert.Equal(t, pcommon.Timestamp(200), dp.StartTimestamp())
+	assert.Equal(t, int64(3), dp.IntVal())
+}
+
+func TestMetricTracker_sweeper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sweepEvent := make(chan pcommon.Timestamp)
+	closed := make(chan struct{})
+
+	tracker := &MetricTracker{logger: zap.NewNop(), maxStaleness: time.Millisecond}
+	go func() {
+		tracker.sweeper(ctx, func(staleBefore pcommon.Timestamp) {
+			sweepEvent <- staleBefore
+		})
+		close(closed)
+	}()
+
+	start := time.Now()
+	staleBefore := <-sweepEvent
+	assert.LessOrEqual(t, start.Add(-time.Millisecond).UnixNano(), staleBefore.AsTime().UnixNano())
+
+	cancel()
+	<-closed
+}
+
+func TestExponentialBuckets(t *testing.T) {
+	b := exponentialBuckets{offset: -3, counts: []uint64{1, 2, 3, 4, 5}}
+
+	assert.Equal(t, b, b.downscale(0))
+	// -3 and -2 become -2, -1 and 0 become -1, 1 becomes 0
+	assert.Equal(t, exponentialBuckets{offset: -2, counts: []uint64{1, 5, 9}}, b.downscale(1))
+	// -3 to -1 become -1, 0 and 1 become 0
+	assert.Equal(t, exponentialBuckets{offset: -1, counts: []uint64{6, 9}}, b.downscale(2))
+
+	merged := b.merge(exponentialBuckets{offset: 1, counts: []uint64{1, 1, 1}})
+	assert.Equal(t, exponentialBuckets{offset: -3, counts: []uint64{1, 2, 3, 4, 6, 1, 1}}, merged)
+	assert.Equal(t, b, b.merge(exponentialBuckets{}))
+	assert.Equal(t, b, exponentialBuckets{}.merge(b))
+}
@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+var testStart = time.Unix(1660000000, 0)
+
+// ts returns the timestamp of the given second of the test.
+func ts(second int) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(testStart.Add(time.Duration(second) * time.Second))
+}
+
+type testSumPoint struct {
+	start, end int
+	value      int64
+}
+
+func generateSumMetrics(name string, temporality pmetric.MetricAggregationTemporality, points ...testSumPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(temporality)
+	m.Sum().SetIsMonotonic(true)
+	for _, p := range points {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(p.start))
+		dp.SetTimestamp(ts(p.end))
+		dp.SetIntVal(p.value)
+	}
+	return md
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (*consumertest.MetricsSink, func(pmetric.Metrics)) {
+	sink := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	if cfg == nil {
+		cfg = factory.CreateDefaultConfig().(*Config)
+	}
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, mp.Shutdown(context.Background()))
+	})
+	return sink, func(md pmetric.Metrics) {
+		require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	}
+}
+
+func lastMetric(t *testing.T, sink *consumertest.MetricsSink) pmetric.Metric {
+	all := sink.AllMetrics()
+	require.NotEmpty(t, all)
+	metrics := all[len(all)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	return metrics.At(0)
+}
+
+func TestDeltaSumToCumulative(t *testing.T) {
+	sink, consume := newTestProcessor(t, nil)
+
+	tests := []struct {
+		name     string
+		in       testSumPoint
+		expected *testSumPoint
+	}{
+		{
+			name:     "first point starts the series",
+			in:       testSumPoint{start: 0, end: 10, value: 5},
+			expected: &testSumPoint{start: 0, end: 10, value: 5},
+		},
+		{
+			name:     "contiguous point is accumulated",
+			in:       testSumPoint{start: 10, end: 20, value: 3},
+			expected: &testSumPoint{start: 0, end: 20, value: 8},
+		},
+		{
+			name: "out of order point is dropped",
+			in:   testSumPoint{start: 5, end: 15, value: 100},
+		},
+		{
+			name: "overlapping point is dropped",
+			in:   testSumPoint{start: 15, end: 25, value: 100},
+		},
+		{
+			name:     "point after a gap resets the start timestamp",
+			in:       testSumPoint{start: 30, end: 40, value: 2},
+			expected: &testSumPoint{start: 30, end: 40, value: 2},
+		},
+		{
+			name:     "accumulation restarts after the gap",
+			in:       testSumPoint{start: 40, end: 50, value: 1},
+			expected: &testSumPoint{start: 30, end: 50, value: 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink.Reset()
+			consume(generateSumMetrics("requests", pmetric.MetricAggregationTemporalityDelta, test.in))
+			if test.expected == nil {
+				assert.Equal(t, 0, sink.DataPointCount())
+				return
+			}
+			m := lastMetric(t, sink)
+			assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+			assert.True(t, m.Sum().IsMonotonic())
+			require.Equal(t, 1, m.Sum().DataPoints().Len())
+			dp := m.Sum().DataPoints().At(0)
+			assert.Equal(t, ts(test.expected.start), dp.StartTimestamp())
+			assert.Equal(t, ts(test.expected.end), dp.Timestamp())
+			assert.Equal(t, test.expected.value, dp.IntVal())
+		})
+	}
+}
+
+func TestDeltaSumSeries(t *testing.T) {
+	sink, consume := newTestProcessor(t, nil)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("bytes")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	for _, p := range []struct {
+		host       string
+		start, end int
+		value      float64
+	}{
+		{host: "a", start: 0, end: 10, value: 1},
+		{host: "b", start: 5, end: 10, value: 2},
+		{host: "a", start: 10, end: 20, value: 3},
+	} {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("host", p.host)
+		dp.SetStartTimestamp(ts(p.start))
+		dp.SetTimestamp(ts(p.end))
+		dp.SetDoubleVal(p.value)
+	}
+	consume(md)
+
+	dps := lastMetric(t, sink).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	assert.Equal(t, 1.0, dps.At(0).DoubleVal())
+	assert.Equal(t, 2.0, dps.At(1).DoubleVal(), "each attribute set should be accumulated separately")
+	assert.Equal(t, ts(5), dps.At(1).StartTimestamp())
+	assert.Equal(t, 4.0, dps.At(2).DoubleVal())
+	assert.Equal(t, ts(0), dps.At(2).StartTimestamp())
+}
+
+func TestCumulativeSumIsNotModified(t *testing.T) {
+	sink, consume := newTestProcessor(t, nil)
+
+	md := generateSumMetrics("requests", pmetric.MetricAggregationTemporalityCumulative, testSumPoint{start: 0, end: 10, value: 5})
+	expected := md.Clone()
+	consume(md)
+	assert.Equal(t, []pmetric.Metrics{expected}, sink.AllMetrics())
+}
+
+func TestExcludedMetricIsNotModified(t *testing.T) {
+	sink, consume := newTestProcessor(t, &Config{
+		Exclude: MatchMetrics{
+			Metrics: []string{"requests"},
+			Config:  filterset.Config{MatchType: "strict"},
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		consume(generateSumMetrics("requests", pmetric.MetricAggregationTemporalityDelta, testSumPoint{start: i * 10, end: i*10 + 10, value: 5}))
+	}
+	m := lastMetric(t, sink)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	assert.Equal(t, int64(5), m.Sum().DataPoints().At(0).IntVal())
+}
+
+func TestDeltaHistogramToCumulative(t *testing.T) {
+	sink, consume := newTestProcessor(t, nil)
+
+	histogram := func(start, end int, bounds []float64, buckets []uint64, sum *float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		m.SetDataType(pmetric.MetricDataTypeHistogram)
+		m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(start))
+		dp.SetTimestamp(ts(end))
+		count := uint64(0)
+		for _, b := range buckets {
+			count += b
+		}
+		dp.SetCount(count)
+		if sum != nil {
+			dp.SetSum(*sum)
+		}
+		dp.SetMin(float64(start))
+		dp.SetMax(float64(end))
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(buckets))
+		return md
+	}
+	sum := func(v float64) *float64 { return &v }
+
+	consume(histogram(0, 10, []float64{1, 10}, []uint64{1, 2, 3}, sum(20)))
+	consume(histogram(10, 20, []float64{1, 10}, []uint64{0, 1, 1}, sum(15)))
+
+	m := lastMetric(t, sink)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, ts(20), dp.Timestamp())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, 35.0, dp.Sum())
+	assert.Equal(t, 0.0, dp.Min())
+	assert.Equal(t, 20.0, dp.Max())
+	assert.Equal(t, []float64{1, 10}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 3, 4}, dp.BucketCounts().AsRaw())
+
+	// a point without sum makes the cumulative sum unknown
+	consume(histogram(20, 30, []float64{1, 10}, []uint64{1, 0, 0}, nil))
+	dp = lastMetric(t, sink).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.False(t, dp.HasSum())
+
+	// changing the bucket boundaries starts a new cumulative series
+	consume(histogram(30, 40, []float64{5}, []uint64{2, 2}, sum(12)))
+	dp = lastMetric(t, sink).Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(30), dp.StartTimestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 12.0, dp.Sum())
+	assert.Equal(t, []uint64{2, 2}, dp.BucketCounts().AsRaw())
+}
+
+func TestDeltaExponentialHistogramToCumulative(t *testing.T) {
+	sink, consume := newTestProcessor(t, nil)
+
+	histogram := func(start, end int, scale int32, offset int32, buckets []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+		m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(start))
+		dp.SetTimestamp(ts(end))
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		count := uint64(1)
+		for _, b := range buckets {
+			count += b
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.Positive().SetOffset(offset)
+		dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(buckets))
+		return md
+	}
+
+	consume(histogram(0, 10, 1, -2, []uint64{1, 2, 3, 4}))
+	consume(histogram(10, 20, 1, 1, []uint64{1, 1}))
+
+	dp := lastMetric(t, sink).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, uint64(14), dp.Count())
+	assert.Equal(t, 14.0, dp.Sum())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2, 3, 5, 1}, dp.Positive().BucketCounts().AsRaw())
+
+	// a lower scale merges the accumulated buckets: at scale 0, the buckets
+	// -2 and -1 become -1, 0 and 1 become 0 and 2 becomes 1
+	consume(histogram(20, 30, 0, 0, []uint64{1}))
+	dp = lastMetric(t, sink).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{3, 9, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, uint64(16), dp.Count())
+
+	// a higher scale is merged at the accumulated scale
+	consume(histogram(30, 40, 2, 4, []uint64{1, 1}))
+	dp = lastMetric(t, sink).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{3, 9, 3}, dp.Positive().BucketCounts().AsRaw())
+}
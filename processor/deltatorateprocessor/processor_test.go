@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 )
 
 type testMetric struct {
@@ -263,3 +264,24 @@ func generateGaugeMetrics(tm testMetric) pmetric.Metrics {
 
 	return md
 }
+
+func TestExponentialHistogramPassedThrough(t *testing.T) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric_1")
+	m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(120, 0)))
+	dp.SetCount(4)
+	dp.SetScale(1)
+	dp.Positive().SetOffset(2)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 3}))
+	expected := md.Clone()
+
+	dtrp := newDeltaToRateProcessor(&Config{Metrics: []string{"metric_1"}}, zap.NewNop())
+	got, err := dtrp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, expected, got, "exponential histograms must not be converted")
+}
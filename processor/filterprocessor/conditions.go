@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// conditionFilter decides whether telemetry is kept with conditions written in the
+// telemetry query language.
+type conditionFilter struct {
+	include []tql.BoolExpressionEvaluator
+	exclude []tql.BoolExpressionEvaluator
+}
+
+func newConditionFilter(filters ConditionFilters, pathParser tql.PathExpressionParser, enumParser tql.EnumParser) (*conditionFilter, error) {
+	include, err := tql.ParseConditions(filters.Include, tqlotel.ConditionFunctions(), pathParser, enumParser)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include condition: %w", err)
+	}
+	exclude, err := tql.ParseConditions(filters.Exclude, tqlotel.ConditionFunctions(), pathParser, enumParser)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude condition: %w", err)
+	}
+	return &conditionFilter{include: include, exclude: exclude}, nil
+}
+
+// isEmpty returns whether no conditions are configured, in which case everything is kept.
+func (cf *conditionFilter) isEmpty() bool {
+	return len(cf.include) == 0 && len(cf.exclude) == 0
+}
+
+// shouldKeep returns whether the telemetry of ctx matches one of the include conditions,
+// if any is configured, and none of the exclude conditions.
+func (cf *conditionFilter) shouldKeep(ctx tql.TransformContext) bool {
+	if len(cf.include) > 0 && !anyConditionMatches(cf.include, ctx) {
+		return false
+	}
+	return !anyConditionMatches(cf.exclude, ctx)
+}
+
+func anyConditionMatches(conditions []tql.BoolExpressionEvaluator, ctx tql.TransformContext) bool {
+	for _, condition := range conditions {
+		if condition(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMetricPath parses the paths of metric conditions. Only the fields of the metric, its scope
+// and its resource are available, the fields of the data points are left to data point conditions.
+func parseMetricPath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		switch val.Fields[0].Name {
+		case "metric", "resource", "instrumentation_scope":
+		default:
+			return nil, fmt.Errorf("path %q is not available in metric conditions, use a datapoint condition instead", val.Fields[0].Name)
+		}
+	}
+	return tqlmetrics.ParsePath(val)
+}
+
+// spanEventTransformContext is the context of span event conditions. Span events do not have
+// a context of their own in the telemetry query language.
+type spanEventTransformContext struct {
+	spanEvent ptrace.SpanEvent
+	span      ptrace.Span
+	scope     pcommon.InstrumentationScope
+	resource  pcommon.Resource
+}
+
+func (ctx spanEventTransformContext) GetItem() interface{} {
+	return ctx.spanEvent
+}
+
+func (ctx spanEventTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.scope
+}
+
+func (ctx spanEventTransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+func (ctx spanEventTransformContext) spanContext() tqltraces.SpanTransformContext {
+	return tqltraces.SpanTransformContext{
+		Span:                 ctx.span,
+		InstrumentationScope: ctx.scope,
+		Resource:             ctx.resource,
+	}
+}
+
+// spanEventGetter is a tql.GetSetter evaluating span event conditions. Conditions only
+// read the telemetry, so setting a value is not supported.
+type spanEventGetter func(ctx spanEventTransformContext) interface{}
+
+func (g spanEventGetter) Get(ctx tql.TransformContext) interface{} {
+	return g(ctx.(spanEventTransformContext))
+}
+
+func (g spanEventGetter) Set(tql.TransformContext, interface{}) {}
+
+// parseSpanEventPath parses the paths of span event conditions. The fields of the span event are
+// accessed directly, e.g. `name` or `attributes["exception.type"]`, while the fields of the span
+// holding the event are prefixed with `span`, e.g. `span.name`. `resource` and `instrumentation_library`
+// paths are those of spans.
+func parseSpanEventPath(val *tql.Path) (tql.GetSetter, error) {
+	if val == nil || len(val.Fields) == 0 {
+		return nil, fmt.Errorf("bad path %v", val)
+	}
+
+	field := val.Fields[0]
+	switch field.Name {
+	case "span", "resource", "instrumentation_library":
+		path := val
+		if field.Name == "span" {
+			path = &tql.Path{Fields: val.Fields[1:]}
+		}
+		spanGetSetter, err := tqltraces.ParsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+			return spanGetSetter.Get(ctx.spanContext())
+		}), nil
+	}
+
+	if len(val.Fields) > 1 {
+		return nil, fmt.Errorf("invalid path expression %v", val)
+	}
+	switch field.Name {
+	case "name":
+		return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+			return ctx.spanEvent.Name()
+		}), nil
+	case "time_unix_nano":
+		return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+			return ctx.spanEvent.Timestamp().AsTime().UnixNano()
+		}), nil
+	case "dropped_attributes_count":
+		return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+			return int64(ctx.spanEvent.DroppedAttributesCount())
+		}), nil
+	case "attributes":
+		keys := field.Keys
+		if keys == nil {
+			return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+				return ctx.spanEvent.Attributes()
+			}), nil
+		}
+		return spanEventGetter(func(ctx spanEventTransformContext) interface{} {
+			return getAttributeValue(ctx.spanEvent.Attributes(), keys)
+		}), nil
+	}
+	return nil, fmt.Errorf("invalid path expression %v", val)
+}
+
+// getAttributeValue returns the value of attrs found by following keys through nested maps and
+// slices, as the type the telemetry query language uses for it. nil is returned if any of the keys
+// does not exist.
+func getAttributeValue(attrs pcommon.Map, keys []tql.Key) interface{} {
+	if len(keys) == 0 || keys[0].String == nil {
+		return nil
+	}
+	value, ok := attrs.Get(*keys[0].String)
+	if !ok {
+		return nil
+	}
+	for _, key := range keys[1:] {
+		switch {
+		case key.String != nil && value.Type() == pcommon.ValueTypeMap:
+			if value, ok = value.MapVal().Get(*key.String); !ok {
+				return nil
+			}
+		case key.Int != nil && value.Type() == pcommon.ValueTypeSlice:
+			if *key.Int < 0 || *key.Int >= int64(value.SliceVal().Len()) {
+				return nil
+			}
+			value = value.SliceVal().At(int(*key.Int))
+		default:
+			return nil
+		}
+	}
+
+	switch value.Type() {
+	case pcommon.ValueTypeString:
+		return value.StringVal()
+	case pcommon.ValueTypeBool:
+		return value.BoolVal()
+	case pcommon.ValueTypeInt:
+		return value.IntVal()
+	case pcommon.ValueTypeDouble:
+		return value.DoubleVal()
+	case pcommon.ValueTypeMap:
+		return value.MapVal()
+	case pcommon.ValueTypeSlice:
+		return value.SliceVal()
+	case pcommon.ValueTypeBytes:
+		return value.BytesVal().AsRaw()
+	}
+	return nil
+}
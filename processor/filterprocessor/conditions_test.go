@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+)
+
+func TestSpanEventConditions(t *testing.T) {
+	span := ptrace.NewSpan()
+	span.SetName("span")
+	span.Attributes().InsertString("http.method", "GET")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(1000)
+	event.SetDroppedAttributesCount(2)
+	event.Attributes().InsertString("exception.type", "error")
+	event.Attributes().Insert("nested", pcommon.NewValueMap())
+	nested, _ := event.Attributes().Get("nested")
+	nested.MapVal().InsertInt("key", 1)
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("otel")
+
+	ctx := spanEventTransformContext{
+		spanEvent: event,
+		span:      span,
+		scope:     scope,
+		resource:  resource,
+	}
+
+	tests := []struct {
+		condition string
+		matches   bool
+	}{
+		{condition: `name == "exception"`, matches: true},
+		{condition: `name == "log"`, matches: false},
+		{condition: `time_unix_nano == 1000`, matches: true},
+		{condition: `dropped_attributes_count == 2`, matches: true},
+		{condition: `attributes["exception.type"] == "error"`, matches: true},
+		{condition: `attributes["nested"]["key"] == 1`, matches: true},
+		{condition: `attributes["missing"] == nil`, matches: true},
+		{condition: `span.name == "span"`, matches: true},
+		{condition: `span.attributes["http.method"] == "POST"`, matches: false},
+		{condition: `resource.attributes["service.name"] == "checkout"`, matches: true},
+		{condition: `instrumentation_library.name == "otel"`, matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			filter, err := newConditionFilter(ConditionFilters{Include: []string{tt.condition}}, parseSpanEventPath, tqltraces.ParseEnum)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.matches, filter.shouldKeep(ctx))
+		})
+	}
+}
+
+func TestInvalidConditionPaths(t *testing.T) {
+	_, err := newConditionFilter(ConditionFilters{Exclude: []string{`span == "span"`}}, parseSpanEventPath, tqltraces.ParseEnum)
+	assert.Error(t, err)
+
+	_, err = newConditionFilter(ConditionFilters{Exclude: []string{`name.value == "span"`}}, parseSpanEventPath, tqltraces.ParseEnum)
+	assert.Error(t, err)
+
+	_, err = newConditionFilter(ConditionFilters{Exclude: []string{`value_int > 10`}}, parseMetricPath, tqltraces.ParseEnum)
+	assert.ErrorContains(t, err, `path "value_int" is not available in metric conditions`)
+}
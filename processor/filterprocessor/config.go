@@ -54,6 +54,22 @@ type MetricFilters struct {
 
 	// RegexpConfig specifies options for the Regexp match type
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
+
+	// Conditions filter metrics and data points with conditions written in the telemetry query language.
+	// Conditions cannot be combined with Include and Exclude.
+	Conditions *MetricConditions `mapstructure:"conditions"`
+}
+
+// MetricConditions filters metrics and their data points with conditions written in the
+// telemetry query language, using the paths of the metrics context.
+type MetricConditions struct {
+	// Metric conditions are evaluated against whole metrics, and may only use the fields of
+	// the metric, its instrumentation scope and its resource.
+	Metric ConditionFilters `mapstructure:"metric"`
+
+	// DataPoint conditions are evaluated against the data points of the metrics kept by the
+	// Metric conditions. Metrics whose data points are all dropped are dropped too.
+	DataPoint ConditionFilters `mapstructure:"datapoint"`
 }
 
 // SpanFilters filters by Span attributes and various other fields, Regexp config is per matcher
@@ -67,6 +83,21 @@ type SpanFilters struct {
 	// all other spans should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
+
+	// Conditions filter spans and span events with conditions written in the telemetry query language.
+	// Conditions cannot be combined with Include and Exclude.
+	Conditions *SpanConditions `mapstructure:"conditions"`
+}
+
+// SpanConditions filters spans and their events with conditions written in the telemetry query language.
+type SpanConditions struct {
+	// Span conditions are evaluated against spans, using the paths of the traces context.
+	Span ConditionFilters `mapstructure:"span"`
+
+	// SpanEvent conditions are evaluated against the events of the spans kept by the Span conditions.
+	// The fields of the event are accessed directly, e.g. `name` or `attributes["exception.type"]`,
+	// and the fields of its span with a `span` prefix, e.g. `span.name`.
+	SpanEvent ConditionFilters `mapstructure:"span_event"`
 }
 
 // LogFilters filters by Log properties.
@@ -79,6 +110,30 @@ type LogFilters struct {
 	// all other logs should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
+
+	// Conditions filter log records with conditions written in the telemetry query language.
+	// Conditions cannot be combined with Include and Exclude.
+	Conditions *LogConditions `mapstructure:"conditions"`
+}
+
+// LogConditions filters log records with conditions written in the telemetry query language.
+type LogConditions struct {
+	// LogRecord conditions are evaluated against log records, using the paths of the logs context.
+	LogRecord ConditionFilters `mapstructure:"log_record"`
+}
+
+// ConditionFilters is a list of conditions written in the telemetry query language, e.g.
+// `attributes["http.method"] == "GET"`, describing the telemetry to keep or to drop.
+type ConditionFilters struct {
+	// Include conditions describe the telemetry that should be kept, all other telemetry is dropped.
+	// The telemetry is kept if it matches any of the conditions.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Include []string `mapstructure:"include"`
+
+	// Exclude conditions describe the telemetry that should be dropped. The telemetry is
+	// dropped if it matches any of the conditions.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Exclude []string `mapstructure:"exclude"`
 }
 
 // LogMatchType specifies the strategy for matching against `plog.Log`s.
@@ -263,5 +318,15 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, cfg.Logs.Exclude.validate())
 	}
 
+	if cfg.Metrics.Conditions != nil && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
+		err = multierr.Append(err, errors.New("metrics: conditions cannot be used together with include or exclude"))
+	}
+	if cfg.Logs.Conditions != nil && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
+		err = multierr.Append(err, errors.New("logs: conditions cannot be used together with include or exclude"))
+	}
+	if cfg.Spans.Conditions != nil && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
+		err = multierr.Append(err, errors.New("spans: conditions cannot be used together with include or exclude"))
+	}
+
 	return err
 }
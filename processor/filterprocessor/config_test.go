@@ -638,6 +638,58 @@ func TestLoadingSpans(t *testing.T) {
 	}
 }
 
+func TestLoadingConditions(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(path.Join(".", "testdata", "config_conditions.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	expCfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "conditions")),
+		Spans: SpanFilters{
+			Conditions: &SpanConditions{
+				Span: ConditionFilters{
+					Include: []string{`resource.attributes["service.name"] == "checkout"`},
+					Exclude: []string{`name == "healthcheck"`},
+				},
+				SpanEvent: ConditionFilters{
+					Exclude: []string{`name == "debug"`},
+				},
+			},
+		},
+		Metrics: MetricFilters{
+			Conditions: &MetricConditions{
+				Metric: ConditionFilters{
+					Exclude: []string{`metric.name == "process.cpu.time"`},
+				},
+				DataPoint: ConditionFilters{
+					Exclude: []string{`attributes["env"] == "dev"`},
+				},
+			},
+		},
+		Logs: LogFilters{
+			Conditions: &LogConditions{
+				LogRecord: ConditionFilters{
+					Include: []string{`severity_number >= SEVERITY_NUMBER_WARN`},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expCfg, cfg.Processors[expCfg.ID()])
+}
+
+func TestLoadingConditionsWithMatchProperties(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(path.Join(".", "testdata", "config_conditions_invalid.yaml"), factories)
+	assert.ErrorContains(t, err, "spans: conditions cannot be used together with include or exclude")
+}
+
 func TestLoadingConfigExpr(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.NoError(t, err)
@@ -16,6 +16,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -26,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
 )
 
 type filterMetricProcessor struct {
@@ -37,9 +39,16 @@ type filterMetricProcessor struct {
 	logger           *zap.Logger
 	checksMetrics    bool
 	checksResouces   bool
+
+	// metricConditions and dataPointConditions are set when the metrics are filtered with conditions.
+	metricConditions    *conditionFilter
+	dataPointConditions *conditionFilter
 }
 
 func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	if cfg.Metrics.Conditions != nil {
+		return newConditionMetricProcessor(logger, cfg)
+	}
 
 	inc, includeAttr, err := createMatcher(cfg.Metrics.Include)
 	if err != nil {
@@ -102,6 +111,32 @@ func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricPro
 	}, nil
 }
 
+func newConditionMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	metricConditions, err := newConditionFilter(cfg.Metrics.Conditions.Metric, parseMetricPath, tqlmetrics.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metric conditions: %w", err)
+	}
+	dataPointConditions, err := newConditionFilter(cfg.Metrics.Conditions.DataPoint, tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data point conditions: %w", err)
+	}
+
+	logger.Info(
+		"Metric filter configured",
+		zap.Strings("include metric conditions", cfg.Metrics.Conditions.Metric.Include),
+		zap.Strings("exclude metric conditions", cfg.Metrics.Conditions.Metric.Exclude),
+		zap.Strings("include data point conditions", cfg.Metrics.Conditions.DataPoint.Include),
+		zap.Strings("exclude data point conditions", cfg.Metrics.Conditions.DataPoint.Exclude),
+	)
+
+	return &filterMetricProcessor{
+		cfg:                 cfg,
+		logger:              logger,
+		metricConditions:    metricConditions,
+		dataPointConditions: dataPointConditions,
+	}, nil
+}
+
 func createMatcher(mp *filtermetric.MatchProperties) (filtermetric.Matcher, filtermatcher.AttributesMatcher, error) {
 	// Nothing specified in configuration
 	if mp == nil {
@@ -125,6 +160,10 @@ func createMatcher(mp *filtermetric.MatchProperties) (filtermetric.Matcher, filt
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(_ context.Context, pdm pmetric.Metrics) (pmetric.Metrics, error) {
+	if fmp.metricConditions != nil {
+		return fmp.processMetricsWithConditions(pdm)
+	}
+
 	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
 		keepMetricsForResource := fmp.shouldKeepMetricsForResource(rm.Resource())
 		if !keepMetricsForResource {
@@ -200,3 +239,64 @@ func (fmp *filterMetricProcessor) shouldKeepMetricsForResource(resource pcommon.
 
 	return true
 }
+
+// processMetricsWithConditions filters the given metrics, and then their data points, with the conditions.
+func (fmp *filterMetricProcessor) processMetricsWithConditions(pdm pmetric.Metrics) (pmetric.Metrics, error) {
+	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				ctx := tqlmetrics.MetricTransformContext{
+					Metric:               m,
+					Metrics:              sm.Metrics(),
+					InstrumentationScope: sm.Scope(),
+					Resource:             rm.Resource(),
+				}
+				if !fmp.metricConditions.shouldKeep(ctx) {
+					return true
+				}
+				return !fmp.filterDataPoints(ctx)
+			})
+			// Filter out empty ScopeMetrics
+			return sm.Metrics().Len() == 0
+		})
+		// Filter out empty ResourceMetrics
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if pdm.ResourceMetrics().Len() == 0 {
+		return pdm, processorhelper.ErrSkipProcessingData
+	}
+	return pdm, nil
+}
+
+// filterDataPoints removes the data points of the metric of ctx that are not kept by the data point
+// conditions. It returns false if all the data points of the metric were removed.
+func (fmp *filterMetricProcessor) filterDataPoints(ctx tqlmetrics.MetricTransformContext) bool {
+	if fmp.dataPointConditions.isEmpty() {
+		return true
+	}
+
+	shouldRemove := func(dp interface{}) bool {
+		ctx.DataPoint = dp
+		return !fmp.dataPointConditions.shouldKeep(ctx)
+	}
+
+	m := ctx.Metric
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return shouldRemove(dp) })
+		return m.Gauge().DataPoints().Len() > 0
+	case pmetric.MetricDataTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return shouldRemove(dp) })
+		return m.Sum().DataPoints().Len() > 0
+	case pmetric.MetricDataTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return shouldRemove(dp) })
+		return m.Histogram().DataPoints().Len() > 0
+	case pmetric.MetricDataTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return shouldRemove(dp) })
+		return m.ExponentialHistogram().DataPoints().Len() > 0
+	case pmetric.MetricDataTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return shouldRemove(dp) })
+		return m.Summary().DataPoints().Len() > 0
+	}
+	return true
+}
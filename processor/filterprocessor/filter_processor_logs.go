@@ -23,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
 )
 
 type filterLogProcessor struct {
@@ -30,9 +31,24 @@ type filterLogProcessor struct {
 	excludeMatcher filterlog.Matcher
 	includeMatcher filterlog.Matcher
 	logger         *zap.Logger
+
+	// conditions is set when the log records are filtered with conditions.
+	conditions *conditionFilter
 }
 
 func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	if cfg.Logs.Conditions != nil {
+		conditions, err := newConditionFilter(cfg.Logs.Conditions.LogRecord, tqllogs.ParsePath, tqllogs.ParseEnum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log record conditions: %w", err)
+		}
+		return &filterLogProcessor{
+			cfg:        cfg,
+			logger:     logger,
+			conditions: conditions,
+		}, nil
+	}
+
 	var includeMatcher filterlog.Matcher
 	var excludeMatcher filterlog.Matcher
 
@@ -84,6 +100,16 @@ func (flp *filterLogProcessor) filterLogRecords(rLogs plog.ResourceLogsSlice) {
 			instrumentationScope := scope.Scope()
 			lrs := scope.LogRecords()
 
+			if flp.conditions != nil {
+				lrs.RemoveIf(func(lr plog.LogRecord) bool {
+					return !flp.conditions.shouldKeep(tqllogs.LogTransformContext{
+						Log:                  lr,
+						InstrumentationScope: instrumentationScope,
+						Resource:             resource,
+					})
+				})
+			}
+
 			if flp.includeMatcher != nil {
 				// If includeMatcher exists, remove all records that do not match the filter.
 				lrs.RemoveIf(func(lr plog.LogRecord) bool {
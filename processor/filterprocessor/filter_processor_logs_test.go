@@ -624,6 +624,94 @@ func TestFilterLogProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterLogProcessorWithConditions(t *testing.T) {
+	inLogs := []logWithResource{
+		{
+			logNames:           []string{"log1", "log2"},
+			resourceAttributes: map[string]interface{}{"service.name": "checkout"},
+			severityNumber:     plog.SeverityNumberWARN,
+		},
+		{
+			logNames:           []string{"log3"},
+			resourceAttributes: map[string]interface{}{"service.name": "cart"},
+			severityNumber:     plog.SeverityNumberDEBUG,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		conditions      LogConditions
+		outLN           [][]string
+		allLogsFiltered bool
+	}{
+		{
+			name: "includeSeverity",
+			conditions: LogConditions{
+				LogRecord: ConditionFilters{Include: []string{`severity_number >= SEVERITY_NUMBER_WARN`}},
+			},
+			outLN: [][]string{{"log1", "log2"}},
+		},
+		{
+			name: "excludeRecordAttribute",
+			conditions: LogConditions{
+				LogRecord: ConditionFilters{Exclude: []string{`attributes["name"] == "log2"`}},
+			},
+			outLN: [][]string{{"log1"}, {"log3"}},
+		},
+		{
+			name: "excludeResource",
+			conditions: LogConditions{
+				LogRecord: ConditionFilters{Exclude: []string{`resource.attributes["service.name"] != "nothing"`}},
+			},
+			allLogsFiltered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := new(consumertest.LogsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs:              LogFilters{Conditions: &test.conditions},
+			}
+			flp, err := NewFactory().CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+
+			require.NoError(t, flp.ConsumeLogs(context.Background(), testResourceLogs(inLogs)))
+			got := next.AllLogs()
+
+			if test.allLogsFiltered {
+				require.Len(t, got, 0)
+				return
+			}
+
+			require.Len(t, got, 1)
+			rLogs := got[0].ResourceLogs()
+			require.Equal(t, len(test.outLN), rLogs.Len())
+			for i, wantOut := range test.outLN {
+				gotLogs := rLogs.At(i).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, len(wantOut), gotLogs.Len())
+				for idx := range wantOut {
+					val, ok := gotLogs.At(idx).Attributes().Get("name")
+					require.True(t, ok)
+					assert.Equal(t, wantOut[idx], val.AsString())
+				}
+			}
+		})
+	}
+}
+
+func TestFilterLogProcessorInvalidCondition(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Logs: LogFilters{Conditions: &LogConditions{
+			LogRecord: ConditionFilters{Include: []string{`unknown == "value"`}},
+		}},
+	}
+	_, err := NewFactory().CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "failed to parse log record conditions")
+}
+
 func testResourceLogs(lwrs []logWithResource) plog.Logs {
 	ld := plog.NewLogs()
 
@@ -379,6 +379,149 @@ func TestFilterMetricProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterMetricProcessorWithConditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		conditions         MetricConditions
+		outDataPoints      map[string][]string // output data point "env" attributes per metric name
+		allMetricsFiltered bool
+	}{
+		{
+			name: "excludeMetricName",
+			conditions: MetricConditions{
+				Metric: ConditionFilters{Exclude: []string{`metric.name == "gauge"`}},
+			},
+			outDataPoints: map[string][]string{
+				"sum":       {"dev", "prod"},
+				"histogram": {"dev"},
+			},
+		},
+		{
+			name: "includeMetricType",
+			conditions: MetricConditions{
+				Metric: ConditionFilters{Include: []string{`metric.type == METRIC_DATA_TYPE_HISTOGRAM`}},
+			},
+			outDataPoints: map[string][]string{
+				"histogram": {"dev"},
+			},
+		},
+		{
+			name: "excludeDataPoints",
+			conditions: MetricConditions{
+				DataPoint: ConditionFilters{Exclude: []string{`attributes["env"] == "dev"`}},
+			},
+			outDataPoints: map[string][]string{
+				"gauge": {"prod"},
+				"sum":   {"prod"},
+			},
+		},
+		{
+			name: "includeDataPointsOfMetric",
+			conditions: MetricConditions{
+				Metric:    ConditionFilters{Include: []string{`resource.attributes["host.name"] == "host"`}},
+				DataPoint: ConditionFilters{Include: []string{`attributes["env"] == "prod" or metric.name == "histogram"`}},
+			},
+			outDataPoints: map[string][]string{
+				"gauge":     {"prod"},
+				"sum":       {"prod"},
+				"histogram": {"dev"},
+			},
+		},
+		{
+			name: "excludeAll",
+			conditions: MetricConditions{
+				DataPoint: ConditionFilters{Exclude: []string{`resource.attributes["host.name"] == "host"`}},
+			},
+			allMetricsFiltered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Metrics:           MetricFilters{Conditions: &test.conditions},
+			}
+			fmp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+
+			require.NoError(t, fmp.ConsumeMetrics(context.Background(), testDataPointMetrics()))
+			got := next.AllMetrics()
+
+			if test.allMetricsFiltered {
+				require.Equal(t, 0, len(got))
+				return
+			}
+
+			require.Equal(t, 1, len(got))
+			gotDataPoints := map[string][]string{}
+			metrics := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				m := metrics.At(i)
+				var envs []string
+				switch m.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					for j := 0; j < m.Gauge().DataPoints().Len(); j++ {
+						env, _ := m.Gauge().DataPoints().At(j).Attributes().Get("env")
+						envs = append(envs, env.StringVal())
+					}
+				case pmetric.MetricDataTypeSum:
+					for j := 0; j < m.Sum().DataPoints().Len(); j++ {
+						env, _ := m.Sum().DataPoints().At(j).Attributes().Get("env")
+						envs = append(envs, env.StringVal())
+					}
+				case pmetric.MetricDataTypeHistogram:
+					for j := 0; j < m.Histogram().DataPoints().Len(); j++ {
+						env, _ := m.Histogram().DataPoints().At(j).Attributes().Get("env")
+						envs = append(envs, env.StringVal())
+					}
+				}
+				gotDataPoints[m.Name()] = envs
+			}
+			assert.Equal(t, test.outDataPoints, gotDataPoints)
+		})
+	}
+}
+
+func TestFilterMetricProcessorInvalidCondition(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Metrics: MetricFilters{Conditions: &MetricConditions{
+			Metric: ConditionFilters{Exclude: []string{`attributes["env"] == "dev"`}},
+		}},
+	}
+	_, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "failed to parse metric conditions")
+}
+
+// testDataPointMetrics generates a gauge and a sum with a "dev" and a "prod" data point,
+// and a histogram with a "dev" data point.
+func testDataPointMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("host.name", "host")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("env", "dev")
+	gauge.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("env", "prod")
+
+	sum := ms.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("env", "dev")
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("env", "prod")
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("env", "dev")
+	return md
+}
+
 func testResourceMetrics(mwrs []metricWithResource) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
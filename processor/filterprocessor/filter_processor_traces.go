@@ -16,6 +16,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -24,6 +25,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 )
 
 type filterSpanProcessor struct {
@@ -31,9 +33,17 @@ type filterSpanProcessor struct {
 	include filterspan.Matcher
 	exclude filterspan.Matcher
 	logger  *zap.Logger
+
+	// spanConditions and spanEventConditions are set when the spans are filtered with conditions.
+	spanConditions      *conditionFilter
+	spanEventConditions *conditionFilter
 }
 
 func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	if cfg.Spans.Conditions != nil {
+		return newConditionSpansProcessor(logger, cfg)
+	}
+
 	if cfg.Spans.Include == nil && cfg.Spans.Exclude == nil {
 		return nil, nil
 	}
@@ -67,6 +77,33 @@ func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProces
 	}, nil
 }
 
+func newConditionSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	spanConditions, err := newConditionFilter(cfg.Spans.Conditions.Span, tqltraces.ParsePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse span conditions: %w", err)
+	}
+	spanEventConditions, err := newConditionFilter(cfg.Spans.Conditions.SpanEvent, parseSpanEventPath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse span event conditions: %w", err)
+	}
+
+	logger.Info(
+		"Span filter configured",
+		zap.String("ID", cfg.ID().String()),
+		zap.Strings("[Include] span conditions", cfg.Spans.Conditions.Span.Include),
+		zap.Strings("[Exclude] span conditions", cfg.Spans.Conditions.Span.Exclude),
+		zap.Strings("[Include] span event conditions", cfg.Spans.Conditions.SpanEvent.Include),
+		zap.Strings("[Exclude] span event conditions", cfg.Spans.Conditions.SpanEvent.Exclude),
+	)
+
+	return &filterSpanProcessor{
+		cfg:                 cfg,
+		logger:              logger,
+		spanConditions:      spanConditions,
+		spanEventConditions: spanEventConditions,
+	}, nil
+}
+
 func createSpanMatcher(cfg *Config) (filterspan.Matcher, filterspan.Matcher, error) {
 	var includeMatcher filterspan.Matcher
 	var excludeMatcher filterspan.Matcher
@@ -93,7 +130,11 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 		for x := 0; x < resSpan.ScopeSpans().Len(); x++ {
 			ils := resSpan.ScopeSpans().At(x)
 			ils.Spans().RemoveIf(func(span ptrace.Span) bool {
-				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope())
+				if fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope()) {
+					return true
+				}
+				fsp.filterSpanEvents(span, resSpan.Resource(), ils.Scope())
+				return false
 			})
 		}
 		// Remove empty elements, that way if we delete everything we can tell
@@ -112,6 +153,14 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 }
 
 func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+	if fsp.spanConditions != nil {
+		return !fsp.spanConditions.shouldKeep(tqltraces.SpanTransformContext{
+			Span:                 span,
+			InstrumentationScope: library,
+			Resource:             resource,
+		})
+	}
+
 	if fsp.include != nil {
 		if !fsp.include.MatchSpan(span, resource, library) {
 			return true
@@ -126,3 +175,18 @@ func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcom
 
 	return false
 }
+
+// filterSpanEvents removes the events of the span that are not kept by the span event conditions.
+func (fsp *filterSpanProcessor) filterSpanEvents(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) {
+	if fsp.spanEventConditions == nil || fsp.spanEventConditions.isEmpty() {
+		return
+	}
+	span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+		return !fsp.spanEventConditions.shouldKeep(spanEventTransformContext{
+			spanEvent: event,
+			span:      span,
+			scope:     library,
+			resource:  resource,
+		})
+	})
+}
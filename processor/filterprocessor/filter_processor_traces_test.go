@@ -166,6 +166,124 @@ func TestFilterTraceProcessor(t *testing.T) {
 		})
 	}
 }
+func TestFilterTraceProcessorWithConditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		conditions         SpanConditions
+		inTraces           ptrace.Traces
+		allTracesFiltered  bool
+		spanCountExpected  int
+		eventCountExpected int
+	}{
+		{
+			name: "includeServiceName",
+			conditions: SpanConditions{
+				Span: ConditionFilters{Include: []string{`resource.attributes["service.name"] == "keep"`}},
+			},
+			inTraces:          generateTraces(nameTraces),
+			spanCountExpected: 2,
+		},
+		{
+			name: "excludeRedis",
+			conditions: SpanConditions{
+				Span: ConditionFilters{Exclude: []string{`attributes["db.type"] == "redis"`}},
+			},
+			inTraces:          generateTraces(redisTraces),
+			allTracesFiltered: true,
+		},
+		{
+			name: "includeThenExclude",
+			conditions: SpanConditions{
+				Span: ConditionFilters{
+					Include: []string{`instrumentation_library.name == "otel"`},
+					Exclude: []string{`resource.attributes["service.name"] == "dont_keep"`},
+				},
+			},
+			inTraces:          generateTraces(nameTraces),
+			spanCountExpected: 2,
+		},
+		{
+			name: "excludeSpanEvents",
+			conditions: SpanConditions{
+				SpanEvent: ConditionFilters{Exclude: []string{`name == "debug"`, `attributes["level"] == "trace"`}},
+			},
+			inTraces:           generateTracesWithEvents(),
+			spanCountExpected:  2,
+			eventCountExpected: 2,
+		},
+		{
+			name: "includeSpanEventsOfSpan",
+			conditions: SpanConditions{
+				SpanEvent: ConditionFilters{Include: []string{`span.name == "with_events" and name != "debug"`}},
+			},
+			inTraces:           generateTracesWithEvents(),
+			spanCountExpected:  2,
+			eventCountExpected: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := new(consumertest.TracesSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Spans:             SpanFilters{Conditions: &test.conditions},
+			}
+			fmp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+			require.NoError(t, err)
+
+			require.NoError(t, fmp.ConsumeTraces(ctx, test.inTraces))
+			got := next.AllTraces()
+
+			if test.allTracesFiltered {
+				require.Equal(t, 0, len(got))
+				return
+			}
+			require.Equal(t, test.spanCountExpected, got[0].SpanCount())
+			if test.eventCountExpected > 0 {
+				events := 0
+				spans := got[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+				for i := 0; i < spans.Len(); i++ {
+					events += spans.At(i).Events().Len()
+				}
+				require.Equal(t, test.eventCountExpected, events)
+			}
+		})
+	}
+}
+
+func TestFilterTraceProcessorInvalidCondition(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Spans: SpanFilters{Conditions: &SpanConditions{
+			SpanEvent: ConditionFilters{Exclude: []string{`kind == SPAN_KIND_SERVER`}},
+		}},
+	}
+	_, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "failed to parse span event conditions")
+}
+
+// generateTracesWithEvents generates a span with four events, and a span without events.
+func generateTracesWithEvents() ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+
+	span := spans.AppendEmpty()
+	span.SetName("with_events")
+	span.Events().AppendEmpty().SetName("info")
+	span.Events().AppendEmpty().SetName("debug")
+	event := span.Events().AppendEmpty()
+	event.SetName("log")
+	event.Attributes().InsertString("level", "trace")
+	event = span.Events().AppendEmpty()
+	event.SetName("log")
+	event.Attributes().InsertString("level", "error")
+
+	spans.AppendEmpty().SetName("without_events")
+	return td
+}
+
 func generateTraces(traces []testTrace) ptrace.Traces {
 	td := ptrace.NewTraces()
 
@@ -22,54 +22,59 @@ import (
 )
 
 func instrumentationLibrariesEqual(il1, il2 pcommon.InstrumentationScope) bool {
-	return il1.Name() == il2.Name() && il1.Version() == il2.Version()
+	return il1.Name() == il2.Name() && il1.Version() == il2.Version() &&
+		il1.DroppedAttributesCount() == il2.DroppedAttributesCount() &&
+		attributesEqual(il1.Attributes(), il2.Attributes())
 }
 
 // matchingScopeSpans searches for a ptrace.ScopeSpans instance matching
-// given InstrumentationScope. If nothing is found, it creates a new one
-func matchingScopeSpans(rl ptrace.ResourceSpans, library pcommon.InstrumentationScope) ptrace.ScopeSpans {
+// given InstrumentationScope and schema URL. If nothing is found, it creates a new one
+func matchingScopeSpans(rl ptrace.ResourceSpans, library pcommon.InstrumentationScope, schemaURL string) ptrace.ScopeSpans {
 	ilss := rl.ScopeSpans()
 	for i := 0; i < ilss.Len(); i++ {
 		ils := ilss.At(i)
-		if instrumentationLibrariesEqual(ils.Scope(), library) {
+		if ils.SchemaUrl() == schemaURL && instrumentationLibrariesEqual(ils.Scope(), library) {
 			return ils
 		}
 	}
 
 	ils := ilss.AppendEmpty()
 	library.CopyTo(ils.Scope())
+	ils.SetSchemaUrl(schemaURL)
 	return ils
 }
 
 // matchingScopeLogs searches for a plog.ScopeLogs instance matching
-// given InstrumentationScope. If nothing is found, it creates a new one
-func matchingScopeLogs(rl plog.ResourceLogs, library pcommon.InstrumentationScope) plog.ScopeLogs {
+// given InstrumentationScope and schema URL. If nothing is found, it creates a new one
+func matchingScopeLogs(rl plog.ResourceLogs, library pcommon.InstrumentationScope, schemaURL string) plog.ScopeLogs {
 	ills := rl.ScopeLogs()
 	for i := 0; i < ills.Len(); i++ {
 		sl := ills.At(i)
-		if instrumentationLibrariesEqual(sl.Scope(), library) {
+		if sl.SchemaUrl() == schemaURL && instrumentationLibrariesEqual(sl.Scope(), library) {
 			return sl
 		}
 	}
 
 	sl := ills.AppendEmpty()
 	library.CopyTo(sl.Scope())
+	sl.SetSchemaUrl(schemaURL)
 	return sl
 }
 
 // matchingScopeMetrics searches for a pmetric.ScopeMetrics instance matching
-// given InstrumentationScope. If nothing is found, it creates a new one
-func matchingScopeMetrics(rm pmetric.ResourceMetrics, library pcommon.InstrumentationScope) pmetric.ScopeMetrics {
+// given InstrumentationScope and schema URL. If nothing is found, it creates a new one
+func matchingScopeMetrics(rm pmetric.ResourceMetrics, library pcommon.InstrumentationScope, schemaURL string) pmetric.ScopeMetrics {
 	ilms := rm.ScopeMetrics()
 	for i := 0; i < ilms.Len(); i++ {
 		ilm := ilms.At(i)
-		if instrumentationLibrariesEqual(ilm.Scope(), library) {
+		if ilm.SchemaUrl() == schemaURL && instrumentationLibrariesEqual(ilm.Scope(), library) {
 			return ilm
 		}
 	}
 
 	ilm := ilms.AppendEmpty()
 	library.CopyTo(ilm.Scope())
+	ilm.SetSchemaUrl(schemaURL)
 	return ilm
 }
 
@@ -121,16 +126,21 @@ func buildReferenceAttributes(originResource pcommon.Resource, requiredAttribute
 // resourceMatches verifies if given pcommon.Resource attributes strictly match with the specified
 // reference Attributes (all attributes must match strictly)
 func resourceMatches(resource pcommon.Resource, referenceAttributes pcommon.Map) bool {
+	return attributesEqual(resource.Attributes(), referenceAttributes)
+}
+
+// attributesEqual verifies if both Attributes have the same keys, with equal values
+func attributesEqual(attributes pcommon.Map, referenceAttributes pcommon.Map) bool {
 
 	// If not the same number of attributes, it doesn't match
-	if referenceAttributes.Len() != resource.Attributes().Len() {
+	if referenceAttributes.Len() != attributes.Len() {
 		return false
 	}
 
-	// Go through each attribute and check the corresponding attribute value in the tested Resource
+	// Go through each attribute and check the corresponding attribute value in the tested Attributes
 	matching := true
 	referenceAttributes.Range(func(referenceKey string, referenceValue pcommon.Value) bool {
-		testedValue, foundKey := resource.Attributes().Get(referenceKey)
+		testedValue, foundKey := attributes.Get(referenceKey)
 		if !foundKey || !referenceValue.Equal(testedValue) {
 			// One difference is enough to consider it doesn't match, so fail early
 			matching = false
@@ -142,34 +152,34 @@ func resourceMatches(resource pcommon.Resource, referenceAttributes pcommon.Map)
 	return matching
 }
 
-// findResource searches for an existing plog.ResourceLogs that strictly matches with the specified reference
-// Attributes. Returns the matching plog.ResourceLogs and bool value which is set to true if found
-func (lgba logsGroupedByAttrs) findResource(referenceAttributes pcommon.Map) (plog.ResourceLogs, bool) {
+// findResource searches for an existing plog.ResourceLogs that strictly matches with the specified schema URL
+// and reference Attributes. Returns the matching plog.ResourceLogs and bool value which is set to true if found
+func (lgba logsGroupedByAttrs) findResource(schemaURL string, referenceAttributes pcommon.Map) (plog.ResourceLogs, bool) {
 	for i := 0; i < lgba.Len(); i++ {
-		if resourceMatches(lgba.At(i).Resource(), referenceAttributes) {
+		if lgba.At(i).SchemaUrl() == schemaURL && resourceMatches(lgba.At(i).Resource(), referenceAttributes) {
 			return lgba.At(i), true
 		}
 	}
 	return plog.ResourceLogs{}, false
 }
 
-// findResource searches for an existing plog.ResourceLogs that strictly matches with the specified reference
-// Attributes. Returns the matching plog.ResourceLogs and bool value which is set to true if found
-func (sgba spansGroupedByAttrs) findResource(referenceAttributes pcommon.Map) (ptrace.ResourceSpans, bool) {
+// findResource searches for an existing ptrace.ResourceSpans that strictly matches with the specified schema URL
+// and reference Attributes. Returns the matching ptrace.ResourceSpans and bool value which is set to true if found
+func (sgba spansGroupedByAttrs) findResource(schemaURL string, referenceAttributes pcommon.Map) (ptrace.ResourceSpans, bool) {
 	for i := 0; i < sgba.Len(); i++ {
-		if resourceMatches(sgba.At(i).Resource(), referenceAttributes) {
+		if sgba.At(i).SchemaUrl() == schemaURL && resourceMatches(sgba.At(i).Resource(), referenceAttributes) {
 			return sgba.At(i), true
 		}
 	}
 	return ptrace.ResourceSpans{}, false
 }
 
-// findResource searches for an existing pmetric.ResourceMetrics that strictly matches with the specified reference
-// Attributes. Returns the matching pmetric.ResourceMetrics and bool value which is set to true if found
-func (mgba metricsGroupedByAttrs) findResource(referenceAttributes pcommon.Map) (pmetric.ResourceMetrics, bool) {
+// findResource searches for an existing pmetric.ResourceMetrics that strictly matches with the specified schema URL
+// and reference Attributes. Returns the matching pmetric.ResourceMetrics and bool value which is set to true if found
+func (mgba metricsGroupedByAttrs) findResource(schemaURL string, referenceAttributes pcommon.Map) (pmetric.ResourceMetrics, bool) {
 
 	for i := 0; i < mgba.Len(); i++ {
-		if resourceMatches(mgba.At(i).Resource(), referenceAttributes) {
+		if mgba.At(i).SchemaUrl() == schemaURL && resourceMatches(mgba.At(i).Resource(), referenceAttributes) {
 			return mgba.At(i), true
 		}
 	}
@@ -191,14 +201,14 @@ func updateResourceToMatch(newResource pcommon.Resource, originResource pcommon.
 
 }
 
-// findOrCreateResource searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
-func (sgba *spansGroupedByAttrs) findOrCreateResource(originResource pcommon.Resource, requiredAttributes pcommon.Map) ptrace.ResourceSpans {
+// findOrCreateResource searches for a Resource with matching schema URL and attributes and returns it. If nothing is found, it is being created
+func (sgba *spansGroupedByAttrs) findOrCreateResource(originResource pcommon.Resource, schemaURL string, requiredAttributes pcommon.Map) ptrace.ResourceSpans {
 
 	// Build the reference attributes that we're looking for in Resources
 	referenceAttributes := buildReferenceAttributes(originResource, requiredAttributes)
 
 	// Do we have a matching Resource?
-	resource, found := sgba.findResource(referenceAttributes)
+	resource, found := sgba.findResource(schemaURL, referenceAttributes)
 	if found {
 		return resource
 	}
@@ -206,18 +216,19 @@ func (sgba *spansGroupedByAttrs) findOrCreateResource(originResource pcommon.Res
 	// Not found: create a new resource
 	resource = sgba.AppendEmpty()
 	updateResourceToMatch(resource.Resource(), originResource, requiredAttributes)
+	resource.SetSchemaUrl(schemaURL)
 	return resource
 
 }
 
-// findResourceOrElseCreate searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
-func (lgba *logsGroupedByAttrs) findResourceOrElseCreate(originResource pcommon.Resource, requiredAttributes pcommon.Map) plog.ResourceLogs {
+// findResourceOrElseCreate searches for a Resource with matching schema URL and attributes and returns it. If nothing is found, it is being created
+func (lgba *logsGroupedByAttrs) findResourceOrElseCreate(originResource pcommon.Resource, schemaURL string, requiredAttributes pcommon.Map) plog.ResourceLogs {
 
 	// Build the reference attributes that we're looking for in Resources
 	referenceAttributes := buildReferenceAttributes(originResource, requiredAttributes)
 
 	// Do we have a matching Resource?
-	resource, found := lgba.findResource(referenceAttributes)
+	resource, found := lgba.findResource(schemaURL, referenceAttributes)
 	if found {
 		return resource
 	}
@@ -225,18 +236,19 @@ func (lgba *logsGroupedByAttrs) findResourceOrElseCreate(originResource pcommon.
 	// Not found: create a new resource
 	resource = lgba.AppendEmpty()
 	updateResourceToMatch(resource.Resource(), originResource, requiredAttributes)
+	resource.SetSchemaUrl(schemaURL)
 	return resource
 
 }
 
-// findResourceOrElseCreate searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
-func (mgba *metricsGroupedByAttrs) findResourceOrElseCreate(originResource pcommon.Resource, requiredAttributes pcommon.Map) pmetric.ResourceMetrics {
+// findResourceOrElseCreate searches for a Resource with matching schema URL and attributes and returns it. If nothing is found, it is being created
+func (mgba *metricsGroupedByAttrs) findResourceOrElseCreate(originResource pcommon.Resource, schemaURL string, requiredAttributes pcommon.Map) pmetric.ResourceMetrics {
 
 	// Build the reference attributes that we're looking for in Resources
 	referenceAttributes := buildReferenceAttributes(originResource, requiredAttributes)
 
 	// Do we have a matching Resource?
-	resource, found := mgba.findResource(referenceAttributes)
+	resource, found := mgba.findResource(schemaURL, referenceAttributes)
 	if found {
 		return resource
 	}
@@ -244,6 +256,7 @@ func (mgba *metricsGroupedByAttrs) findResourceOrElseCreate(originResource pcomm
 	// Not found: create a new resource
 	resource = mgba.AppendEmpty()
 	updateResourceToMatch(resource.Resource(), originResource, requiredAttributes)
+	resource.SetSchemaUrl(schemaURL)
 	return resource
 
 }
@@ -119,7 +119,7 @@ func TestResourceAttributeScenarios(t *testing.T) {
 				tt.fillExpectedResourceFun(tt.baseResource, expectedResource)
 			}
 
-			rl := lagAttrs.findResourceOrElseCreate(tt.baseResource, recordAttributeMap)
+			rl := lagAttrs.findResourceOrElseCreate(tt.baseResource, "", recordAttributeMap)
 			assert.EqualValues(t, expectedResource.Attributes(), rl.Resource().Attributes())
 		})
 	}
@@ -135,28 +135,28 @@ func TestInstrumentationLibraryMatching(t *testing.T) {
 	il2 := pcommon.NewInstrumentationScope()
 	il2.SetName("Name2")
 
-	ill1 := matchingScopeLogs(rl, il1)
-	ils1 := matchingScopeSpans(rs, il1)
-	ilm1 := matchingScopeMetrics(rm, il1)
+	ill1 := matchingScopeLogs(rl, il1, "")
+	ils1 := matchingScopeSpans(rs, il1, "")
+	ilm1 := matchingScopeMetrics(rm, il1, "")
 	assert.EqualValues(t, il1, ill1.Scope())
 	assert.EqualValues(t, il1, ils1.Scope())
 	assert.EqualValues(t, il1, ilm1.Scope())
 
-	ill2 := matchingScopeLogs(rl, il2)
-	ils2 := matchingScopeSpans(rs, il2)
-	ilm2 := matchingScopeMetrics(rm, il2)
+	ill2 := matchingScopeLogs(rl, il2, "")
+	ils2 := matchingScopeSpans(rs, il2, "")
+	ilm2 := matchingScopeMetrics(rm, il2, "")
 	assert.EqualValues(t, il2, ill2.Scope())
 	assert.EqualValues(t, il2, ils2.Scope())
 	assert.EqualValues(t, il2, ilm2.Scope())
 
-	ill1 = matchingScopeLogs(rl, il1)
-	ils1 = matchingScopeSpans(rs, il1)
-	ilm1 = matchingScopeMetrics(rm, il1)
+	ill1 = matchingScopeLogs(rl, il1, "")
+	ils1 = matchingScopeSpans(rs, il1, "")
+	ilm1 = matchingScopeMetrics(rm, il1, "")
 	assert.EqualValues(t, il1, ill1.Scope())
 	assert.EqualValues(t, il1, ils1.Scope())
 	assert.EqualValues(t, il1, ilm1.Scope())
 }
 
 func BenchmarkAttrGrouping(b *testing.B) {
-	lagAttrs.findResourceOrElseCreate(res, groups[rand.Intn(count)])
+	lagAttrs.findResourceOrElseCreate(res, "", groups[rand.Intn(count)])
 }
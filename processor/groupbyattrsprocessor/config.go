@@ -15,14 +15,57 @@
 package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+// Mode describes how the processor moves attributes between the records and their Resource.
+type Mode string
+
+const (
+	// ModeGroup moves the attributes matching the keys from the spans, log records and metric data points
+	// to their Resource, grouping the records with the same values under the same Resource.
+	// Without keys, the records sharing the same Resource and InstrumentationScope are compacted.
+	ModeGroup Mode = "group"
+	// ModeCompact merges the records sharing the same Resource and InstrumentationScope, without moving
+	// any attribute. It is the same as ModeGroup without keys.
+	ModeCompact Mode = "compact"
+	// ModeDemote moves the Resource attributes matching the keys down to each span, log record and metric
+	// data point of the Resource, and then compacts the Resources that became identical.
+	ModeDemote Mode = "demote"
+)
+
 // Config is the configuration for the processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
-	// GroupByKeys describes the attribute names that are going to be used for grouping.
+	// Mode describes how attributes are moved: group (default), compact or demote.
+	Mode Mode `mapstructure:"mode"`
+
+	// GroupByKeys describes the attribute names that are going to be used for grouping,
+	// or that are demoted from the Resource to the records in the demote mode.
 	// Empty value is allowed, since processor in such case can compact data
 	GroupByKeys []string `mapstructure:"keys"`
 }
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case ModeGroup:
+	case ModeCompact:
+		if len(cfg.GroupByKeys) > 0 {
+			return errors.New("keys cannot be used in the compact mode")
+		}
+	case ModeDemote:
+		if len(cfg.GroupByKeys) == 0 {
+			return errors.New("keys are required in the demote mode")
+		}
+	default:
+		return fmt.Errorf("unsupported mode %q, must be one of %q, %q or %q", cfg.Mode, ModeGroup, ModeCompact, ModeDemote)
+	}
+	return nil
+}
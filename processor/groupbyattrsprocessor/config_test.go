@@ -52,6 +52,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, groupingConf,
 		&Config{
 			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "grouping")),
+			Mode:              ModeGroup,
 			GroupByKeys:       []string{"key1", "key2"},
 		})
 
@@ -59,6 +60,47 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, compactionConf,
 		&Config{
 			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "compaction")),
+			Mode:              ModeGroup,
 			GroupByKeys:       []string{},
 		})
+
+	demotionConf := cfg.Processors[config.NewComponentIDWithName(typeStr, "demotion")]
+	assert.Equal(t, demotionConf,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "demotion")),
+			Mode:              ModeDemote,
+			GroupByKeys:       []string{"k8s.pod.name"},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   Mode
+		keys   []string
+		errMsg string
+	}{
+		{name: "group with keys", mode: ModeGroup, keys: []string{"key1"}},
+		{name: "group without keys", mode: ModeGroup},
+		{name: "compact", mode: ModeCompact},
+		{name: "compact with keys", mode: ModeCompact, keys: []string{"key1"}, errMsg: "keys cannot be used in the compact mode"},
+		{name: "demote", mode: ModeDemote, keys: []string{"key1"}},
+		{name: "demote without keys", mode: ModeDemote, errMsg: "keys are required in the demote mode"},
+		{name: "unsupported mode", mode: "promote", errMsg: `unsupported mode "promote", must be one of "group", "compact" or "demote"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Mode = tt.mode
+			cfg.GroupByKeys = tt.keys
+
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
 }
@@ -58,11 +58,12 @@ func NewFactory() component.ProcessorFactory {
 func createDefaultConfig() config.Processor {
 	return &Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Mode:              ModeGroup,
 		GroupByKeys:       []string{},
 	}
 }
 
-func createGroupByAttrsProcessor(logger *zap.Logger, attributes []string) *groupByAttrsProcessor {
+func createGroupByAttrsProcessor(logger *zap.Logger, mode Mode, attributes []string) *groupByAttrsProcessor {
 	var nonEmptyAttributes []string
 	presentAttributes := make(map[string]struct{})
 
@@ -78,7 +79,7 @@ func createGroupByAttrsProcessor(logger *zap.Logger, attributes []string) *group
 		}
 	}
 
-	return &groupByAttrsProcessor{logger: logger, groupByKeys: nonEmptyAttributes}
+	return &groupByAttrsProcessor{logger: logger, demote: mode == ModeDemote, groupByKeys: nonEmptyAttributes}
 }
 
 // createTracesProcessor creates a trace processor based on this config.
@@ -89,7 +90,7 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces) (component.TracesProcessor, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := createGroupByAttrsProcessor(set.Logger, oCfg.Mode, oCfg.GroupByKeys)
 
 	return processorhelper.NewTracesProcessorWithCreateSettings(
 		ctx,
@@ -108,7 +109,7 @@ func createLogsProcessor(
 	nextConsumer consumer.Logs) (component.LogsProcessor, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := createGroupByAttrsProcessor(set.Logger, oCfg.Mode, oCfg.GroupByKeys)
 
 	return processorhelper.NewLogsProcessorWithCreateSettings(
 		ctx,
@@ -127,7 +128,7 @@ func createMetricsProcessor(
 	nextConsumer consumer.Metrics) (component.MetricsProcessor, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := createGroupByAttrsProcessor(set.Logger, oCfg.Mode, oCfg.GroupByKeys)
 
 	return processorhelper.NewMetricsProcessorWithCreateSettings(
 		ctx,
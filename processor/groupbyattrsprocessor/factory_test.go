@@ -54,12 +54,12 @@ func TestCreateTestProcessor(t *testing.T) {
 
 func TestNoKeys(t *testing.T) {
 	// This is allowed since can be used for compacting data
-	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+	gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, []string{})
 	assert.NotNil(t, gap)
 }
 
 func TestDuplicateKeys(t *testing.T) {
-	gbap := createGroupByAttrsProcessor(zap.NewNop(), []string{"foo", "foo", ""})
+	gbap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, []string{"foo", "foo", ""})
 	assert.NotNil(t, gbap)
 	assert.EqualValues(t, []string{"foo"}, gbap.groupByKeys)
 }
@@ -26,7 +26,10 @@ import (
 )
 
 type groupByAttrsProcessor struct {
-	logger      *zap.Logger
+	logger *zap.Logger
+	// demote is true when the attributes matching the keys are moved from the Resource to the records,
+	// instead of from the records to the Resource.
+	demote      bool
 	groupByKeys []string
 }
 
@@ -37,6 +40,7 @@ func (gap *groupByAttrsProcessor) processTraces(ctx context.Context, td ptrace.T
 
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
+		resource, demotedAttributes := gap.extractDemotedAttributes(rs.Resource())
 
 		ilss := rs.ScopeSpans()
 		for j := 0; j < ilss.Len(); j++ {
@@ -44,20 +48,17 @@ func (gap *groupByAttrsProcessor) processTraces(ctx context.Context, td ptrace.T
 			for k := 0; k < ils.Spans().Len(); k++ {
 				span := ils.Spans().At(k)
 
-				toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(span.Attributes())
+				toBeGrouped, requiredAttributes := gap.moveAttributes(span.Attributes(), demotedAttributes)
 				if toBeGrouped {
 					stats.Record(ctx, mNumGroupedSpans.M(1))
-					// Some attributes are going to be moved from span to resource level,
-					// so we can delete those on the record level
-					deleteAttributes(requiredAttributes, span.Attributes())
 				} else {
 					stats.Record(ctx, mNumNonGroupedSpans.M(1))
 				}
 
 				// Lets combine the base resource attributes + the extracted (grouped) attributes
 				// and keep them in the grouping entry
-				groupedSpans := groupedResourceSpans.findOrCreateResource(rs.Resource(), requiredAttributes)
-				sp := matchingScopeSpans(groupedSpans, ils.Scope()).Spans().AppendEmpty()
+				groupedSpans := groupedResourceSpans.findOrCreateResource(resource, rs.SchemaUrl(), requiredAttributes)
+				sp := matchingScopeSpans(groupedSpans, ils.Scope(), ils.SchemaUrl()).Spans().AppendEmpty()
 				span.CopyTo(sp)
 			}
 		}
@@ -77,6 +78,7 @@ func (gap *groupByAttrsProcessor) processLogs(ctx context.Context, ld plog.Logs)
 
 	for i := 0; i < rl.Len(); i++ {
 		ls := rl.At(i)
+		resource, demotedAttributes := gap.extractDemotedAttributes(ls.Resource())
 
 		ills := ls.ScopeLogs()
 		for j := 0; j < ills.Len(); j++ {
@@ -84,20 +86,17 @@ func (gap *groupByAttrsProcessor) processLogs(ctx context.Context, ld plog.Logs)
 			for k := 0; k < sl.LogRecords().Len(); k++ {
 				log := sl.LogRecords().At(k)
 
-				toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(log.Attributes())
+				toBeGrouped, requiredAttributes := gap.moveAttributes(log.Attributes(), demotedAttributes)
 				if toBeGrouped {
 					stats.Record(ctx, mNumGroupedLogs.M(1))
-					// Some attributes are going to be moved from log record to resource level,
-					// so we can delete those on the record level
-					deleteAttributes(requiredAttributes, log.Attributes())
 				} else {
 					stats.Record(ctx, mNumNonGroupedLogs.M(1))
 				}
 
 				// Lets combine the base resource attributes + the extracted (grouped) attributes
 				// and keep them in the grouping entry
-				groupedLogs := groupedResourceLogs.findResourceOrElseCreate(ls.Resource(), requiredAttributes)
-				lr := matchingScopeLogs(groupedLogs, sl.Scope()).LogRecords().AppendEmpty()
+				groupedLogs := groupedResourceLogs.findResourceOrElseCreate(resource, ls.SchemaUrl(), requiredAttributes)
+				lr := matchingScopeLogs(groupedLogs, sl.Scope(), sl.SchemaUrl()).LogRecords().AppendEmpty()
 				log.CopyTo(lr)
 			}
 		}
@@ -118,6 +117,7 @@ func (gap *groupByAttrsProcessor) processMetrics(ctx context.Context, md pmetric
 
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
+		resource, demotedAttributes := gap.extractDemotedAttributes(rm.Resource())
 
 		ilms := rm.ScopeMetrics()
 		for j := 0; j < ilms.Len(); j++ {
@@ -130,35 +130,35 @@ func (gap *groupByAttrsProcessor) processMetrics(ctx context.Context, md pmetric
 				case pmetric.MetricDataTypeGauge:
 					for pointIndex := 0; pointIndex < metric.Gauge().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Gauge().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, resource, demotedAttributes, ilm, metric, dataPoint.Attributes())
 						dataPoint.CopyTo(groupedMetric.Gauge().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricDataTypeSum:
 					for pointIndex := 0; pointIndex < metric.Sum().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Sum().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, resource, demotedAttributes, ilm, metric, dataPoint.Attributes())
 						dataPoint.CopyTo(groupedMetric.Sum().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricDataTypeSummary:
 					for pointIndex := 0; pointIndex < metric.Summary().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Summary().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, resource, demotedAttributes, ilm, metric, dataPoint.Attributes())
 						dataPoint.CopyTo(groupedMetric.Summary().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricDataTypeHistogram:
 					for pointIndex := 0; pointIndex < metric.Histogram().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Histogram().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, resource, demotedAttributes, ilm, metric, dataPoint.Attributes())
 						dataPoint.CopyTo(groupedMetric.Histogram().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricDataTypeExponentialHistogram:
 					for pointIndex := 0; pointIndex < metric.ExponentialHistogram().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.ExponentialHistogram().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, groupedResourceMetrics, rm, resource, demotedAttributes, ilm, metric, dataPoint.Attributes())
 						dataPoint.CopyTo(groupedMetric.ExponentialHistogram().DataPoints().AppendEmpty())
 					}
 
@@ -182,6 +182,45 @@ func deleteAttributes(attrsForRemoval, targetAttrs pcommon.Map) {
 	})
 }
 
+// extractDemotedAttributes returns the Resource the records of originResource belong to after processing,
+// together with the attributes demoted from it to the records. The attributes matching the keys are
+// removed from a copy of the Resource in the demote mode; otherwise the Resource is returned unchanged.
+func (gap *groupByAttrsProcessor) extractDemotedAttributes(originResource pcommon.Resource) (pcommon.Resource, pcommon.Map) {
+	if !gap.demote {
+		return originResource, pcommon.NewMap()
+	}
+
+	_, demotedAttributes := gap.extractGroupingAttributes(originResource.Attributes())
+	resource := pcommon.NewResource()
+	originResource.CopyTo(resource)
+	deleteAttributes(demotedAttributes, resource.Attributes())
+	return resource, demotedAttributes
+}
+
+// moveAttributes moves the attributes of a span, log record or metric data point between the record and its
+// Resource. The attributes matching the keys are extracted from the record, or the demoted Resource attributes
+// are added to the record in the demote mode, unless the record has its own value for them.
+// Returns:
+//   - whether any attribute was moved (true) or none (false)
+//   - the extracted attributes the Resource of the record must have
+func (gap *groupByAttrsProcessor) moveAttributes(attributes pcommon.Map, demotedAttributes pcommon.Map) (bool, pcommon.Map) {
+	if gap.demote {
+		demotedAttributes.Range(func(k string, v pcommon.Value) bool {
+			attributes.Insert(k, v)
+			return true
+		})
+		return demotedAttributes.Len() > 0, pcommon.NewMap()
+	}
+
+	toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(attributes)
+	if toBeGrouped {
+		// Some attributes are going to be moved from the record to resource level,
+		// so we can delete those on the record level
+		deleteAttributes(requiredAttributes, attributes)
+	}
+	return toBeGrouped, requiredAttributes
+}
+
 // extractGroupingAttributes extracts the keys and values of the specified Attributes
 // that match with the attributes keys that is used for grouping
 // Returns:
@@ -245,26 +284,25 @@ func (gap *groupByAttrsProcessor) getGroupedMetricsFromAttributes(
 	ctx context.Context,
 	groupedResourceMetrics *metricsGroupedByAttrs,
 	originResourceMetrics pmetric.ResourceMetrics,
+	originResource pcommon.Resource,
+	demotedAttributes pcommon.Map,
 	ilm pmetric.ScopeMetrics,
 	metric pmetric.Metric,
 	attributes pcommon.Map,
 ) pmetric.Metric {
 
-	toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(attributes)
+	toBeGrouped, requiredAttributes := gap.moveAttributes(attributes, demotedAttributes)
 	if toBeGrouped {
 		stats.Record(ctx, mNumGroupedMetrics.M(1))
-		// These attributes are going to be moved from datapoint to resource level,
-		// so we can delete those on the datapoint
-		deleteAttributes(requiredAttributes, attributes)
 	} else {
 		stats.Record(ctx, mNumNonGroupedMetrics.M(1))
 	}
 
 	// Get the ResourceMetrics matching with these attributes
-	groupedResource := groupedResourceMetrics.findResourceOrElseCreate(originResourceMetrics.Resource(), requiredAttributes)
+	groupedResource := groupedResourceMetrics.findResourceOrElseCreate(originResource, originResourceMetrics.SchemaUrl(), requiredAttributes)
 
 	// Get the corresponding instrumentation library
-	groupedInstrumentationLibrary := matchingScopeMetrics(groupedResource, ilm.Scope())
+	groupedInstrumentationLibrary := matchingScopeMetrics(groupedResource, ilm.Scope(), ilm.SchemaUrl())
 
 	// Return the metric in this resource
 	return getMetricInInstrumentationLibrary(groupedInstrumentationLibrary, metric)
@@ -289,7 +289,7 @@ func TestComplexAttributeGrouping(t *testing.T) {
 			inputMetrics := someComplexMetrics(tt.withResourceAttrIndex, tt.inputResourceCount, tt.inputInstrumentationLibraryCount, 2)
 			inputHistogramMetrics := someComplexHistogramMetrics(tt.withResourceAttrIndex, tt.inputResourceCount, tt.inputInstrumentationLibraryCount, 2, 2)
 
-			gap := createGroupByAttrsProcessor(zap.NewNop(), tt.groupByKeys)
+			gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, tt.groupByKeys)
 
 			processedLogs, err := gap.processLogs(context.Background(), inputLogs)
 			assert.NoError(t, err)
@@ -436,7 +436,7 @@ func TestAttributeGrouping(t *testing.T) {
 			histogramMetrics := someHistogramMetrics(attrMap, 1, tt.count)
 			exponentialHistogramMetrics := someExponentialHistogramMetrics(attrMap, 1, tt.count)
 
-			gap := createGroupByAttrsProcessor(zap.NewNop(), tt.groupByKeys)
+			gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, tt.groupByKeys)
 
 			expectedResource := prepareResource(attrMap, tt.groupByKeys)
 			expectedAttributes := filterAttributeMap(attrMap, tt.nonGroupedKeys)
@@ -764,7 +764,7 @@ func TestMetricAdvancedGrouping(t *testing.T) {
 	datapoint.Attributes().UpsertString("id", "eth0")
 
 	// Perform the test
-	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{"host.name"})
+	gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, []string{"host.name"})
 
 	processedMetrics, err := gap.processMetrics(context.Background(), metrics)
 	assert.NoError(t, err)
@@ -848,7 +848,7 @@ func TestCompacting(t *testing.T) {
 	assert.Equal(t, 100, logs.ResourceLogs().Len())
 	assert.Equal(t, 100, metrics.ResourceMetrics().Len())
 
-	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+	gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, []string{})
 
 	processedSpans, err := gap.processTraces(context.Background(), spans)
 	assert.NoError(t, err)
@@ -884,6 +884,144 @@ func TestCompacting(t *testing.T) {
 	}
 }
 
+func TestCompactingSchemaURLAndScopeAttributes(t *testing.T) {
+	traces := ptrace.NewTraces()
+	for _, tt := range []struct {
+		resourceSchemaURL string
+		scopeSchemaURL    string
+		scopeAttribute    string
+	}{
+		{resourceSchemaURL: "https://opentelemetry.io/schemas/1.9.0", scopeSchemaURL: "", scopeAttribute: "a"},
+		{resourceSchemaURL: "https://opentelemetry.io/schemas/1.9.0", scopeSchemaURL: "", scopeAttribute: "a"},
+		{resourceSchemaURL: "https://opentelemetry.io/schemas/1.9.0", scopeSchemaURL: "", scopeAttribute: "b"},
+		{resourceSchemaURL: "https://opentelemetry.io/schemas/1.9.0", scopeSchemaURL: "https://opentelemetry.io/schemas/1.8.0", scopeAttribute: "a"},
+		{resourceSchemaURL: "https://opentelemetry.io/schemas/1.8.0", scopeSchemaURL: "", scopeAttribute: "a"},
+	} {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(tt.resourceSchemaURL)
+		rs.Resource().Attributes().UpsertString("host.name", "localhost")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl(tt.scopeSchemaURL)
+		ss.Scope().SetName("MyLibrary")
+		ss.Scope().Attributes().UpsertString("attr", tt.scopeAttribute)
+		ss.Spans().AppendEmpty().SetName("span")
+	}
+
+	gap := createGroupByAttrsProcessor(zap.NewNop(), ModeCompact, []string{})
+
+	processedTraces, err := gap.processTraces(context.Background(), traces)
+	assert.NoError(t, err)
+
+	rss := processedTraces.ResourceSpans()
+	assert.Equal(t, 2, rss.Len())
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rss.At(0).SchemaUrl())
+	ilss := rss.At(0).ScopeSpans()
+	assert.Equal(t, 3, ilss.Len())
+	assert.Equal(t, 2, ilss.At(0).Spans().Len())
+	assert.Equal(t, 1, ilss.At(1).Spans().Len())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.8.0", ilss.At(2).SchemaUrl())
+	assert.Equal(t, 1, ilss.At(2).Spans().Len())
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.8.0", rss.At(1).SchemaUrl())
+	assert.Equal(t, 1, rss.At(1).ScopeSpans().Len())
+}
+
+func TestDemoting(t *testing.T) {
+	// Input:
+	//
+	// Resource {host.name="localhost",pod="pod-A"}
+	//   Record {id="1"}
+	// Resource {host.name="localhost",pod="pod-B"}
+	//   Record {id="2",pod="pod-C"}
+	// Resource {host.name="localhost"}
+	//   Record {id="3"}
+	//
+	// Expected output:
+	//
+	// Resource {host.name="localhost"}
+	//   Record {id="1",pod="pod-A"}
+	//   Record {id="2",pod="pod-C"}
+	//   Record {id="3"}
+	resourcePods := []string{"pod-A", "pod-B", ""}
+	recordPods := []string{"", "pod-C", ""}
+	expectedPods := []string{"pod-A", "pod-C", ""}
+
+	traces := ptrace.NewTraces()
+	logs := plog.NewLogs()
+	metrics := pmetric.NewMetrics()
+	for i := range resourcePods {
+		resourceAttributes := pcommon.NewMap()
+		resourceAttributes.UpsertString("host.name", "localhost")
+		recordAttributes := pcommon.NewMap()
+		recordAttributes.UpsertString("id", fmt.Sprint(i+1))
+		if resourcePods[i] != "" {
+			resourceAttributes.UpsertString("pod", resourcePods[i])
+		}
+		if recordPods[i] != "" {
+			recordAttributes.UpsertString("pod", recordPods[i])
+		}
+
+		rs := traces.ResourceSpans().AppendEmpty()
+		resourceAttributes.CopyTo(rs.Resource().Attributes())
+		recordAttributes.CopyTo(rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes())
+
+		rl := logs.ResourceLogs().AppendEmpty()
+		resourceAttributes.CopyTo(rl.Resource().Attributes())
+		recordAttributes.CopyTo(rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes())
+
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		resourceAttributes.CopyTo(rm.Resource().Attributes())
+		metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("gauge-1")
+		metric.SetDataType(pmetric.MetricDataTypeGauge)
+		recordAttributes.CopyTo(metric.Gauge().DataPoints().AppendEmpty().Attributes())
+	}
+
+	gap := createGroupByAttrsProcessor(zap.NewNop(), ModeDemote, []string{"pod"})
+
+	processedTraces, err := gap.processTraces(context.Background(), traces)
+	assert.NoError(t, err)
+	processedLogs, err := gap.processLogs(context.Background(), logs)
+	assert.NoError(t, err)
+	processedMetrics, err := gap.processMetrics(context.Background(), metrics)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, processedTraces.ResourceSpans().Len())
+	assert.Equal(t, 1, processedLogs.ResourceLogs().Len())
+	assert.Equal(t, 1, processedMetrics.ResourceMetrics().Len())
+
+	rs := processedTraces.ResourceSpans().At(0)
+	rl := processedLogs.ResourceLogs().At(0)
+	rm := processedMetrics.ResourceMetrics().At(0)
+
+	expectedResource := pcommon.NewMap()
+	expectedResource.UpsertString("host.name", "localhost")
+	assert.Equal(t, expectedResource.AsRaw(), rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, expectedResource.AsRaw(), rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, expectedResource.AsRaw(), rm.Resource().Attributes().AsRaw())
+
+	spans := rs.ScopeSpans().At(0).Spans()
+	logRecords := rl.ScopeLogs().At(0).LogRecords()
+	dataPoints := rm.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	assert.Equal(t, 3, spans.Len())
+	assert.Equal(t, 3, logRecords.Len())
+	assert.Equal(t, 3, dataPoints.Len())
+
+	for i, expectedPod := range expectedPods {
+		expected := map[string]interface{}{"id": fmt.Sprint(i + 1)}
+		if expectedPod != "" {
+			expected["pod"] = expectedPod
+		}
+		assert.Equal(t, expected, spans.At(i).Attributes().AsRaw())
+		assert.Equal(t, expected, logRecords.At(i).Attributes().AsRaw())
+		assert.Equal(t, expected, dataPoints.At(i).Attributes().AsRaw())
+	}
+
+	// The original data must be left untouched
+	assert.Equal(t, "pod-A", traces.ResourceSpans().At(0).Resource().Attributes().AsRaw()["pod"])
+}
+
 func BenchmarkCompacting(bb *testing.B) {
 	runs := []struct {
 		ilCount   int
@@ -906,7 +1044,7 @@ func BenchmarkCompacting(bb *testing.B) {
 	for _, run := range runs {
 		bb.Run(fmt.Sprintf("instrumentation_library_count=%d, spans_per_library_count=%d", run.ilCount, run.spanCount), func(b *testing.B) {
 			spans := someSpans(attrMap, run.ilCount, run.spanCount)
-			gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+			gap := createGroupByAttrsProcessor(zap.NewNop(), ModeGroup, []string{})
 
 			b.ResetTimer()
 			for n := 0; n < b.N; n++ {
@@ -0,0 +1,511 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+)
+
+const keySeparator = byte(0x1E)
+
+// aggregator holds the series aggregated during the current interval, grouped like in pmetric.Metrics.
+// Every level keeps the order in which its keys were added, so that the emitted metrics follow the
+// order of the received ones. The cumulative series are kept across intervals, until all their inputs
+// are stale.
+type aggregator struct {
+	logger           *zap.Logger
+	removeAttributes []string
+	gaugeAggregation GaugeAggregation
+	stalenessTimeout time.Duration
+	resources        map[string]*resourceAggregation
+	resourceKeys     []string
+}
+
+type resourceAggregation struct {
+	resource  pcommon.Resource
+	scopes    map[string]*scopeAggregation
+	scopeKeys []string
+}
+
+type scopeAggregation struct {
+	scope      pcommon.InstrumentationScope
+	metrics    map[string]*metricAggregation
+	metricKeys []string
+}
+
+type metricAggregation struct {
+	// metric holds the descriptor of the metric, without data points.
+	metric     pmetric.Metric
+	series     map[string]*seriesAggregation
+	seriesKeys []string
+}
+
+// seriesAggregation is the aggregated value of a series, identified by the attributes left after the removal.
+// Gauges and delta metrics are aggregated as their data points are received. For cumulative metrics,
+// the most recent value of every input series is kept, keyed by its original attributes, and these
+// values are only summed when the interval ends. They are kept across intervals, so that an input
+// series missing from an interval doesn't make the sum go down, and dropped once they are stale.
+type seriesAggregation struct {
+	attributes           pcommon.Map
+	number               *numberValue
+	histogram            *histogramValue
+	cumulativeNumbers    map[string]*numberValue
+	cumulativeHistograms map[string]*histogramValue
+	cumulativeLastSeen   map[string]time.Time
+	cumulativeKeys       []string
+	// cumulativeUpdated is whether an input series was received during the current interval.
+	cumulativeUpdated bool
+	// cumulativeStart is the start timestamp of the emitted cumulative data points, which must not
+	// change from one interval to the next.
+	cumulativeStart pcommon.Timestamp
+}
+
+func newAggregator(logger *zap.Logger, removeAttributes []string, gaugeAggregation GaugeAggregation, stalenessTimeout time.Duration) *aggregator {
+	return &aggregator{
+		logger:           logger,
+		removeAttributes: removeAttributes,
+		gaugeAggregation: gaugeAggregation,
+		stalenessTimeout: stalenessTimeout,
+		resources:        map[string]*resourceAggregation{},
+	}
+}
+
+// add aggregates the data points of a gauge, sum or histogram metric received at the given time.
+func (a *aggregator) add(now time.Time, resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) {
+	ma := a.metricAggregation(resource, scope, m)
+
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if skipNumberDataPoint(dp) {
+				continue
+			}
+			a.aggregateGauge(a.series(ma, dp.Attributes()), newNumberValue(dp))
+		}
+	case pmetric.MetricDataTypeSum:
+		cumulative := m.Sum().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
+		monotonic := m.Sum().IsMonotonic()
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if skipNumberDataPoint(dp) {
+				continue
+			}
+			s := a.series(ma, dp.Attributes())
+			value := newNumberValue(dp)
+			switch {
+			case cumulative:
+				inputKey := mapKey(dp.Attributes())
+				previous, ok := s.cumulativeNumbers[inputKey]
+				if !ok {
+					s.cumulativeKeys = append(s.cumulativeKeys, inputKey)
+				}
+				if !ok || previous.timestamp <= value.timestamp {
+					if ok && isNumberReset(previous, value, monotonic) {
+						s.restartCumulative()
+					}
+					s.cumulativeNumbers[inputKey] = value
+				}
+				s.seen(inputKey, now)
+			case s.number == nil:
+				s.number = value
+			default:
+				s.number.add(value)
+			}
+		}
+	case pmetric.MetricDataTypeHistogram:
+		cumulative := m.Histogram().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Flags().NoRecordedValue() {
+				continue
+			}
+			s := a.series(ma, dp.Attributes())
+			value := newHistogramValue(dp)
+			switch {
+			case cumulative:
+				inputKey := mapKey(dp.Attributes())
+				previous, ok := s.cumulativeHistograms[inputKey]
+				if !ok {
+					s.cumulativeKeys = append(s.cumulativeKeys, inputKey)
+				}
+				if !ok || previous.timestamp <= value.timestamp {
+					if ok && isHistogramReset(previous, value) {
+						s.restartCumulative()
+					}
+					s.cumulativeHistograms[inputKey] = value
+				}
+				s.seen(inputKey, now)
+			case s.histogram == nil:
+				s.histogram = value
+			case !s.histogram.hasSameBuckets(value):
+				a.logger.Debug("dropping histogram data point with different bucket boundaries", zap.String("metric", m.Name()))
+			default:
+				s.histogram.add(value)
+			}
+		}
+	}
+}
+
+func (a *aggregator) aggregateGauge(s *seriesAggregation, value *numberValue) {
+	if s.number == nil {
+		s.number = value
+		return
+	}
+
+	var replace bool
+	switch a.gaugeAggregation {
+	case GaugeAggregationMin:
+		replace = value.float() < s.number.float()
+	case GaugeAggregationMax:
+		replace = value.float() > s.number.float()
+	default:
+		replace = value.timestamp >= s.number.timestamp
+	}
+	// The aggregated data point covers the whole interval, whichever value is kept.
+	start, timestamp := minStartTimestamp(s.number.start, value.start), maxTimestamp(s.number.timestamp, value.timestamp)
+	if replace {
+		s.number = value
+	}
+	s.number.start, s.number.timestamp = start, timestamp
+}
+
+func (a *aggregator) metricAggregation(resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) *metricAggregation {
+	resourceKey := mapKey(resource.Attributes())
+	ra, ok := a.resources[resourceKey]
+	if !ok {
+		ra = &resourceAggregation{resource: pcommon.NewResource(), scopes: map[string]*scopeAggregation{}}
+		resource.CopyTo(ra.resource)
+		a.resources[resourceKey] = ra
+		a.resourceKeys = append(a.resourceKeys, resourceKey)
+	}
+
+	scopeKey := scope.Name() + string(keySeparator) + scope.Version()
+	sa, ok := ra.scopes[scopeKey]
+	if !ok {
+		sa = &scopeAggregation{scope: pcommon.NewInstrumentationScope(), metrics: map[string]*metricAggregation{}}
+		scope.CopyTo(sa.scope)
+		ra.scopes[scopeKey] = sa
+		ra.scopeKeys = append(ra.scopeKeys, scopeKey)
+	}
+
+	metricKey := metricKey(m)
+	ma, ok := sa.metrics[metricKey]
+	if !ok {
+		ma = &metricAggregation{metric: pmetric.NewMetric(), series: map[string]*seriesAggregation{}}
+		metricutils.CopyMetricDescriptor(m, ma.metric)
+		sa.metrics[metricKey] = ma
+		sa.metricKeys = append(sa.metricKeys, metricKey)
+	}
+	return ma
+}
+
+// series returns the aggregation of the series the data point with the given attributes belongs to.
+func (a *aggregator) series(ma *metricAggregation, attributes pcommon.Map) *seriesAggregation {
+	seriesAttributes := pcommon.NewMap()
+	attributes.CopyTo(seriesAttributes)
+	for _, key := range a.removeAttributes {
+		seriesAttributes.Remove(key)
+	}
+
+	seriesKey := mapKey(seriesAttributes)
+	s, ok := ma.series[seriesKey]
+	if !ok {
+		s = &seriesAggregation{
+			attributes:           seriesAttributes,
+			cumulativeNumbers:    map[string]*numberValue{},
+			cumulativeHistograms: map[string]*histogramValue{},
+			cumulativeLastSeen:   map[string]time.Time{},
+		}
+		ma.series[seriesKey] = s
+		ma.seriesKeys = append(ma.seriesKeys, seriesKey)
+	}
+	return s
+}
+
+// seen records that a data point of the cumulative input series was received.
+func (s *seriesAggregation) seen(inputKey string, now time.Time) {
+	s.cumulativeLastSeen[inputKey] = now
+	s.cumulativeUpdated = true
+}
+
+// hasData returns whether a data point must be emitted for the series at the end of the current interval.
+func (s *seriesAggregation) hasData() bool {
+	return s.number != nil || s.histogram != nil || s.cumulativeUpdated
+}
+
+// restartCumulative starts a new cumulative series, as the sum of the inputs may go down after an input
+// series was reset or removed. The new series starts at the latest timestamp of the current inputs, which
+// the emitted data points can't precede.
+func (s *seriesAggregation) restartCumulative() {
+	var start pcommon.Timestamp
+	for _, inputKey := range s.cumulativeKeys {
+		if value, ok := s.cumulativeNumbers[inputKey]; ok {
+			start = maxTimestamp(start, value.timestamp)
+		}
+		if value, ok := s.cumulativeHistograms[inputKey]; ok {
+			start = maxTimestamp(start, value.timestamp)
+		}
+	}
+	s.cumulativeStart = start
+}
+
+// startTimestamp returns the start timestamp of the data point emitted for the series. For cumulative
+// series, the earliest start timestamp of the inputs is kept from the first emitted data point, until
+// the series is restarted.
+func (s *seriesAggregation) startTimestamp(start pcommon.Timestamp) pcommon.Timestamp {
+	if len(s.cumulativeKeys) == 0 {
+		return start
+	}
+	if s.cumulativeStart == 0 {
+		s.cumulativeStart = start
+	}
+	return s.cumulativeStart
+}
+
+// flush returns a data point for every series aggregated since the previous flush, and starts a new interval.
+func (a *aggregator) flush(now time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, resourceKey := range a.resourceKeys {
+		ra := a.resources[resourceKey]
+		rm := md.ResourceMetrics().AppendEmpty()
+		ra.resource.CopyTo(rm.Resource())
+		for _, scopeKey := range ra.scopeKeys {
+			sa := ra.scopes[scopeKey]
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sa.scope.CopyTo(sm.Scope())
+			for _, metricKey := range sa.metricKeys {
+				a.writeMetric(sa.metrics[metricKey], sm.Metrics())
+			}
+		}
+	}
+	a.reset(now)
+
+	// Metrics whose data points were all skipped have no series, and may leave empty scopes behind.
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md
+}
+
+// reset starts a new interval. Only the cumulative input series which are not stale are kept.
+func (a *aggregator) reset(now time.Time) {
+	a.resourceKeys = filterKeys(a.resourceKeys, func(resourceKey string) bool {
+		ra := a.resources[resourceKey]
+		ra.scopeKeys = filterKeys(ra.scopeKeys, func(scopeKey string) bool {
+			sa := ra.scopes[scopeKey]
+			sa.metricKeys = filterKeys(sa.metricKeys, func(metricKey string) bool {
+				ma := sa.metrics[metricKey]
+				ma.seriesKeys = filterKeys(ma.seriesKeys, func(seriesKey string) bool {
+					if a.resetSeries(ma.series[seriesKey], now) {
+						return true
+					}
+					delete(ma.series, seriesKey)
+					return false
+				})
+				if len(ma.seriesKeys) > 0 {
+					return true
+				}
+				delete(sa.metrics, metricKey)
+				return false
+			})
+			if len(sa.metricKeys) > 0 {
+				return true
+			}
+			delete(ra.scopes, scopeKey)
+			return false
+		})
+		if len(ra.scopeKeys) > 0 {
+			return true
+		}
+		delete(a.resources, resourceKey)
+		return false
+	})
+}
+
+// resetSeries starts a new interval for the series, dropping its stale cumulative input series.
+// It returns whether the series still has input series to aggregate.
+func (a *aggregator) resetSeries(s *seriesAggregation, now time.Time) bool {
+	s.number, s.histogram, s.cumulativeUpdated = nil, nil, false
+	removed := false
+	s.cumulativeKeys = filterKeys(s.cumulativeKeys, func(inputKey string) bool {
+		if now.Sub(s.cumulativeLastSeen[inputKey]) < a.stalenessTimeout {
+			return true
+		}
+		delete(s.cumulativeNumbers, inputKey)
+		delete(s.cumulativeHistograms, inputKey)
+		delete(s.cumulativeLastSeen, inputKey)
+		removed = true
+		return false
+	})
+	if len(s.cumulativeKeys) == 0 {
+		return false
+	}
+	if removed {
+		s.restartCumulative()
+	}
+	return true
+}
+
+func (a *aggregator) writeMetric(ma *metricAggregation, dest pmetric.MetricSlice) {
+	var seriesKeys []string
+	for _, seriesKey := range ma.seriesKeys {
+		if ma.series[seriesKey].hasData() {
+			seriesKeys = append(seriesKeys, seriesKey)
+		}
+	}
+	if len(seriesKeys) == 0 {
+		return
+	}
+	m := dest.AppendEmpty()
+	ma.metric.CopyTo(m)
+	for _, seriesKey := range seriesKeys {
+		s := ma.series[seriesKey]
+		switch m.DataType() {
+		case pmetric.MetricDataTypeGauge:
+			s.number.writeTo(s.attributes, m.Gauge().DataPoints().AppendEmpty())
+		case pmetric.MetricDataTypeSum:
+			value := s.number
+			for _, inputKey := range s.cumulativeKeys {
+				input := s.cumulativeNumbers[inputKey]
+				if value == nil {
+					value = input.clone()
+				} else {
+					value.add(input)
+				}
+			}
+			value.start = s.startTimestamp(value.start)
+			value.writeTo(s.attributes, m.Sum().DataPoints().AppendEmpty())
+		case pmetric.MetricDataTypeHistogram:
+			value := s.histogram
+			for _, inputKey := range s.cumulativeKeys {
+				input := s.cumulativeHistograms[inputKey]
+				switch {
+				case value == nil:
+					value = input.clone()
+				case !value.hasSameBuckets(input):
+					a.logger.Debug("dropping histogram data point with different bucket boundaries", zap.String("metric", m.Name()))
+				default:
+					value.add(input)
+				}
+			}
+			value.start = s.startTimestamp(value.start)
+			value.writeTo(s.attributes, m.Histogram().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+// metricKey identifies a metric within a scope. Metrics with the same name but a different
+// type or temporality are aggregated separately.
+func metricKey(m pmetric.Metric) string {
+	var b strings.Builder
+	b.WriteString(m.Name())
+	b.WriteByte(keySeparator)
+	b.WriteString(m.Unit())
+	b.WriteByte(keySeparator)
+	b.WriteString(m.DataType().String())
+	switch m.DataType() {
+	case pmetric.MetricDataTypeSum:
+		b.WriteByte(keySeparator)
+		b.WriteString(m.Sum().AggregationTemporality().String())
+		b.WriteByte(keySeparator)
+		b.WriteString(strconv.FormatBool(m.Sum().IsMonotonic()))
+	case pmetric.MetricDataTypeHistogram:
+		b.WriteByte(keySeparator)
+		b.WriteString(m.Histogram().AggregationTemporality().String())
+	}
+	return b.String()
+}
+
+// mapKey returns a representation of the attributes usable as a key. The attributes are sorted in place.
+// The type of the values is part of the key, so that e.g. the int 1 and the string "1" are different.
+func mapKey(attributes pcommon.Map) string {
+	var b strings.Builder
+	attributes.Sort().Range(func(k string, v pcommon.Value) bool {
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(v.Type().String())
+		b.WriteByte(':')
+		b.WriteString(v.AsString())
+		b.WriteByte(keySeparator)
+		return true
+	})
+	return b.String()
+}
+
+// filterKeys returns the keys for which keep returns true, reusing the given slice.
+func filterKeys(keys []string, keep func(key string) bool) []string {
+	kept := keys[:0]
+	for _, key := range keys {
+		if keep(key) {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
+
+// skipNumberDataPoint returns whether the data point has no value to aggregate.
+func skipNumberDataPoint(dp pmetric.NumberDataPoint) bool {
+	return dp.Flags().NoRecordedValue() ||
+		(dp.ValueType() == pmetric.NumberDataPointValueTypeDouble && math.IsNaN(dp.DoubleVal()))
+}
+
+// isNumberReset returns whether the cumulative input series was reset between the previous value and
+// the current one: it has a new start timestamp, or it went down while being monotonic.
+func isNumberReset(previous, current *numberValue, monotonic bool) bool {
+	if previous.start != 0 && current.start != previous.start {
+		return true
+	}
+	return monotonic && current.float() < previous.float()
+}
+
+// isHistogramReset returns whether the cumulative input histogram was reset between the previous value
+// and the current one: it has a new start timestamp, or its count went down.
+func isHistogramReset(previous, current *histogramValue) bool {
+	if previous.start != 0 && current.start != previous.start {
+		return true
+	}
+	return current.summary.Count < previous.summary.Count
+}
+
+// minStartTimestamp returns the earliest of the start timestamps which are set.
+func minStartTimestamp(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+func maxTimestamp(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if b > a {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// GaugeAggregation is the way the gauge data points of a series are aggregated during an interval.
+type GaugeAggregation string
+
+const (
+	// GaugeAggregationLast keeps the most recent data point.
+	GaugeAggregationLast GaugeAggregation = "last"
+	// GaugeAggregationMin keeps the lowest value.
+	GaugeAggregationMin GaugeAggregation = "min"
+	// GaugeAggregationMax keeps the highest value.
+	GaugeAggregationMax GaugeAggregation = "max"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is the time window over which the data points are aggregated.
+	// A single data point is emitted for every series at the end of each interval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// RemoveAttributes lists the data point attributes removed before the series are aggregated.
+	RemoveAttributes []string `mapstructure:"remove_attributes"`
+
+	// StalenessTimeout is the time after which the last value of a cumulative input series, which is
+	// kept across intervals, is dropped if no new data point is received for it. Default: 5m.
+	StalenessTimeout time.Duration `mapstructure:"staleness_timeout"`
+
+	// GaugeAggregation is the way gauge data points are aggregated: last, min or max. Default: last.
+	GaugeAggregation GaugeAggregation `mapstructure:"gauge_aggregation"`
+
+	// Include specifies a filter on the metrics that should be aggregated.
+	// Exclude specifies a filter on the metrics that should not be aggregated.
+	// If neither `include` nor `exclude` are set, all metrics will be aggregated.
+	Include MatchMetrics `mapstructure:"include"`
+	Exclude MatchMetrics `mapstructure:"exclude"`
+}
+
+type MatchMetrics struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Metrics []string `mapstructure:"metrics"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if config.StalenessTimeout < config.Interval {
+		return fmt.Errorf("staleness_timeout must not be shorter than interval")
+	}
+	switch config.GaugeAggregation {
+	case GaugeAggregationLast, GaugeAggregationMin, GaugeAggregationMax:
+	default:
+		return fmt.Errorf("unsupported gauge_aggregation %q, must be one of %q, %q or %q",
+			config.GaugeAggregation, GaugeAggregationLast, GaugeAggregationMin, GaugeAggregationMax)
+	}
+	if (len(config.Include.Metrics) > 0 && len(config.Include.MatchType) == 0) ||
+		(len(config.Exclude.Metrics) > 0 && len(config.Exclude.MatchType) == 0) {
+		return fmt.Errorf("match_type must be set if metrics are supplied")
+	}
+	if (len(config.Include.MatchType) > 0 && len(config.Include.Metrics) == 0) ||
+		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
+		return fmt.Errorf("metrics must be supplied if match_type is set")
+	}
+	return nil
+}
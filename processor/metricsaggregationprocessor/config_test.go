@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func TestLoadingFullConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	assert.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	expected := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Interval:          30 * time.Second,
+		StalenessTimeout:  2 * time.Minute,
+		RemoveAttributes:  []string{"pod", "container"},
+		GaugeAggregation:  GaugeAggregationMax,
+		Include: MatchMetrics{
+			Metrics: []string{`http\..*`},
+			Config:  filterset.Config{MatchType: "regexp"},
+		},
+		Exclude: MatchMetrics{
+			Metrics: []string{"http.server.active_requests"},
+			Config:  filterset.Config{MatchType: "strict"},
+		},
+	}
+	assert.Equal(t, expected, cfg.Processors[expected.ID()])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          Config
+		errorMessage string
+	}{
+		{
+			name: "valid",
+			cfg: Config{
+				Interval:         time.Minute,
+				StalenessTimeout: 5 * time.Minute,
+				GaugeAggregation: GaugeAggregationLast,
+			},
+		},
+		{
+			name: "missing interval",
+			cfg: Config{
+				GaugeAggregation: GaugeAggregationLast,
+			},
+			errorMessage: "interval must be positive",
+		},
+		{
+			name: "staleness timeout shorter than interval",
+			cfg: Config{
+				Interval:         time.Minute,
+				StalenessTimeout: 30 * time.Second,
+				GaugeAggregation: GaugeAggregationLast,
+			},
+			errorMessage: "staleness_timeout must not be shorter than interval",
+		},
+		{
+			name: "unsupported gauge aggregation",
+			cfg: Config{
+				Interval:         time.Minute,
+				StalenessTimeout: 5 * time.Minute,
+				GaugeAggregation: "avg",
+			},
+			errorMessage: `unsupported gauge_aggregation "avg", must be one of "last", "min" or "max"`,
+		},
+		{
+			name: "missing match type",
+			cfg: Config{
+				Interval:         time.Minute,
+				StalenessTimeout: 5 * time.Minute,
+				GaugeAggregation: GaugeAggregationLast,
+				Exclude:          MatchMetrics{Metrics: []string{"metric1"}},
+			},
+			errorMessage: "match_type must be set if metrics are supplied",
+		},
+		{
+			name: "missing metrics",
+			cfg: Config{
+				Interval:         time.Minute,
+				StalenessTimeout: 5 * time.Minute,
+				GaugeAggregation: GaugeAggregationLast,
+				Include:          MatchMetrics{Config: filterset.Config{MatchType: "strict"}},
+			},
+			errorMessage: "metrics must be supplied if match_type is set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.Validate()
+			if test.errorMessage == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.errorMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+)
+
+type metricsAggregationProcessor struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	includeFS    filterset.FilterSet
+	excludeFS    filterset.FilterSet
+	interval     time.Duration
+	ticker       timeutils.TTicker
+
+	// lock protects the aggregator, which starts a new interval on every tick.
+	lock       sync.Mutex
+	aggregator *aggregator
+}
+
+var _ component.MetricsProcessor = (*metricsAggregationProcessor)(nil)
+
+func newMetricsAggregationProcessor(logger *zap.Logger, config *Config, nextConsumer consumer.Metrics) *metricsAggregationProcessor {
+	p := &metricsAggregationProcessor{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		interval:     config.Interval,
+		aggregator:   newAggregator(logger, config.RemoveAttributes, config.GaugeAggregation, config.StalenessTimeout),
+	}
+	if len(config.Include.Metrics) > 0 {
+		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
+	}
+	if len(config.Exclude.Metrics) > 0 {
+		p.excludeFS, _ = filterset.CreateFilterSet(config.Exclude.Metrics, &config.Exclude.Config)
+	}
+	return p
+}
+
+// Start is invoked during service startup.
+func (p *metricsAggregationProcessor) Start(context.Context, component.Host) error {
+	p.ticker = &timeutils.PolicyTicker{OnTickFunc: p.onTick}
+	p.ticker.Start(p.interval)
+	return nil
+}
+
+// Shutdown is invoked during service shutdown. The series aggregated during the current
+// interval are emitted, instead of being lost.
+func (p *metricsAggregationProcessor) Shutdown(ctx context.Context) error {
+	if p.ticker != nil {
+		p.ticker.Stop()
+	}
+	return p.flush(ctx)
+}
+
+func (p *metricsAggregationProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// ConsumeMetrics aggregates the metrics matching the filters, and passes the others through.
+func (p *metricsAggregationProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	now := time.Now()
+	p.lock.Lock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if !p.shouldAggregateMetric(m) {
+					return false
+				}
+				p.aggregator.add(now, rm.Resource(), sm.Scope(), m)
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	p.lock.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (p *metricsAggregationProcessor) onTick() {
+	if err := p.flush(context.Background()); err != nil {
+		p.logger.Error("failed to export the aggregated metrics", zap.Error(err))
+	}
+}
+
+// flush emits a data point for every series aggregated since the previous flush.
+func (p *metricsAggregationProcessor) flush(ctx context.Context) error {
+	p.lock.Lock()
+	md := p.aggregator.flush(time.Now())
+	p.lock.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+// shouldAggregateMetric returns whether the metric matches the filters and has a type that can be aggregated.
+func (p *metricsAggregationProcessor) shouldAggregateMetric(m pmetric.Metric) bool {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge, pmetric.MetricDataTypeSum, pmetric.MetricDataTypeHistogram:
+	default:
+		return false
+	}
+	return (p.includeFS == nil || p.includeFS.Matches(m.Name())) &&
+		(p.excludeFS == nil || !p.excludeFS.Matches(m.Name()))
+}
@@ -0,0 +1,371 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func newTestProcessor(t *testing.T, gaugeAggregation GaugeAggregation) (*metricsAggregationProcessor, *consumertest.MetricsSink) {
+	sink := new(consumertest.MetricsSink)
+	cfg := &Config{
+		Interval:         time.Minute,
+		StalenessTimeout: 5 * time.Minute,
+		RemoveAttributes: []string{"pod"},
+		GaugeAggregation: gaugeAggregation,
+		Exclude: MatchMetrics{
+			Metrics: []string{"excluded"},
+			Config:  filterset.Config{MatchType: "strict"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	return newMetricsAggregationProcessor(zap.NewNop(), cfg, sink), sink
+}
+
+// newTestMetric returns a batch with a single metric of the given type.
+func newTestMetric(name string, dataType pmetric.MetricDataType) (pmetric.Metrics, pmetric.Metric) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(dataType)
+	return md, m
+}
+
+func appendNumberDataPoint(dps pmetric.NumberDataPointSlice, pod string, start, timestamp int, value int64) pmetric.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.Attributes().InsertString("pod", pod)
+	dp.Attributes().InsertString("method", "GET")
+	dp.SetStartTimestamp(pcommon.Timestamp(start))
+	dp.SetTimestamp(pcommon.Timestamp(timestamp))
+	dp.SetIntVal(value)
+	return dp
+}
+
+func appendHistogramDataPoint(dps pmetric.HistogramDataPointSlice, pod string, start, timestamp int, bounds []float64, buckets []uint64) pmetric.HistogramDataPoint {
+	dp := dps.AppendEmpty()
+	dp.Attributes().InsertString("pod", pod)
+	dp.SetStartTimestamp(pcommon.Timestamp(start))
+	dp.SetTimestamp(pcommon.Timestamp(timestamp))
+	var count uint64
+	for _, bucket := range buckets {
+		count += bucket
+	}
+	dp.SetCount(count)
+	dp.SetSum(float64(count))
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(buckets))
+	return dp
+}
+
+// flushedMetric flushes the processor and returns the single metric it emits.
+func flushedMetric(t *testing.T, p *metricsAggregationProcessor, sink *consumertest.MetricsSink) pmetric.Metric {
+	sink.Reset()
+	require.NoError(t, p.flush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.MetricCount())
+
+	rm := md.ResourceMetrics().At(0)
+	v, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", v.StringVal())
+	assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+	return rm.ScopeMetrics().At(0).Metrics().At(0)
+}
+
+func TestAggregateDeltaSum(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+
+	for i, pod := range []string{"a", "b", "a"} {
+		md, m := newTestMetric("requests", pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		m.Sum().SetIsMonotonic(true)
+		appendNumberDataPoint(m.Sum().DataPoints(), pod, i*10+10, i*10+20, int64(i+1))
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+	assert.Empty(t, sink.AllMetrics(), "aggregated metrics must only be emitted when the interval ends")
+
+	m := flushedMetric(t, p, sink)
+	assert.Equal(t, "requests", m.Name())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	assert.True(t, m.Sum().IsMonotonic())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(6), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(40), dp.Timestamp())
+
+	sink.Reset()
+	require.NoError(t, p.flush(context.Background()))
+	assert.Empty(t, sink.AllMetrics(), "a flush must start a new interval")
+}
+
+func TestAggregateCumulativeSum(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+
+	md, m := newTestMetric("requests", pmetric.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	appendNumberDataPoint(m.Sum().DataPoints(), "a", 5, 20, 10)
+	appendNumberDataPoint(m.Sum().DataPoints(), "a", 5, 10, 4)
+	appendNumberDataPoint(m.Sum().DataPoints(), "b", 0, 10, 5).SetDoubleVal(5.5)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	m = flushedMetric(t, p, sink)
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+	assert.Equal(t, 15.5, dp.DoubleVal(), "only the most recent value of each input series must be summed")
+	assert.Equal(t, pcommon.Timestamp(5), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+}
+
+func TestAggregateCumulativeSumWithMissingSeries(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+	consume := func(pod string, start, timestamp int, value int64) {
+		md, m := newTestMetric("requests", pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		appendNumberDataPoint(m.Sum().DataPoints(), pod, start, timestamp, value)
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+
+	consume("a", 5, 10, 10)
+	consume("b", 3, 10, 5)
+	dp := flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(15), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(3), dp.StartTimestamp())
+
+	consume("a", 5, 20, 12)
+	dp = flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(17), dp.IntVal(), "the last value of a missing input series must be kept")
+	assert.Equal(t, pcommon.Timestamp(3), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+
+	consume("c", 15, 30, 1)
+	dp = flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(18), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(3), dp.StartTimestamp(), "the start timestamp must not change across intervals")
+
+	sink.Reset()
+	require.NoError(t, p.flush(context.Background()))
+	assert.Empty(t, sink.AllMetrics(), "no data point must be emitted when no input series was received")
+}
+
+func TestAggregateCumulativeSumDropsStaleSeries(t *testing.T) {
+	agg := newAggregator(zap.NewNop(), []string{"pod"}, GaugeAggregationLast, 5*time.Minute)
+	now := time.Now()
+	add := func(now time.Time, pod string, timestamp int, value int64) {
+		_, m := newTestMetric("requests", pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		appendNumberDataPoint(m.Sum().DataPoints(), pod, 0, timestamp, value)
+		agg.add(now, pcommon.NewResource(), pcommon.NewInstrumentationScope(), m)
+	}
+
+	add(now, "a", 10, 10)
+	add(now, "b", 10, 5)
+	require.Equal(t, 1, agg.flush(now).DataPointCount())
+
+	for i := 1; i <= 6; i++ {
+		now = now.Add(time.Minute)
+		add(now, "a", 10+i*10, int64(10+i))
+		md := agg.flush(now)
+		require.Equal(t, 1, md.DataPointCount())
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+		if i <= 5 {
+			assert.Equal(t, int64(15+i), dp.IntVal())
+			assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+		} else {
+			assert.Equal(t, int64(16), dp.IntVal(), "the stale input series must be dropped")
+			assert.Equal(t, pcommon.Timestamp(60), dp.StartTimestamp(), "a new series must start once the sum goes down")
+			assert.Equal(t, pcommon.Timestamp(70), dp.Timestamp())
+		}
+	}
+
+	now = now.Add(10 * time.Minute)
+	agg.flush(now)
+	assert.Empty(t, agg.resources, "the series must be dropped once all its input series are stale")
+}
+
+func TestAggregateCumulativeSumWithInputReset(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+	consume := func(name string, pod string, start, timestamp int, value int64) {
+		md, m := newTestMetric(name, pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(name == "requests")
+		appendNumberDataPoint(m.Sum().DataPoints(), pod, start, timestamp, value)
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+
+	consume("requests", "a", 5, 10, 10)
+	consume("requests", "b", 5, 10, 5)
+	consume("requests", "a", 5, 20, 12)
+	dp := flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(17), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(5), dp.StartTimestamp())
+
+	consume("requests", "a", 25, 30, 1)
+	dp = flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(6), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp(), "a new series must start when an input has a new start timestamp")
+	assert.Equal(t, pcommon.Timestamp(30), dp.Timestamp())
+
+	consume("requests", "b", 5, 40, 2)
+	dp = flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(3), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp(), "a new series must start when a monotonic input goes down")
+
+	consume("queue_size", "a", 5, 10, 10)
+	consume("queue_size", "a", 5, 20, 4)
+	dp = flushedMetric(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(4), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(5), dp.StartTimestamp(), "a non-monotonic input going down is not a reset")
+}
+
+func TestAggregateCumulativeHistogramWithInputReset(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+	consume := func(pod string, timestamp int, buckets []uint64) {
+		md, m := newTestMetric("latency", pmetric.MetricDataTypeHistogram)
+		m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		appendHistogramDataPoint(m.Histogram().DataPoints(), pod, 0, timestamp, []float64{1}, buckets)
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+
+	consume("a", 10, []uint64{1, 2})
+	consume("b", 10, []uint64{1, 1})
+	dp := flushedMetric(t, p, sink).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+
+	consume("a", 20, []uint64{1, 0})
+	dp = flushedMetric(t, p, sink).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, []uint64{2, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp(), "a new series must start when the count of an input goes down")
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+}
+
+func TestAggregateKeepsAttributeTypes(t *testing.T) {
+	agg := newAggregator(zap.NewNop(), []string{"pod"}, GaugeAggregationLast, 5*time.Minute)
+	_, m := newTestMetric("requests", pmetric.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	appendNumberDataPoint(m.Sum().DataPoints(), "a", 0, 10, 1).Attributes().InsertInt("code", 1)
+	appendNumberDataPoint(m.Sum().DataPoints(), "b", 0, 10, 2).Attributes().InsertString("code", "1")
+	agg.add(time.Now(), pcommon.NewResource(), pcommon.NewInstrumentationScope(), m)
+
+	md := agg.flush(time.Now())
+	assert.Equal(t, 2, md.DataPointCount(), "attributes with the same value but different types must not be aggregated together")
+}
+
+func TestAggregateGauge(t *testing.T) {
+	tests := []struct {
+		aggregation GaugeAggregation
+		want        int64
+	}{
+		{aggregation: GaugeAggregationLast, want: 3},
+		{aggregation: GaugeAggregationMin, want: 1},
+		{aggregation: GaugeAggregationMax, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.aggregation), func(t *testing.T) {
+			p, sink := newTestProcessor(t, tt.aggregation)
+
+			md, m := newTestMetric("memory", pmetric.MetricDataTypeGauge)
+			appendNumberDataPoint(m.Gauge().DataPoints(), "a", 0, 20, 1)
+			appendNumberDataPoint(m.Gauge().DataPoints(), "b", 0, 30, 3)
+			appendNumberDataPoint(m.Gauge().DataPoints(), "c", 0, 10, 7)
+			require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+			m = flushedMetric(t, p, sink)
+			require.Equal(t, 1, m.Gauge().DataPoints().Len())
+			dp := m.Gauge().DataPoints().At(0)
+			assert.Equal(t, tt.want, dp.IntVal())
+			assert.Equal(t, pcommon.Timestamp(30), dp.Timestamp())
+		})
+	}
+}
+
+func TestAggregateDeltaHistogram(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+
+	md, m := newTestMetric("latency", pmetric.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "a", 0, 10, []float64{1, 2}, []uint64{1, 2, 3}).SetMin(0.5)
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "b", 0, 10, []float64{1, 2}, []uint64{4, 5, 6})
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "c", 0, 10, []float64{1, 5}, []uint64{1, 1, 1})
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	m = flushedMetric(t, p, sink)
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(21), dp.Count())
+	assert.Equal(t, 21.0, dp.Sum())
+	assert.False(t, dp.HasMin(), "the min must be dropped when a data point doesn't have it")
+	assert.Equal(t, []float64{1, 2}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{5, 7, 9}, dp.BucketCounts().AsRaw(), "data points with other bounds must be dropped")
+}
+
+func TestAggregateCumulativeHistogram(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+
+	md, m := newTestMetric("latency", pmetric.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "a", 0, 10, []float64{1}, []uint64{1, 2})
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "a", 0, 20, []float64{1}, []uint64{2, 4})
+	appendHistogramDataPoint(m.Histogram().DataPoints(), "b", 0, 20, []float64{1}, []uint64{1, 1})
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	m = flushedMetric(t, p, sink)
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, []uint64{3, 5}, dp.BucketCounts().AsRaw())
+}
+
+func TestNotAggregatedMetricsArePassedThrough(t *testing.T) {
+	p, sink := newTestProcessor(t, GaugeAggregationLast)
+
+	md, m := newTestMetric("excluded", pmetric.MetricDataTypeGauge)
+	appendNumberDataPoint(m.Gauge().DataPoints(), "a", 0, 10, 1)
+	summary := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty()
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].MetricCount())
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, 2, dp.Attributes().Len(), "attributes of metrics which are not aggregated must not be removed")
+
+	sink.Reset()
+	require.NoError(t, p.flush(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
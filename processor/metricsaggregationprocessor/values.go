@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsaggregationprocessor"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+)
+
+// numberValue is the aggregated value of a gauge or sum series. Integer values are summed as
+// integers until a double value is added.
+type numberValue struct {
+	start     pcommon.Timestamp
+	timestamp pcommon.Timestamp
+	isInt     bool
+	intVal    int64
+	doubleVal float64
+}
+
+func newNumberValue(dp pmetric.NumberDataPoint) *numberValue {
+	return &numberValue{
+		start:     dp.StartTimestamp(),
+		timestamp: dp.Timestamp(),
+		isInt:     dp.ValueType() == pmetric.NumberDataPointValueTypeInt,
+		intVal:    dp.IntVal(),
+		doubleVal: dp.DoubleVal(),
+	}
+}
+
+func (v *numberValue) clone() *numberValue {
+	clone := *v
+	return &clone
+}
+
+func (v *numberValue) float() float64 {
+	if v.isInt {
+		return float64(v.intVal)
+	}
+	return v.doubleVal
+}
+
+func (v *numberValue) add(other *numberValue) {
+	v.start = minStartTimestamp(v.start, other.start)
+	v.timestamp = maxTimestamp(v.timestamp, other.timestamp)
+	if v.isInt && other.isInt {
+		v.intVal += other.intVal
+		return
+	}
+	v.doubleVal = v.float() + other.float()
+	v.isInt = false
+}
+
+func (v *numberValue) writeTo(attributes pcommon.Map, dp pmetric.NumberDataPoint) {
+	attributes.CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(v.start)
+	dp.SetTimestamp(v.timestamp)
+	if v.isInt {
+		dp.SetIntVal(v.intVal)
+	} else {
+		dp.SetDoubleVal(v.doubleVal)
+	}
+}
+
+// histogramValue is the aggregated value of a histogram series.
+type histogramValue struct {
+	start     pcommon.Timestamp
+	timestamp pcommon.Timestamp
+	summary   metricutils.HistogramSummary
+	buckets   metricutils.ExplicitBuckets
+}
+
+func newHistogramValue(dp pmetric.HistogramDataPoint) *histogramValue {
+	summary := metricutils.NewHistogramSummary(dp)
+	summary.HasSum = summary.HasSum && !math.IsNaN(summary.Sum)
+	return &histogramValue{
+		start:     dp.StartTimestamp(),
+		timestamp: dp.Timestamp(),
+		summary:   summary,
+		buckets:   metricutils.NewExplicitBuckets(dp),
+	}
+}
+
+func (h *histogramValue) clone() *histogramValue {
+	clone := *h
+	clone.buckets = h.buckets.Clone()
+	return &clone
+}
+
+// hasSameBuckets returns whether both values have the same bucket boundaries.
+func (h *histogramValue) hasSameBuckets(other *histogramValue) bool {
+	return h.buckets.HasSameBounds(other.buckets)
+}
+
+// add merges a value with the same bucket boundaries.
+func (h *histogramValue) add(other *histogramValue) {
+	h.start = minStartTimestamp(h.start, other.start)
+	h.timestamp = maxTimestamp(h.timestamp, other.timestamp)
+	h.summary.Merge(other.summary)
+	h.buckets.Merge(other.buckets)
+}
+
+func (h *histogramValue) writeTo(attributes pcommon.Map, dp pmetric.HistogramDataPoint) {
+	attributes.CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(h.start)
+	dp.SetTimestamp(h.timestamp)
+	h.summary.CopyToHistogram(dp)
+	h.buckets.CopyTo(dp)
+}
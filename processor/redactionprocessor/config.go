@@ -15,6 +15,9 @@
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
@@ -35,6 +38,21 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// BlockedValueDetectors is a list of built-in detectors of sensitive
+	// values. Values that they detect are masked like the blocked values.
+	// Possible values are `credit_card`, `email`, `ipv4`, `ipv6`, `jwt` and
+	// `api_key`.
+	BlockedValueDetectors []string `mapstructure:"blocked_value_detectors"`
+
+	// MaskingMode controls how blocked values are masked. With `mask`, the
+	// default, they are replaced with asterisks. With `hmac`, they are
+	// replaced with their hex encoded HMAC-SHA256 keyed with HMACKey, so
+	// that masked values can still be joined without exposing them.
+	MaskingMode string `mapstructure:"masking_mode"`
+
+	// HMACKey is the secret key of the `hmac` masking mode
+	HMACKey string `mapstructure:"hmac_key"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -42,3 +60,24 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for _, name := range cfg.BlockedValueDetectors {
+		if _, ok := valueDetectors[name]; !ok {
+			return fmt.Errorf("unknown blocked value detector %q", name)
+		}
+	}
+	switch cfg.MaskingMode {
+	case "", maskMode:
+	case hmacMode:
+		if cfg.HMACKey == "" {
+			return errors.New("hmac_key must be set with the hmac masking mode")
+		}
+	default:
+		return fmt.Errorf("unknown masking mode %q, must be %q or %q", cfg.MaskingMode, maskMode, hmacMode)
+	}
+	return nil
+}
@@ -51,3 +51,49 @@ func TestLoadConfigEmpty(t *testing.T) {
 	p0 := cfg.Processors[config.NewComponentID(typeStr)]
 	assert.Equal(t, p0, createDefaultConfig())
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "default",
+			config: &Config{},
+		},
+		{
+			name:   "detectors",
+			config: &Config{BlockedValueDetectors: []string{creditCardDetector, emailDetector, ipv4Detector, ipv6Detector, jwtDetector, apiKeyDetector}},
+		},
+		{
+			name:   "unknown detector",
+			config: &Config{BlockedValueDetectors: []string{"phone"}},
+			err:    `unknown blocked value detector "phone"`,
+		},
+		{
+			name:   "hmac",
+			config: &Config{MaskingMode: hmacMode, HMACKey: "key"},
+		},
+		{
+			name:   "hmac without key",
+			config: &Config{MaskingMode: hmacMode},
+			err:    "hmac_key must be set with the hmac masking mode",
+		},
+		{
+			name:   "unknown masking mode",
+			config: &Config{MaskingMode: "hash"},
+			err:    `unknown masking mode "hash", must be "mask" or "hmac"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
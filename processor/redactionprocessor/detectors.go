@@ -0,0 +1,124 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Names of the built-in detectors of sensitive values
+const (
+	creditCardDetector = "credit_card"
+	emailDetector      = "email"
+	ipv4Detector       = "ipv4"
+	ipv6Detector       = "ipv6"
+	jwtDetector        = "jwt"
+	apiKeyDetector     = "api_key"
+)
+
+// valueDetector finds sensitive values. The regular expression finds
+// candidates, which are only masked if they pass the validation, when set.
+// The validation receives the whole value and the bounds of the candidate,
+// so that it can check the characters around it
+type valueDetector struct {
+	re       *regexp.Regexp
+	validate func(value string, start, end int) bool
+}
+
+// valueDetectors holds the built-in detectors, by name
+var valueDetectors = map[string]valueDetector{
+	// 13 to 19 digits, optionally grouped with spaces or dashes
+	creditCardDetector: {
+		re:       regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		validate: isCreditCard,
+	},
+	emailDetector: {
+		re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	},
+	ipv4Detector: {
+		re: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+	},
+	// Candidates made of hexadecimal groups separated by colons, possibly ending
+	// with an embedded IPv4 address, are validated by the standard parser. The
+	// regular expression has no boundaries, which are checked by the validation
+	ipv6Detector: {
+		re:       regexp.MustCompile(`(?:[0-9A-Fa-f]{0,4}:){2,7}(?:(?:\d{1,3}\.){3}\d{1,3}|[0-9A-Fa-f]{0,4})`),
+		validate: isIPv6,
+	},
+	// Header and payload are base64url encoded JSON objects, starting with `{"`
+	jwtDetector: {
+		re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	// AWS access key IDs, GitHub tokens, Slack tokens, Stripe keys and Google API keys
+	apiKeyDetector: {
+		re: regexp.MustCompile(`\b(?:(?:AKIA|ASIA)[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36}|xox[abprs]-[A-Za-z0-9-]{10,}|[rs]k_(?:live|test)_[A-Za-z0-9]{16,}|AIza[0-9A-Za-z_-]{35})\b`),
+	},
+}
+
+func isCreditCard(value string, start, end int) bool {
+	return isLuhnValid(value[start:end])
+}
+
+// isLuhnValid checks the Luhn checksum of a card number, ignoring its separators
+func isLuhnValid(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isIPv6 checks that the candidate is a whole IPv6 address, and not a part of
+// a word or of a longer sequence of groups, like in "std::vector" or "a::b::c".
+// The standard parser only accepts addresses with 8 groups or a "::"
+func isIPv6(value string, start, end int) bool {
+	if start > 0 && isIPv6Adjacent(value[start-1]) {
+		return false
+	}
+	if end < len(value) {
+		next := value[end]
+		// A trailing dot is accepted at the end of a sentence, but not before a word
+		if next == '.' {
+			if end+1 < len(value) && isIPv6Adjacent(value[end+1]) {
+				return false
+			}
+		} else if isIPv6Adjacent(next) {
+			return false
+		}
+	}
+	candidate := value[start:end]
+	return strings.Contains(candidate, ":") && net.ParseIP(candidate) != nil
+}
+
+// isIPv6Adjacent returns whether the character can't be next to an IPv6 address
+func isIPv6Adjacent(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' ||
+		c == '_' || c == ':' || c == '.'
+}
@@ -16,6 +16,9 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"sort"
@@ -24,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -35,11 +40,14 @@ type redaction struct {
 	allowList map[string]string
 	// Attribute values blocked in a span
 	blockRegexList map[string]*regexp.Regexp
+	// Built-in detectors of blocked values
+	detectors []valueDetector
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
+	// Next trace consumer in line. Logs and metrics are passed on by the
+	// processor helper instead
 	next consumer.Traces
 }
 
@@ -52,9 +60,19 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
+	detectors := make([]valueDetector, 0, len(config.BlockedValueDetectors))
+	for _, name := range config.BlockedValueDetectors {
+		detector, ok := valueDetectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown blocked value detector %q", name)
+		}
+		detectors = append(detectors, detector)
+	}
+
 	return &redaction{
 		allowList:      allowList,
 		blockRegexList: blockRegexList,
+		detectors:      detectors,
 		config:         config,
 		logger:         logger,
 		next:           next,
@@ -91,12 +109,102 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
+// processLogs implements ProcessLogsFunc. It redacts the attributes of the
+// resources and log records, and masks the blocked values in string bodies
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		rlAttrs := rl.Resource().Attributes()
+		s.processAttrs(ctx, &rlAttrs)
+
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			records := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				record := records.At(k)
+				recordAttrs := record.Attributes()
+				s.processAttrs(ctx, &recordAttrs)
+
+				if record.Body().Type() == pcommon.ValueTypeString {
+					if masked, ok := s.maskString(record.Body().StringVal()); ok {
+						record.Body().SetStringVal(masked)
+					}
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It redacts the attributes of
+// the resources and data points. No summary is added to them, as it would
+// change the identity of the series
+func (s *redaction) processMetrics(_ context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		rmAttrs := rm.Resource().Attributes()
+		s.redactAttrs(&rmAttrs)
+
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			ms := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				s.processMetricDataPoints(ms.At(k))
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// processMetricDataPoints redacts the attributes of the data points of a metric
+func (s *redaction) processMetricDataPoints(metric pmetric.Metric) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(&attrs)
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(&attrs)
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(&attrs)
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(&attrs)
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs := dps.At(i).Attributes()
+			s.redactAttrs(&attrs)
+		}
+	}
+}
+
+// processAttrs redacts the attributes of a resource, a span or a log record,
+// and adds the summary of the changes to them
 func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 	// TODO: Use the context for recording metrics
-	var toDelete []string
-	var toBlock []string
+	toDelete, toBlock := s.redactAttrs(attributes)
 
+	// Add diagnostic information to the span
+	s.summarizeRedactedSpan(toDelete, attributes)
+	s.summarizeMaskedSpan(toBlock, attributes)
+}
+
+// redactAttrs deletes the attributes which aren't allowed and masks the
+// blocked values of the others. It returns the keys of the deleted and masked
+// attributes
+func (s *redaction) redactAttrs(attributes *pcommon.Map) (toDelete []string, toBlock []string) {
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
 	// 2. Mask any blocked values for the other attributes
@@ -116,15 +224,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(value.StringVal())
-			if match {
-				toBlock = append(toBlock, k)
-
-				valueCopy := value.StringVal()
-				maskedValue := compiledRE.ReplaceAllString(valueCopy, "****")
-				attributes.Update(k, pcommon.NewValueString(maskedValue))
-			}
+		if maskedValue, masked := s.maskString(value.StringVal()); masked {
+			toBlock = append(toBlock, k)
+			attributes.Update(k, pcommon.NewValueString(maskedValue))
 		}
 		return true
 	})
@@ -133,9 +235,58 @@ func (s *redaction) processAttrs(_ context.Context, attributes *pcommon.Map) {
 	for _, k := range toDelete {
 		attributes.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.summarizeRedactedSpan(toDelete, attributes)
-	s.summarizeMaskedSpan(toBlock, attributes)
+	return toDelete, toBlock
+}
+
+// maskString masks the parts of a value matching a blocked value regex or
+// detected by a detector. All the matches are found in the original value,
+// and overlapping matches are masked together, so that the masked value
+// doesn't depend on the order of the regexes. It returns false if nothing
+// was masked
+func (s *redaction) maskString(value string) (string, bool) {
+	var matches [][]int
+	for _, compiledRE := range s.blockRegexList {
+		matches = append(matches, compiledRE.FindAllStringIndex(value, -1)...)
+	}
+	for _, detector := range s.detectors {
+		for _, match := range detector.re.FindAllStringIndex(value, -1) {
+			if detector.validate == nil || detector.validate(value, match[0], match[1]) {
+				matches = append(matches, match)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return value, false
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i][0] < matches[j][0]
+	})
+	var masked strings.Builder
+	end := 0
+	for i := 0; i < len(matches); {
+		start, stop := matches[i][0], matches[i][1]
+		for i++; i < len(matches) && matches[i][0] < stop; i++ {
+			if matches[i][1] > stop {
+				stop = matches[i][1]
+			}
+		}
+		masked.WriteString(value[end:start])
+		masked.WriteString(s.mask(value[start:stop]))
+		end = stop
+	}
+	masked.WriteString(value[end:])
+	return masked.String(), true
+}
+
+// mask returns the replacement of a blocked value
+func (s *redaction) mask(value string) string {
+	if s.config.MaskingMode != hmacMode {
+		return "****"
+	}
+	mac := hmac.New(sha256.New, []byte(s.config.HMACKey))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
 // ConsumeTraces implements the SpanProcessor interface
@@ -184,6 +335,8 @@ func (s *redaction) summarizeMaskedSpan(toBlock []string, attributes *pcommon.Ma
 const (
 	debug            = "debug"
 	info             = "info"
+	maskMode         = "mask"
+	hmacMode         = "hmac"
 	redactedKeys     = "redaction.redacted.keys"
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
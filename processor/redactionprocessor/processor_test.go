@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
@@ -350,6 +352,147 @@ func TestMultipleBlockValues(t *testing.T) {
 	assert.Equal(t, "mystery ****", mysteryValue.StringVal())
 }
 
+// TestBlockedValueDetectors validates that the processor masks the values
+// found by the built-in detectors
+func TestBlockedValueDetectors(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:          true,
+		BlockedValueDetectors: []string{creditCardDetector, emailDetector, ipv4Detector},
+	}
+	allowed := map[string]pcommon.Value{
+		"order": pcommon.NewValueString("order 4111111111111112"),
+	}
+	masked := map[string]pcommon.Value{
+		"card":  pcommon.NewValueString("card 4111 1111 1111 1111"),
+		"email": pcommon.NewValueString("sent to jane.doe@example.com"),
+		"peer":  pcommon.NewValueString("10.0.0.1:8080"),
+	}
+
+	_, _, next := runTest(t, allowed, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	// The number fails the Luhn check
+	order, _ := attr.Get("order")
+	assert.Equal(t, "order 4111111111111112", order.StringVal())
+	card, _ := attr.Get("card")
+	assert.Equal(t, "card ****", card.StringVal())
+	email, _ := attr.Get("email")
+	assert.Equal(t, "sent to ****", email.StringVal())
+	peer, _ := attr.Get("peer")
+	assert.Equal(t, "****:8080", peer.StringVal())
+}
+
+// TestOverlappingBlockValues validates that overlapping matches of different
+// patterns are masked together
+func TestOverlappingBlockValues(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:          true,
+		BlockedValues:         []string{"secret-[a-z]+"},
+		BlockedValueDetectors: []string{emailDetector},
+	}
+	masked := map[string]pcommon.Value{
+		"name": pcommon.NewValueString("secret-admin@example.com and secret-user"),
+	}
+
+	_, _, next := runTest(t, nil, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	name, _ := attr.Get("name")
+	assert.Equal(t, "**** and ****", name.StringVal())
+}
+
+// TestHMACMaskingMode validates that the processor replaces blocked values
+// with their keyed hash, which is stable across values
+func TestHMACMaskingMode(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:          true,
+		BlockedValueDetectors: []string{emailDetector},
+		MaskingMode:           hmacMode,
+		HMACKey:               "key",
+	}
+	masked := map[string]pcommon.Value{
+		"from": pcommon.NewValueString("jane.doe@example.com"),
+		"to":   pcommon.NewValueString("to jane.doe@example.com"),
+	}
+
+	_, _, next := runTest(t, nil, nil, masked, config)
+
+	attr := next.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	from, _ := attr.Get("from")
+	assert.Equal(t, "e55f6828603d89f63c336b2b7e7e4d06369fbb57a8924d2709dd19c17c0aedf3", from.StringVal())
+	to, _ := attr.Get("to")
+	assert.Equal(t, "to "+from.StringVal(), to.StringVal())
+}
+
+// TestProcessLogs validates that the processor redacts the attributes of log
+// records and masks the blocked values in their bodies
+func TestProcessLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:           []string{"user"},
+		BlockedValueDetectors: []string{emailDetector},
+		Summary:               "info",
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), nil)
+	assert.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "host")
+	record := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Body().SetStringVal("login failed for jane.doe@example.com")
+	record.Attributes().InsertString("user", "jane.doe@example.com")
+	record.Attributes().InsertString("password", "hunter2")
+
+	logs, err = processor.processLogs(context.Background(), logs)
+	assert.NoError(t, err)
+
+	rl = logs.ResourceLogs().At(0)
+	_, ok := rl.Resource().Attributes().Get("host.name")
+	assert.False(t, ok)
+	record = rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "login failed for ****", record.Body().StringVal())
+	user, _ := record.Attributes().Get("user")
+	assert.Equal(t, "****", user.StringVal())
+	_, ok = record.Attributes().Get("password")
+	assert.False(t, ok)
+	count, ok := record.Attributes().Get(redactedKeyCount)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), count.IntVal())
+}
+
+// TestProcessMetrics validates that the processor redacts the attributes of
+// data points, without adding a summary to them
+func TestProcessMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:           []string{"client"},
+		BlockedValueDetectors: []string{ipv4Detector},
+		Summary:               "debug",
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), nil)
+	assert.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := ms.AppendEmpty()
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("client", "192.168.1.10")
+	dp.Attributes().InsertString("user", "jane")
+	histogram := ms.AppendEmpty()
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("user", "jane")
+
+	metrics, err = processor.processMetrics(context.Background(), metrics)
+	assert.NoError(t, err)
+
+	ms = metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	attrs := ms.At(0).Sum().DataPoints().At(0).Attributes()
+	assert.Equal(t, 1, attrs.Len())
+	client, _ := attrs.Get("client")
+	assert.Equal(t, "****", client.StringVal())
+	assert.Equal(t, 0, ms.At(1).Histogram().DataPoints().At(0).Attributes().Len())
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
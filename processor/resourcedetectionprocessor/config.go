@@ -15,12 +15,16 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -42,6 +46,9 @@ type Config struct {
 	// Attributes is an allowlist of attributes to add.
 	// If a supplied attribute is not a valid atrtibute of a supplied detector it will be ignored.
 	Attributes []string `mapstructure:"attributes"`
+	// RefreshInterval is the interval at which the detectors are re-run in the background
+	// to pick up changes of the resource information. Disabled by default (0).
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 }
 
 // DetectorConfig contains user-specified configurations unique to all individual detectors
@@ -54,6 +61,9 @@ type DetectorConfig struct {
 
 	// SystemConfig contains user-specified configurations for the System detector
 	SystemConfig system.Config `mapstructure:"system"`
+
+	// OpenShiftConfig contains user-specified configurations for the OpenShift detector
+	OpenShiftConfig openshift.Config `mapstructure:"openshift"`
 }
 
 func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
@@ -64,6 +74,8 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.ConsulConfig
 	case system.TypeStr:
 		return d.SystemConfig
+	case openshift.TypeStr:
+		return d.OpenShiftConfig
 	default:
 		return nil
 	}
@@ -71,5 +83,8 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 
 // Validate config
 func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return fmt.Errorf("refresh_interval must not be negative")
+	}
 	return cfg.DetectorConfig.SystemConfig.Validate()
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -50,6 +51,7 @@ func TestLoadConfig(t *testing.T) {
 		Detectors:          []string{"env", "gce"},
 		HTTPClientSettings: confighttp.HTTPClientSettings{Timeout: 2 * time.Second, MaxIdleConns: p2.MaxIdleConns, IdleConnTimeout: p2.IdleConnTimeout},
 		Override:           false,
+		RefreshInterval:    5 * time.Minute,
 	}
 	assert.Equal(t, p2, p2e)
 
@@ -95,6 +97,15 @@ func TestLoadInvalidConfig(t *testing.T) {
 	assert.NotNil(t, cfg)
 }
 
+func TestValidateRefreshInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RefreshInterval = time.Minute
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RefreshInterval = -time.Minute
+	assert.EqualError(t, cfg.Validate(), "refresh_interval must not be negative")
+}
+
 func TestGetConfigFromType(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -136,6 +147,20 @@ func TestGetConfigFromType(t *testing.T) {
 				HostnameSources: []string{"os"},
 			},
 		},
+		{
+			name:         "Get OpenShift Config",
+			detectorType: openshift.TypeStr,
+			inputDetectorConfig: DetectorConfig{
+				OpenShiftConfig: openshift.Config{
+					Address: "https://api.example.com:6443",
+					Token:   "token",
+				},
+			},
+			expectedConfig: openshift.Config{
+				Address: "https://api.example.com:6443",
+				Token:   "token",
+			},
+		},
 	}
 
 	for _, tt := range tests {
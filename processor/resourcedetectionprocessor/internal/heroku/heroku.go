@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heroku // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "heroku"
+
+	// The cloud provider is not defined by the semantic conventions yet.
+	cloudProviderHeroku = "heroku"
+
+	herokuAppID                    = "heroku.app.id"
+	herokuDynoName                 = "heroku.dyno.name"
+	herokuReleaseCommit            = "heroku.release.commit"
+	herokuReleaseCreationTimestamp = "heroku.release.creation_timestamp"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Heroku dyno metadata detector. It reads the environment variables set by
+// the Dyno Metadata feature, see https://devcenter.heroku.com/articles/dyno-metadata
+type Detector struct {
+	logger *zap.Logger
+}
+
+// NewDetector creates a new Heroku dyno metadata detector
+func NewDetector(p component.ProcessorCreateSettings, cfg internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{logger: p.Logger}, nil
+}
+
+// Detect detects the dyno metadata and returns a resource with the available ones
+func (d *Detector) Detect(context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+
+	dynoID, ok := os.LookupEnv("HEROKU_DYNO_ID")
+	if !ok {
+		d.logger.Debug("Heroku metadata unavailable, the dyno metadata feature is not enabled or not running on Heroku")
+		// return an empty Resource and no error
+		return res, "", nil
+	}
+
+	attrs := res.Attributes()
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderHeroku)
+	attrs.InsertString(conventions.AttributeServiceInstanceID, dynoID)
+	for attribute, envVar := range map[string]string{
+		conventions.AttributeServiceName:    "HEROKU_APP_NAME",
+		conventions.AttributeServiceVersion: "HEROKU_RELEASE_VERSION",
+		herokuAppID:                         "HEROKU_APP_ID",
+		herokuDynoName:                      "DYNO",
+		herokuReleaseCommit:                 "HEROKU_SLUG_COMMIT",
+		herokuReleaseCreationTimestamp:      "HEROKU_RELEASE_CREATED_AT",
+	} {
+		if value, exists := os.LookupEnv(envVar); exists {
+			attrs.InsertString(attribute, value)
+		}
+	}
+
+	return res, conventions.SchemaURL, nil
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+
+import (
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines user-specified configurations unique to the OpenShift detector
+type Config struct {
+	// Address is the address of the OpenShift API server.
+	// Defaults to the in-cluster address of the Kubernetes service.
+	Address string `mapstructure:"address"`
+
+	// Token is the bearer token used to query the API server.
+	// Defaults to the token of the service account of the pod.
+	Token string `mapstructure:"token"`
+
+	// TLSSettings contains TLS configurations that are specific to client
+	// connection used to communicate with the API server.
+	// Defaults to the certificate authority of the service account of the pod.
+	TLSSettings configtls.TLSClientSetting `mapstructure:"tls"`
+}
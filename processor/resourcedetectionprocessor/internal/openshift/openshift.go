@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "openshift"
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// The cloud providers and platforms which are not defined by the semantic conventions yet.
+	cloudProviderIBMCloud       = "ibm_cloud"
+	cloudProviderOpenStack      = "openstack"
+	cloudPlatformAWSOpenShift   = "aws_openshift"
+	cloudPlatformAzureOpenShift = "azure_openshift"
+	cloudPlatformGCPOpenShift   = "gcp_openshift"
+	cloudPlatformIBMOpenShift   = "ibm_cloud_openshift"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OpenShift cluster metadata detector
+type Detector struct {
+	provider openshift.Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new OpenShift cluster metadata detector
+func NewDetector(p component.ProcessorCreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	userCfg := dcfg.(Config)
+
+	if userCfg.Address == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		userCfg.Address = "https://" + net.JoinHostPort(host, port)
+	}
+	if userCfg.Token == "" {
+		// The token is missing when not running in a pod, in which case detection fails later on.
+		token, err := os.ReadFile(serviceAccountDir + "/token")
+		if err == nil {
+			userCfg.Token = strings.TrimSpace(string(token))
+		}
+	}
+	if userCfg.TLSSettings.CAFile == "" && !userCfg.TLSSettings.Insecure {
+		if _, err := os.Stat(serviceAccountDir + "/ca.crt"); err == nil {
+			userCfg.TLSSettings.CAFile = serviceAccountDir + "/ca.crt"
+		}
+	}
+
+	tlsCfg, err := userCfg.TLSSettings.LoadTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed loading OpenShift TLS configuration: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	return &Detector{
+		provider: openshift.NewProvider(userCfg.Address, userCfg.Token, &http.Client{Transport: transport}),
+		logger:   p.Logger,
+	}, nil
+}
+
+// Detect detects OpenShift cluster metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+	attrs := res.Attributes()
+
+	infra, err := d.provider.Infrastructure(ctx)
+	if err != nil {
+		d.logger.Debug("OpenShift detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, "", nil
+	}
+
+	attrs.InsertString(conventions.AttributeK8SClusterName, infra.Status.InfrastructureName)
+
+	platform := infra.Status.PlatformStatus
+	switch strings.ToLower(platform.Type) {
+	case "aws":
+		attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+		attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformAWSOpenShift)
+		if platform.AWS != nil {
+			attrs.InsertString(conventions.AttributeCloudRegion, platform.AWS.Region)
+		}
+	case "azure":
+		attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+		attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformAzureOpenShift)
+	case "gcp":
+		attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+		attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformGCPOpenShift)
+		if platform.GCP != nil {
+			attrs.InsertString(conventions.AttributeCloudRegion, platform.GCP.Region)
+		}
+	case "ibmcloud":
+		attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderIBMCloud)
+		attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformIBMOpenShift)
+		if platform.IBMCloud != nil {
+			attrs.InsertString(conventions.AttributeCloudRegion, platform.IBMCloud.Location)
+		}
+	case "openstack":
+		attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderOpenStack)
+	}
+
+	return res, conventions.SchemaURL, nil
+}
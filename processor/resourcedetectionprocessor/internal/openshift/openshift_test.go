@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func newTestDetector(t *testing.T, platformStatus string) internal.Detector {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"status": {"infrastructureName": "ocp-cluster", "platformStatus": %s}}`, platformStatus)
+	}))
+	t.Cleanup(ts.Close)
+
+	d, err := NewDetector(componenttest.NewNopProcessorCreateSettings(), Config{
+		Address:     ts.URL,
+		Token:       "token",
+		TLSSettings: configtls.TLSClientSetting{Insecure: true},
+	})
+	require.NoError(t, err)
+	return d
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name           string
+		platformStatus string
+		expected       map[string]interface{}
+	}{
+		{
+			name:           "aws",
+			platformStatus: `{"type": "AWS", "aws": {"region": "us-east-1"}}`,
+			expected: map[string]interface{}{
+				conventions.AttributeK8SClusterName: "ocp-cluster",
+				conventions.AttributeCloudProvider:  "aws",
+				conventions.AttributeCloudPlatform:  "aws_openshift",
+				conventions.AttributeCloudRegion:    "us-east-1",
+			},
+		},
+		{
+			name:           "ibm cloud",
+			platformStatus: `{"type": "IBMCloud", "ibmcloud": {"location": "eu-de"}}`,
+			expected: map[string]interface{}{
+				conventions.AttributeK8SClusterName: "ocp-cluster",
+				conventions.AttributeCloudProvider:  "ibm_cloud",
+				conventions.AttributeCloudPlatform:  "ibm_cloud_openshift",
+				conventions.AttributeCloudRegion:    "eu-de",
+			},
+		},
+		{
+			name:           "bare metal",
+			platformStatus: `{"type": "BareMetal"}`,
+			expected: map[string]interface{}{
+				conventions.AttributeK8SClusterName: "ocp-cluster",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, schemaURL, err := newTestDetector(t, tt.platformStatus).Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, conventions.SchemaURL, schemaURL)
+			assert.Equal(t, tt.expected, res.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestDetectError(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	d, err := NewDetector(componenttest.NewNopProcessorCreateSettings(), Config{
+		Address:     ts.URL,
+		Token:       "token",
+		TLSSettings: configtls.TLSClientSetting{Insecure: true},
+	})
+	require.NoError(t, err)
+	res, _, err := d.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
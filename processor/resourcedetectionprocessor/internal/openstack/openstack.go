@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "openstack"
+
+	// The cloud provider and platform are not defined by the semantic conventions yet.
+	cloudProviderOpenStack = "openstack"
+	cloudPlatformNova      = "openstack_nova"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OpenStack metadata detector
+type Detector struct {
+	provider openstack.Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new OpenStack metadata detector
+func NewDetector(p component.ProcessorCreateSettings, cfg internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{
+		provider: openstack.NewProvider(&http.Client{}),
+		logger:   p.Logger,
+	}, nil
+}
+
+// Detect detects OpenStack instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+	attrs := res.Attributes()
+
+	metadata, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OpenStack detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, "", nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderOpenStack)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformNova)
+	attrs.InsertString(conventions.AttributeCloudAccountID, metadata.ProjectID)
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, metadata.AvailabilityZone)
+	attrs.InsertString(conventions.AttributeHostID, metadata.UUID)
+	attrs.InsertString(conventions.AttributeHostName, metadata.Name)
+
+	return res, conventions.SchemaURL, nil
+}
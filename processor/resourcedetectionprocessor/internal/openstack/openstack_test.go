@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type fakeProvider struct {
+	metadata *openstack.Metadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*openstack.Metadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(componenttest.NewNopProcessorCreateSettings(), nil)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectOpenStackAvailable(t *testing.T) {
+	detector := &Detector{provider: &fakeProvider{metadata: &openstack.Metadata{
+		UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		Name:             "test",
+		Hostname:         "test.novalocal",
+		AvailabilityZone: "nova",
+		ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+	}}}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	res.Attributes().Sort()
+
+	expected := internal.NewResource(map[string]interface{}{
+		conventions.AttributeCloudProvider:         "openstack",
+		conventions.AttributeCloudPlatform:         "openstack_nova",
+		conventions.AttributeCloudAccountID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		conventions.AttributeCloudAvailabilityZone: "nova",
+		conventions.AttributeHostID:                "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		conventions.AttributeHostName:              "test",
+	})
+	expected.Attributes().Sort()
+
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{provider: &fakeProvider{err: errors.New("mock error")}, logger: zap.NewNop()}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -25,6 +25,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +55,7 @@ func NewProviderFactory(detectors map[DetectorType]DetectorFactory) *ResourcePro
 func (f *ResourceProviderFactory) CreateResourceProvider(
 	params component.ProcessorCreateSettings,
 	timeout time.Duration,
+	refreshInterval time.Duration,
 	attributes []string,
 	detectorConfigs ResourceDetectorConfig,
 	detectorTypes ...DetectorType) (*ResourceProvider, error) {
@@ -69,7 +71,7 @@ func (f *ResourceProviderFactory) CreateResourceProvider(
 		}
 	}
 
-	provider := NewResourceProvider(params.Logger, timeout, attributesToKeep, detectors...)
+	provider := NewResourceProvider(params.Logger, timeout, refreshInterval, attributesToKeep, detectors...)
 	return provider, nil
 }
 
@@ -95,10 +97,23 @@ func (f *ResourceProviderFactory) getDetectors(params component.ProcessorCreateS
 type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
+	refreshInterval  time.Duration
 	detectors        []Detector
-	detectedResource *resourceResult
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// lock protects detectedResource, which is swapped by the refresh loop.
+	lock             sync.RWMutex
+	detectedResource *resourceResult
+
+	// detected records which detectors have returned a non-empty resource. Several detectors
+	// log their failures and return an empty resource, which a refresh must not take as a result.
+	// It is only accessed by the initial detection and the refresh loop, which run sequentially.
+	detected []bool
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
 }
 
 type resourceResult struct {
@@ -107,39 +122,103 @@ type resourceResult struct {
 	err       error
 }
 
-func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
+func NewResourceProvider(logger *zap.Logger, timeout time.Duration, refreshInterval time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
 	return &ResourceProvider{
 		logger:           logger,
 		timeout:          timeout,
+		refreshInterval:  refreshInterval,
 		detectors:        detectors,
 		attributesToKeep: attributesToKeep,
+		stopCh:           make(chan struct{}),
+		detected:         make([]bool, len(detectors)),
 	}
 }
 
+// Get returns the detected resource. The detectors are run on the first call only; if a refresh
+// interval is configured, they are then re-run in the background until StopRefreshing is called.
 func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
 	p.once.Do(func() {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
 		defer cancel()
-		p.detectResource(ctx)
+		// Errors of individual detectors are logged, the initial detection never fails.
+		res, schemaURL, _ := p.detectResource(ctx)
+		p.setDetectedResource(&resourceResult{resource: res, schemaURL: schemaURL})
+
+		if p.refreshInterval > 0 {
+			p.wg.Add(1)
+			go p.refreshLoop(client)
+		}
 	})
 
+	p.lock.RLock()
+	defer p.lock.RUnlock()
 	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// StopRefreshing stops the background refresh of the detected resource, if any.
+// It is safe to call it several times.
+func (p *ResourceProvider) StopRefreshing() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	p.wg.Wait()
+}
+
+func (p *ResourceProvider) refreshLoop(client *http.Client) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.refresh(client)
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// refresh re-runs the detectors and swaps the detected resource. The last good value is kept
+// if any of the detectors fails, or returns an empty resource after having detected one.
+func (p *ResourceProvider) refresh(client *http.Client) {
+	ctx, cancel := context.WithTimeout(ContextWithClient(context.Background(), client), client.Timeout)
+	defer cancel()
 
+	res, schemaURL, err := p.detectResource(ctx)
+	if err != nil {
+		p.logger.Warn("failed to refresh resource information, keeping the previously detected resource", zap.Error(err))
+		return
+	}
+	p.setDetectedResource(&resourceResult{resource: res, schemaURL: schemaURL})
+}
+
+func (p *ResourceProvider) setDetectedResource(result *resourceResult) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.detectedResource = result
+}
+
+// detectResource runs all the detectors and merges the resources they return. The returned error
+// combines the errors of the detectors that failed; the resource merges those that succeeded.
+func (p *ResourceProvider) detectResource(ctx context.Context) (pcommon.Resource, string, error) {
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
+	var errs error
 
 	p.logger.Info("began detecting resource information")
 
-	for _, detector := range p.detectors {
+	for i, detector := range p.detectors {
 		r, schemaURL, err := detector.Detect(ctx)
+		if err == nil && r.Attributes().Len() == 0 && p.detected[i] {
+			err = fmt.Errorf("detector %T returned an empty resource", detector)
+		}
 		if err != nil {
 			p.logger.Warn("failed to detect resource", zap.Error(err))
+			errs = multierr.Append(errs, err)
 		} else {
+			p.detected[i] = p.detected[i] || r.Attributes().Len() > 0
 			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
 			MergeResource(res, r, false)
 		}
@@ -152,8 +231,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	return res, mergedSchemaURL, errs
 }
 
 func AttributesToMap(am pcommon.Map) map[string]interface{} {
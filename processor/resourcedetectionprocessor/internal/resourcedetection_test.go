@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,7 +111,7 @@ func TestDetect(t *testing.T) {
 			}
 
 			f := NewProviderFactory(mockDetectors)
-			p, err := f.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, tt.attributes, &mockDetectorConfig{}, mockDetectorTypes...)
+			p, err := f.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, 0, tt.attributes, &mockDetectorConfig{}, mockDetectorTypes...)
 			require.NoError(t, err)
 
 			got, _, err := p.Get(context.Background(), http.DefaultClient)
@@ -126,7 +127,7 @@ func TestDetect(t *testing.T) {
 func TestDetectResource_InvalidDetectorType(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{})
-	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, 0, nil, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("invalid detector key: %v", mockDetectorKey))
 }
 
@@ -137,7 +138,7 @@ func TestDetectResource_DetectoryFactoryError(t *testing.T) {
 			return nil, errors.New("creation failed")
 		},
 	})
-	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, 0, nil, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("failed creating detector type %q: %v", mockDetectorKey, "creation failed"))
 }
 
@@ -148,11 +149,134 @@ func TestDetectResource_Error(t *testing.T) {
 	md2 := &MockDetector{}
 	md2.On("Detect").Return(pcommon.NewResource(), errors.New("err1"))
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), time.Second, 0, nil, md1, md2)
 	_, _, err := p.Get(context.Background(), http.DefaultClient)
 	require.NoError(t, err)
 }
 
+// sequenceDetector returns the result matching the number of times Detect has been called,
+// repeating the last one once they are exhausted.
+type sequenceDetector struct {
+	calls   int32
+	results []func() (pcommon.Resource, error)
+}
+
+func (d *sequenceDetector) Detect(context.Context) (pcommon.Resource, string, error) {
+	call := int(atomic.AddInt32(&d.calls, 1)) - 1
+	if call >= len(d.results) {
+		call = len(d.results) - 1
+	}
+	res, err := d.results[call]()
+	return res, "", err
+}
+
+func (d *sequenceDetector) numCalls() int {
+	return int(atomic.LoadInt32(&d.calls))
+}
+
+func detectedAttributes(t *testing.T, p *ResourceProvider) map[string]interface{} {
+	res, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	return res.Attributes().AsRaw()
+}
+
+func TestDetectResource_Refresh(t *testing.T) {
+	d := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"a": "1"}), nil },
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"a": "2"}), nil },
+	}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, 10*time.Millisecond, nil, d)
+	defer p.StopRefreshing()
+
+	assert.Equal(t, map[string]interface{}{"a": "1"}, detectedAttributes(t, p))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string]interface{}{"a": "2"}, detectedAttributes(t, p))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDetectResource_RefreshKeepsLastGoodValue(t *testing.T) {
+	failing := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"a": "1"}), nil },
+		func() (pcommon.Resource, error) { return pcommon.NewResource(), errors.New("err1") },
+	}}
+	changing := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "1"}), nil },
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "2"}), nil },
+	}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, 10*time.Millisecond, nil, failing, changing)
+	defer p.StopRefreshing()
+
+	expected := map[string]interface{}{"a": "1", "b": "1"}
+	assert.Equal(t, expected, detectedAttributes(t, p))
+	assert.Eventually(t, func() bool {
+		return failing.numCalls() > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, detectedAttributes(t, p))
+}
+
+func TestDetectResource_RefreshKeepsLastGoodValueOnEmptyResource(t *testing.T) {
+	// Like the azure detector, which logs the failures of the metadata service.
+	failing := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"a": "1"}), nil },
+		func() (pcommon.Resource, error) { return pcommon.NewResource(), nil },
+	}}
+	changing := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "1"}), nil },
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "2"}), nil },
+	}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, 10*time.Millisecond, nil, failing, changing)
+	defer p.StopRefreshing()
+
+	expected := map[string]interface{}{"a": "1", "b": "1"}
+	assert.Equal(t, expected, detectedAttributes(t, p))
+	assert.Eventually(t, func() bool {
+		return failing.numCalls() > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, detectedAttributes(t, p))
+}
+
+func TestDetectResource_RefreshEmptyDetector(t *testing.T) {
+	// A detector which never detects anything, e.g. on another platform, does not block the refreshes.
+	empty := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return pcommon.NewResource(), nil },
+	}}
+	changing := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "1"}), nil },
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"b": "2"}), nil },
+	}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, 10*time.Millisecond, nil, empty, changing)
+	defer p.StopRefreshing()
+
+	assert.Equal(t, map[string]interface{}{"b": "1"}, detectedAttributes(t, p))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string]interface{}{"b": "2"}, detectedAttributes(t, p))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDetectResource_StopRefreshing(t *testing.T) {
+	d := &sequenceDetector{results: []func() (pcommon.Resource, error){
+		func() (pcommon.Resource, error) { return NewResource(map[string]interface{}{"a": "1"}), nil },
+	}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, 10*time.Millisecond, nil, d)
+	_ = detectedAttributes(t, p)
+	assert.Eventually(t, func() bool {
+		return d.numCalls() > 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.StopRefreshing()
+	calls := d.numCalls()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, d.numCalls())
+
+	// Stopping again is a no-op.
+	p.StopRefreshing()
+}
+
 func TestMergeResource(t *testing.T) {
 	for _, tt := range []struct {
 		name       string
@@ -219,7 +343,7 @@ func TestDetectResource_Parallel(t *testing.T) {
 	expectedResource := NewResource(map[string]interface{}{"a": "1", "b": "2", "c": "3"})
 	expectedResource.Attributes().Sort()
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2, md3)
+	p := NewResourceProvider(zap.NewNop(), time.Second, 0, nil, md1, md2, md3)
 
 	// call p.Get multiple times
 	wg := &sync.WaitGroup{}
@@ -0,0 +1,382 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor"
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// parseConditions parses the conditions of the routing table with the paths and enums
+// of the given data type. It does nothing when the routing table uses values.
+func (r *router) parseConditions(dataType config.DataType) error {
+	if !r.config.usesConditions() {
+		return nil
+	}
+
+	conditions := make([]string, len(r.config.Table))
+	for i, item := range r.config.Table {
+		conditions[i] = item.Condition
+	}
+
+	var err error
+	switch dataType {
+	case config.TracesDataType:
+		r.conditions, err = tql.ParseConditions(conditions, tqlotel.ConditionFunctions(), tqltraces.ParsePath, tqltraces.ParseEnum)
+	case config.MetricsDataType:
+		r.conditions, err = tql.ParseConditions(conditions, tqlotel.ConditionFunctions(), tqlmetrics.ParsePath, tqlmetrics.ParseEnum)
+	case config.LogsDataType:
+		r.conditions, err = tql.ParseConditions(conditions, tqlotel.ConditionFunctions(), tqllogs.ParsePath, tqllogs.ParseEnum)
+	default:
+		err = fmt.Errorf("unsupported data type %q", dataType)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid routing condition: %w", err)
+	}
+	return nil
+}
+
+// matchingRoutes returns the indexes of the routing table items whose conditions match
+// the given context, together with a key identifying this set of routes.
+func (r *router) matchingRoutes(ctx tql.TransformContext) (string, []int) {
+	var key []byte
+	var routes []int
+	for i, condition := range r.conditions {
+		if condition(ctx) {
+			key = strconv.AppendInt(append(key, ','), int64(i), 10)
+			routes = append(routes, i)
+		}
+	}
+	return string(key), routes
+}
+
+// tracesExportersForRoutes returns the traces exporters of the given routes, without
+// duplicates, or the default exporters when no route matched.
+func (r *router) tracesExportersForRoutes(routes []int) []component.TracesExporter {
+	if len(routes) == 0 {
+		return r.defaultTracesExporters
+	}
+
+	var exporters []component.TracesExporter
+	for _, i := range routes {
+	exporterLoop:
+		for _, exp := range r.tracesExporters[r.config.Table[i].key()] {
+			for _, added := range exporters {
+				if added == exp {
+					continue exporterLoop
+				}
+			}
+			exporters = append(exporters, exp)
+		}
+	}
+	return exporters
+}
+
+// metricsExportersForRoutes returns the metrics exporters of the given routes, without
+// duplicates, or the default exporters when no route matched.
+func (r *router) metricsExportersForRoutes(routes []int) []component.MetricsExporter {
+	if len(routes) == 0 {
+		return r.defaultMetricsExporters
+	}
+
+	var exporters []component.MetricsExporter
+	for _, i := range routes {
+	exporterLoop:
+		for _, exp := range r.metricsExporters[r.config.Table[i].key()] {
+			for _, added := range exporters {
+				if added == exp {
+					continue exporterLoop
+				}
+			}
+			exporters = append(exporters, exp)
+		}
+	}
+	return exporters
+}
+
+// logsExportersForRoutes returns the logs exporters of the given routes, without
+// duplicates, or the default exporters when no route matched.
+func (r *router) logsExportersForRoutes(routes []int) []component.LogsExporter {
+	if len(routes) == 0 {
+		return r.defaultLogsExporters
+	}
+
+	var exporters []component.LogsExporter
+	for _, i := range routes {
+	exporterLoop:
+		for _, exp := range r.logsExporters[r.config.Table[i].key()] {
+			for _, added := range exporters {
+				if added == exp {
+					continue exporterLoop
+				}
+			}
+			exporters = append(exporters, exp)
+		}
+	}
+	return exporters
+}
+
+// conditionTracesGroup collects the spans matching the same set of routes. The resource
+// and scope of the spans are copied the first time a span of them is added to the group.
+type conditionTracesGroup struct {
+	routed        routedTraces
+	resourceSpans ptrace.ResourceSpans
+	scopeSpans    ptrace.ScopeSpans
+	resourceIndex int
+	scopeIndex    int
+}
+
+// routeTracesForConditions splits the traces by the set of routes matched by each span.
+// Spans matching several routes are sent once to every exporter of these routes, and
+// spans matching no route are sent to the default exporters.
+func (r *router) routeTracesForConditions(tr ptrace.Traces) []routedTraces {
+	groups := map[string]*conditionTracesGroup{}
+	var ordered []*conditionTracesGroup
+
+	resSpansSlice := tr.ResourceSpans()
+	for i := 0; i < resSpansSlice.Len(); i++ {
+		resSpans := resSpansSlice.At(i)
+		scopeSpansSlice := resSpans.ScopeSpans()
+		for j := 0; j < scopeSpansSlice.Len(); j++ {
+			scopeSpans := scopeSpansSlice.At(j)
+			spans := scopeSpans.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key, routes := r.matchingRoutes(tqltraces.SpanTransformContext{
+					Span:                 span,
+					InstrumentationScope: scopeSpans.Scope(),
+					Resource:             resSpans.Resource(),
+				})
+
+				group, ok := groups[key]
+				if !ok {
+					group = &conditionTracesGroup{
+						routed: routedTraces{
+							traces:    ptrace.NewTraces(),
+							exporters: r.tracesExportersForRoutes(routes),
+						},
+						resourceIndex: -1,
+					}
+					groups[key] = group
+					ordered = append(ordered, group)
+				}
+
+				if group.resourceIndex != i {
+					group.resourceSpans = group.routed.traces.ResourceSpans().AppendEmpty()
+					resSpans.Resource().CopyTo(group.resourceSpans.Resource())
+					group.resourceSpans.SetSchemaUrl(resSpans.SchemaUrl())
+					group.resourceIndex = i
+					group.scopeIndex = -1
+				}
+				if group.scopeIndex != j {
+					group.scopeSpans = group.resourceSpans.ScopeSpans().AppendEmpty()
+					scopeSpans.Scope().CopyTo(group.scopeSpans.Scope())
+					group.scopeSpans.SetSchemaUrl(scopeSpans.SchemaUrl())
+					group.scopeIndex = j
+				}
+				span.CopyTo(group.scopeSpans.Spans().AppendEmpty())
+			}
+		}
+	}
+
+	ret := make([]routedTraces, 0, len(ordered))
+	for _, group := range ordered {
+		ret = append(ret, group.routed)
+	}
+	return ret
+}
+
+// conditionLogsGroup collects the log records matching the same set of routes. The resource
+// and scope of the log records are copied the first time a log record of them is added to the group.
+type conditionLogsGroup struct {
+	routed        routedLogs
+	resourceLogs  plog.ResourceLogs
+	scopeLogs     plog.ScopeLogs
+	resourceIndex int
+	scopeIndex    int
+}
+
+// routeLogsForConditions splits the logs by the set of routes matched by each log record.
+// Log records matching several routes are sent once to every exporter of these routes, and
+// log records matching no route are sent to the default exporters.
+func (r *router) routeLogsForConditions(tl plog.Logs) []routedLogs {
+	groups := map[string]*conditionLogsGroup{}
+	var ordered []*conditionLogsGroup
+
+	resLogsSlice := tl.ResourceLogs()
+	for i := 0; i < resLogsSlice.Len(); i++ {
+		resLogs := resLogsSlice.At(i)
+		scopeLogsSlice := resLogs.ScopeLogs()
+		for j := 0; j < scopeLogsSlice.Len(); j++ {
+			scopeLogs := scopeLogsSlice.At(j)
+			logRecords := scopeLogs.LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				logRecord := logRecords.At(k)
+				key, routes := r.matchingRoutes(tqllogs.LogTransformContext{
+					Log:                  logRecord,
+					InstrumentationScope: scopeLogs.Scope(),
+					Resource:             resLogs.Resource(),
+				})
+
+				group, ok := groups[key]
+				if !ok {
+					group = &conditionLogsGroup{
+						routed: routedLogs{
+							logs:      plog.NewLogs(),
+							exporters: r.logsExportersForRoutes(routes),
+						},
+						resourceIndex: -1,
+					}
+					groups[key] = group
+					ordered = append(ordered, group)
+				}
+
+				if group.resourceIndex != i {
+					group.resourceLogs = group.routed.logs.ResourceLogs().AppendEmpty()
+					resLogs.Resource().CopyTo(group.resourceLogs.Resource())
+					group.resourceLogs.SetSchemaUrl(resLogs.SchemaUrl())
+					group.resourceIndex = i
+					group.scopeIndex = -1
+				}
+				if group.scopeIndex != j {
+					group.scopeLogs = group.resourceLogs.ScopeLogs().AppendEmpty()
+					scopeLogs.Scope().CopyTo(group.scopeLogs.Scope())
+					group.scopeLogs.SetSchemaUrl(scopeLogs.SchemaUrl())
+					group.scopeIndex = j
+				}
+				logRecord.CopyTo(group.scopeLogs.LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	ret := make([]routedLogs, 0, len(ordered))
+	for _, group := range ordered {
+		ret = append(ret, group.routed)
+	}
+	return ret
+}
+
+// conditionMetricsGroup collects the data points matching the same set of routes. The resource,
+// scope and metric of the data points are copied the first time a data point of them is added
+// to the group.
+type conditionMetricsGroup struct {
+	routed          routedMetrics
+	resourceMetrics pmetric.ResourceMetrics
+	scopeMetrics    pmetric.ScopeMetrics
+	metric          pmetric.Metric
+	resourceIndex   int
+	scopeIndex      int
+	metricIndex     int
+}
+
+// routeMetricsForConditions splits the metrics by the set of routes matched by each data point.
+// Data points matching several routes are sent once to every exporter of these routes, and
+// data points matching no route, as well as metrics without data points, are sent to the
+// default exporters.
+func (r *router) routeMetricsForConditions(tm pmetric.Metrics) []routedMetrics {
+	groups := map[string]*conditionMetricsGroup{}
+	var ordered []*conditionMetricsGroup
+
+	resMetricsSlice := tm.ResourceMetrics()
+	for i := 0; i < resMetricsSlice.Len(); i++ {
+		resMetrics := resMetricsSlice.At(i)
+		scopeMetricsSlice := resMetrics.ScopeMetrics()
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			scopeMetrics := scopeMetricsSlice.At(j)
+			metrics := scopeMetrics.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+
+				// add appends the data point at index l of the metric to the group
+				// of its routes, or the metric itself when it has no data points
+				add := func(dataPoint interface{}, l int) {
+					key, routes := "", []int(nil)
+					if dataPoint != nil {
+						key, routes = r.matchingRoutes(tqlmetrics.MetricTransformContext{
+							DataPoint:            dataPoint,
+							Metric:               metric,
+							Metrics:              metrics,
+							InstrumentationScope: scopeMetrics.Scope(),
+							Resource:             resMetrics.Resource(),
+						})
+					}
+
+					group, ok := groups[key]
+					if !ok {
+						group = &conditionMetricsGroup{
+							routed: routedMetrics{
+								metrics:   pmetric.NewMetrics(),
+								exporters: r.metricsExportersForRoutes(routes),
+							},
+							resourceIndex: -1,
+						}
+						groups[key] = group
+						ordered = append(ordered, group)
+					}
+
+					if group.resourceIndex != i {
+						group.resourceMetrics = group.routed.metrics.ResourceMetrics().AppendEmpty()
+						resMetrics.Resource().CopyTo(group.resourceMetrics.Resource())
+						group.resourceMetrics.SetSchemaUrl(resMetrics.SchemaUrl())
+						group.resourceIndex = i
+						group.scopeIndex = -1
+					}
+					if group.scopeIndex != j {
+						group.scopeMetrics = group.resourceMetrics.ScopeMetrics().AppendEmpty()
+						scopeMetrics.Scope().CopyTo(group.scopeMetrics.Scope())
+						group.scopeMetrics.SetSchemaUrl(scopeMetrics.SchemaUrl())
+						group.scopeIndex = j
+						group.metricIndex = -1
+					}
+					if group.metricIndex != k {
+						group.metric = group.scopeMetrics.Metrics().AppendEmpty()
+						metricutils.CopyMetricDescriptor(metric, group.metric)
+						group.metricIndex = k
+					}
+					if dataPoint != nil {
+						metricutils.CopyDataPoint(metric, l, group.metric)
+					}
+				}
+
+				dataPoints := metricutils.DataPointCount(metric)
+				for l := 0; l < dataPoints; l++ {
+					add(metricutils.DataPointAt(metric, l), l)
+				}
+				if dataPoints == 0 {
+					add(nil, 0)
+				}
+			}
+		}
+	}
+
+	ret := make([]routedMetrics, 0, len(ordered))
+	for _, group := range ordered {
+		ret = append(ret, group.routed)
+	}
+	return ret
+}
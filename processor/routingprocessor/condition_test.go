@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newConditionsHost(dataType config.DataType, exporters map[string]component.Exporter) component.Host {
+	return &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			available := map[config.ComponentID]component.Exporter{}
+			for name, exp := range exporters {
+				id, err := config.NewComponentIDFromString(name)
+				if err != nil {
+					panic(err)
+				}
+				available[id] = exp
+			}
+			return map[config.DataType]map[config.ComponentID]component.Exporter{dataType: available}
+		},
+	}
+}
+
+func TestLogs_RoutingWorks_Conditions(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	errorExp := &mockLogsExporter{}
+	acmeExp := &mockLogsExporter{}
+
+	exp, err := newProcessorForDataType(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Condition: `severity_number >= SEVERITY_NUMBER_ERROR`,
+				Exporters: []string{"otlp/errors"},
+			},
+			{
+				Condition: `resource.attributes["X-Tenant"] == "acme"`,
+				Exporters: []string{"otlp/acme", "otlp/errors"},
+			},
+		},
+	}, config.LogsDataType)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newConditionsHost(config.LogsDataType, map[string]component.Exporter{
+		"otlp":        defaultExp,
+		"otlp/errors": errorExp,
+		"otlp/acme":   acmeExp,
+	})))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("X-Tenant", "acme")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	logs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberINFO)
+	logs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberERROR)
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("X-Tenant", "globex")
+	logs = rl.ScopeLogs().AppendEmpty().LogRecords()
+	logs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberINFO)
+	logs.AppendEmpty().SetSeverityNumber(plog.SeverityNumberFATAL)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	require.Len(t, defaultExp.AllLogs(), 1)
+	assert.Equal(t, 1, defaultExp.LogRecordCount(), "only the info log of globex should be routed to the default exporter")
+	tenant, _ := defaultExp.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("X-Tenant")
+	assert.Equal(t, "globex", tenant.StringVal())
+
+	assert.Equal(t, 2, acmeExp.LogRecordCount(), "both logs of acme should be routed to the acme exporter")
+
+	// the acme error log matches both routes, but should only be exported once
+	assert.Equal(t, 3, errorExp.LogRecordCount(), "all logs of acme and the globex error should be routed to the errors exporter")
+}
+
+func TestTraces_RoutingWorks_Conditions(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	dbExp := &mockTracesExporter{}
+
+	exp, err := newProcessorForDataType(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Condition: `attributes["db.system"] != nil`,
+				Exporters: []string{"otlp/db"},
+			},
+		},
+	}, config.TracesDataType)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newConditionsHost(config.TracesDataType, map[string]component.Exporter{
+		"otlp":    defaultExp,
+		"otlp/db": dbExp,
+	})))
+
+	tr := ptrace.NewTraces()
+	rs := tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+	ss.Spans().AppendEmpty().SetName("handler")
+	db := ss.Spans().AppendEmpty()
+	db.SetName("query")
+	db.Attributes().InsertString("db.system", "redis")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	require.Len(t, defaultExp.AllTraces(), 1)
+	require.Len(t, dbExp.AllTraces(), 1)
+	for name, traces := range map[string]ptrace.Traces{"handler": defaultExp.AllTraces()[0], "query": dbExp.AllTraces()[0]} {
+		require.Equal(t, 1, traces.SpanCount())
+		rs := traces.ResourceSpans().At(0)
+		service, _ := rs.Resource().Attributes().Get("service.name")
+		assert.Equal(t, "checkout", service.StringVal())
+		assert.Equal(t, "scope", rs.ScopeSpans().At(0).Scope().Name())
+		assert.Equal(t, name, rs.ScopeSpans().At(0).Spans().At(0).Name())
+	}
+	assert.Equal(t, 2, tr.SpanCount(), "the incoming traces should not be modified")
+}
+
+func TestMetrics_RoutingWorks_Conditions(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	acmeExp := &mockMetricsExporter{}
+
+	exp, err := newProcessorForDataType(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Condition: `attributes["tenant"] == "acme"`,
+				Exporters: []string{"otlp/acme"},
+			},
+		},
+	}, config.MetricsDataType)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newConditionsHost(config.MetricsDataType, map[string]component.Exporter{
+		"otlp":      defaultExp,
+		"otlp/acme": acmeExp,
+	})))
+
+	m := pmetric.NewMetrics()
+	metrics := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("tenant", "acme")
+	dp.SetIntVal(1)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("tenant", "globex")
+	dp.SetIntVal(2)
+	empty := metrics.AppendEmpty()
+	empty.SetName("empty")
+	empty.SetDataType(pmetric.MetricDataTypeGauge)
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, acmeExp.AllMetrics(), 1)
+	acmeMetrics := acmeExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, acmeMetrics.Len())
+	assert.Equal(t, "requests", acmeMetrics.At(0).Name())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, acmeMetrics.At(0).Sum().AggregationTemporality())
+	assert.True(t, acmeMetrics.At(0).Sum().IsMonotonic())
+	require.Equal(t, 1, acmeMetrics.At(0).Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), acmeMetrics.At(0).Sum().DataPoints().At(0).IntVal())
+
+	require.Len(t, defaultExp.AllMetrics(), 1)
+	defaultMetrics := defaultExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, defaultMetrics.Len(), "metrics without data points should be routed to the default exporter")
+	require.Equal(t, 1, defaultMetrics.At(0).Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), defaultMetrics.At(0).Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, "empty", defaultMetrics.At(1).Name())
+}
+
+func TestProcessorFailsToBeCreatedWithInvalidCondition(t *testing.T) {
+	cfg := &Config{
+		Table: []RoutingTableItem{
+			{
+				Condition: `severity_number >= SEVERITY_NUMBER_ERROR`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	_, err := newProcessorForDataType(zap.NewNop(), cfg, config.LogsDataType)
+	assert.NoError(t, err)
+
+	_, err = newProcessorForDataType(zap.NewNop(), cfg, config.TracesDataType)
+	assert.ErrorContains(t, err, "invalid routing condition", "severity is not available for spans")
+}
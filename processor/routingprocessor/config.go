@@ -42,7 +42,7 @@ type Config struct {
 	// this could be the HTTP/gRPC header from the original request/RPC. Typically, aggregation processors (batch, groupbytrace)
 	// will create a new context, so, those should be avoided when using this processor.Although the HTTP spec allows headers to be repeated,
 	// this processor will only use the first value.
-	// Required.
+	// Required when the routing table contains values.
 	FromAttribute string `mapstructure:"from_attribute"`
 
 	// DropRoutingResourceAttribute controls whether to remove the resource attribute used for routing.
@@ -57,15 +57,24 @@ type Config struct {
 
 // Validate checks if the processor configuration is valid.
 func (c *Config) Validate() error {
-	// validate that every route has a value for the routing attribute and has
-	// at least one exporter
+	// validate that every route has either a value for the routing attribute
+	// or a condition, and has at least one exporter
+	conditions := 0
 	for _, item := range c.Table {
-		if len(item.Value) == 0 {
+		if len(item.Value) == 0 && len(item.Condition) == 0 {
 			return fmt.Errorf("invalid (empty) route : %w", errEmptyRoute)
 		}
 
+		if len(item.Value) > 0 && len(item.Condition) > 0 {
+			return fmt.Errorf("invalid route %s: %w", item.Value, errValueAndCondition)
+		}
+
 		if len(item.Exporters) == 0 {
-			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
+			return fmt.Errorf("invalid route %s: %w", item.key(), errNoExporters)
+		}
+
+		if len(item.Condition) > 0 {
+			conditions++
 		}
 	}
 
@@ -74,6 +83,15 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid routing table: %w", errNoTableItems)
 	}
 
+	// routes with conditions select the records themselves, values and
+	// conditions can't be used in the same table
+	if conditions > 0 {
+		if conditions < len(c.Table) {
+			return fmt.Errorf("invalid routing table: %w", errMixedRoutes)
+		}
+		return nil
+	}
+
 	// we also need a "FromAttribute" value
 	if len(c.FromAttribute) == 0 {
 		return fmt.Errorf(
@@ -89,6 +107,11 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// usesConditions returns whether the routes of the table are selected by conditions.
+func (c *Config) usesConditions() bool {
+	return len(c.Table) > 0 && len(c.Table[0].Condition) > 0
+}
+
 type AttributeSource string
 
 const (
@@ -100,12 +123,27 @@ const (
 
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
-	// Value represents a possible value for the field specified under FromAttribute. Required.
+	// Value represents a possible value for the field specified under FromAttribute.
+	// Either Value or Condition is required.
 	Value string `mapstructure:"value"`
 
-	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
+	// Condition is a boolean expression in the telemetry query language, evaluated for each span,
+	// log record or metric data point, e.g. `severity_number >= SEVERITY_NUMBER_ERROR`.
+	// The records matching the condition are routed to the exporters of this table item.
+	// Either Value or Condition is required.
+	Condition string `mapstructure:"condition"`
+
+	// Exporters contains the list of exporters to use when the value from the FromAttribute field or the condition matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
 	// Optional.
 	Exporters []string `mapstructure:"exporters"`
 }
+
+// key returns the key under which the exporters of the item are registered.
+func (item RoutingTableItem) key() string {
+	if len(item.Condition) > 0 {
+		return item.Condition
+	}
+	return item.Value
+}
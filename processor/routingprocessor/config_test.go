@@ -109,6 +109,29 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config_conditions.yaml",
+			factoriesFunc: func(factories component.Factories) component.Factories {
+				// we don't need to use it in this test, but the config has them
+				factories.Exporters["logging"] = loggingexporter.NewFactory()
+				return factories
+			},
+			expectedConfig: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				DefaultExporters:  []string{"logging/default"},
+				AttributeSource:   "context",
+				Table: []RoutingTableItem{
+					{
+						Condition: "severity_number >= SEVERITY_NUMBER_ERROR",
+						Exporters: []string{"logging/errors"},
+					},
+					{
+						Condition: `resource.attributes["tenant"] == "acme"`,
+						Exporters: []string{"logging/acme"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
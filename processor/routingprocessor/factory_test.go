@@ -115,6 +115,41 @@ func TestProcessorFailsWithNoFromAttribute(t *testing.T) {
 	assert.ErrorIs(t, cfg.Validate(), errNoMissingFromAttribute)
 }
 
+func TestProcessorFailsWithValueAndCondition(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		FromAttribute:     "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Condition: `resource.attributes["X-Tenant"] == "acme"`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errValueAndCondition)
+}
+
+func TestProcessorFailsWithMixedValuesAndConditions(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		FromAttribute:     "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+			{
+				Condition: `resource.attributes["X-Tenant"] == "globex"`,
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errMixedRoutes)
+}
+
 func TestShouldNotFailWhenNextIsProcessor(t *testing.T) {
 	// prepare
 	factory := NewFactory()
@@ -29,6 +29,8 @@ import (
 
 var (
 	errEmptyRoute                   = errors.New("empty routing attribute provided")
+	errValueAndCondition            = errors.New("both a value and a condition defined for the route")
+	errMixedRoutes                  = errors.New("routes with values and routes with conditions can't be mixed")
 	errNoExporters                  = errors.New("no exporters defined for the route")
 	errNoTableItems                 = errors.New("the routing table is empty")
 	errNoMissingFromAttribute       = errors.New("the FromAttribute property is empty")
@@ -60,6 +62,16 @@ func newProcessor(logger *zap.Logger, cfg config.Processor) *processorImp {
 	}
 }
 
+// newProcessorForDataType creates a new processor for the given data type, parsing the
+// conditions of the routing table with the paths available for this data type.
+func newProcessorForDataType(logger *zap.Logger, cfg config.Processor, dataType config.DataType) (*processorImp, error) {
+	p := newProcessor(logger, cfg)
+	if err := p.router.parseConditions(dataType); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func (e *processorImp) Start(_ context.Context, host component.Host) error {
 	return e.router.registerExporters(host.GetExporters())
 }
@@ -26,10 +26,12 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 // router routes logs, metrics and traces using the configured attributes and
-// attribute sources.
+// attribute sources, or the conditions of the routing table.
 // Upon routing it also groups the logs, metrics and spans into a joint upper level
 // structure (plog.Logs, pmetric.Metrics and ptrace.Traces respectively) in order
 // to not cause higher CPU usage in the exporters when exproting data (it's always
@@ -39,6 +41,10 @@ type router struct {
 	logger    *zap.Logger
 	extractor extractor
 
+	// conditions are the parsed conditions of the routing table items, in the same order,
+	// when the table uses conditions.
+	conditions []tql.BoolExpressionEvaluator
+
 	defaultLogsExporters    []component.LogsExporter
 	logsExporters           map[string][]component.LogsExporter
 	defaultMetricsExporters []component.MetricsExporter
@@ -64,6 +70,10 @@ type routedMetrics struct {
 }
 
 func (r *router) RouteMetrics(ctx context.Context, tm pmetric.Metrics) []routedMetrics {
+	if r.config.usesConditions() {
+		return r.routeMetricsForConditions(tm)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeMetricsForResource(ctx, tm)
@@ -156,6 +166,10 @@ type routedTraces struct {
 }
 
 func (r *router) RouteTraces(ctx context.Context, tr ptrace.Traces) []routedTraces {
+	if r.config.usesConditions() {
+		return r.routeTracesForConditions(tr)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeTracesForResource(ctx, tr)
@@ -244,6 +258,10 @@ type routedLogs struct {
 }
 
 func (r *router) RouteLogs(ctx context.Context, tl plog.Logs) []routedLogs {
+	if r.config.usesConditions() {
+		return r.routeLogsForConditions(tl)
+	}
+
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeLogsForResource(ctx, tl)
@@ -435,9 +453,9 @@ func (r *router) registerExportersForRoutes(available ExporterMap) error {
 		return err
 	}
 
-	// exporters for each defined value
+	// exporters for each defined value or condition
 	for _, item := range r.config.Table {
-		if err := r.registerExportersForRoute(item.Value, available, item.Exporters); err != nil {
+		if err := r.registerExportersForRoute(item.key(), available, item.Exporters); err != nil {
 			return err
 		}
 	}
@@ -44,6 +44,12 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// CacheDirectory is a local directory where the
+	// downloaded schema files are kept, so that they
+	// are only fetched once across restarts of the
+	// collector. (Optional field)
+	CacheDirectory string `mapstructure:"cache_directory"`
 }
 
 func (c *Config) Validate() error {
@@ -0,0 +1,224 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+	"go.uber.org/zap"
+)
+
+const (
+	// The time during which a schema file that could not be retrieved is not retrieved again,
+	// which doubles after every consecutive failure, up to the maximum.
+	initialRetryInterval = 5 * time.Second
+	maxRetryInterval     = 5 * time.Minute
+)
+
+// Manager resolves the translation of signals published with a schema URL
+// to the target version of their schema family. The schema files are
+// retrieved from the provider when first needed, and kept in memory.
+// The failures are kept as well, so that a schema file is not retrieved
+// for every batch of signals while its retrieval fails.
+type Manager struct {
+	log      *zap.Logger
+	provider Provider
+
+	// targets holds the target schema URL and version of each family
+	targets map[string]target
+
+	initialRetryInterval time.Duration
+	maxRetryInterval     time.Duration
+
+	// lock protects the maps, but is not held while the schema files are retrieved
+	lock         sync.Mutex
+	schemas      map[string]*schemaEntry
+	translations map[string]*Translation
+}
+
+// schemaEntry holds the result of the retrieval of a schema file.
+type schemaEntry struct {
+	// done is closed once the retrieval ended. The other fields must not be read before.
+	done   chan struct{}
+	schema *ast.Schema
+	err    error
+	// failures is the number of consecutive failed retrievals, and retryAt
+	// the time before which the schema file must not be retrieved again.
+	failures int
+	retryAt  time.Time
+}
+
+// retryable returns whether the retrieval failed, and can be attempted again.
+func (e *schemaEntry) retryable(now time.Time) bool {
+	select {
+	case <-e.done:
+		return e.err != nil && !now.Before(e.retryAt)
+	default:
+		return false
+	}
+}
+
+type target struct {
+	schemaURL string
+	version   *Version
+}
+
+// NewManager returns a manager translating signals to the given target schema URLs
+func NewManager(targets []string, provider Provider, log *zap.Logger) (*Manager, error) {
+	m := &Manager{
+		log:                  log,
+		provider:             provider,
+		targets:              make(map[string]target, len(targets)),
+		initialRetryInterval: initialRetryInterval,
+		maxRetryInterval:     maxRetryInterval,
+		schemas:              make(map[string]*schemaEntry),
+		translations:         make(map[string]*Translation),
+	}
+	for _, schemaURL := range targets {
+		family, version, err := GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = target{schemaURL: schemaURL, version: version}
+	}
+	return m, nil
+}
+
+// Prefetch retrieves the schema file published at the schema URL,
+// so that signals don't wait for it later on.
+func (m *Manager) Prefetch(ctx context.Context, schemaURL string) error {
+	_, err := m.schema(ctx, schemaURL)
+	return err
+}
+
+// RequestTranslation returns the translation of signals published with the schema URL.
+// It returns nil if the schema URL doesn't belong to a targeted family, in which case
+// signals are left unchanged.
+func (m *Manager) RequestTranslation(ctx context.Context, schemaURL string) (*Translation, error) {
+	if schemaURL == "" {
+		return nil, nil
+	}
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		m.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, nil
+	}
+	tgt, ok := m.targets[family]
+	if !ok {
+		return nil, nil
+	}
+
+	m.lock.Lock()
+	t, exist := m.translations[schemaURL]
+	m.lock.Unlock()
+	if exist {
+		return t, nil
+	}
+
+	if version.Equal(tgt.version) {
+		t = &Translation{targetURL: tgt.schemaURL}
+	} else {
+		// The schema file of a version defines the changes of all the previous
+		// versions, so that the file of the newest one is enough.
+		fileURL := tgt.schemaURL
+		if version.GreaterThan(tgt.version) {
+			fileURL = schemaURL
+		}
+		s, err := m.schema(ctx, fileURL)
+		if err != nil {
+			return nil, err
+		}
+		t, err = newTranslation(s, version, tgt.version, tgt.schemaURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to translate %q to %q: %w", schemaURL, tgt.schemaURL, err)
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	// Keep the translation of a concurrent request, so that all the signals share it
+	if existing, exist := m.translations[schemaURL]; exist {
+		return existing, nil
+	}
+	m.translations[schemaURL] = t
+	return t, nil
+}
+
+// schema returns the parsed schema file published at the schema URL. The schema file is
+// retrieved once by concurrent requests, and a failure is returned until it can be retried.
+func (m *Manager) schema(ctx context.Context, schemaURL string) (*ast.Schema, error) {
+	m.lock.Lock()
+	e, exist := m.schemas[schemaURL]
+	if exist && !e.retryable(time.Now()) {
+		m.lock.Unlock()
+		select {
+		case <-e.done:
+			return e.schema, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	failures := 0
+	if exist {
+		failures = e.failures
+	}
+	e = &schemaEntry{done: make(chan struct{}), failures: failures}
+	m.schemas[schemaURL] = e
+	m.lock.Unlock()
+
+	e.schema, e.err = m.retrieve(ctx, schemaURL)
+	switch {
+	case e.err == nil:
+		e.failures = 0
+	case ctx.Err() != nil:
+		// The request was canceled, the schema file can be retrieved by the next one
+	default:
+		e.failures++
+		e.retryAt = time.Now().Add(m.retryInterval(e.failures))
+	}
+	close(e.done)
+	return e.schema, e.err
+}
+
+// retryInterval returns the time to wait before retrieving a schema file again after failures.
+func (m *Manager) retryInterval(failures int) time.Duration {
+	interval := m.initialRetryInterval
+	for i := 1; i < failures && interval < m.maxRetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > m.maxRetryInterval {
+		return m.maxRetryInterval
+	}
+	return interval
+}
+
+func (m *Manager) retrieve(ctx context.Context, schemaURL string) (*ast.Schema, error) {
+	m.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+	content, err := m.provider.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schema %q: %w", schemaURL, err)
+	}
+	s, err := schema.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse schema %q: %w", schemaURL, err)
+	}
+	return s, nil
+}
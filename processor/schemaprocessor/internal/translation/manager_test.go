@@ -0,0 +1,216 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+type recordingProvider struct {
+	requested []string
+}
+
+func (rp *recordingProvider) Retrieve(_ context.Context, schemaURL string) ([]byte, error) {
+	rp.requested = append(rp.requested, schemaURL)
+	return []byte(exampleSchema), nil
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario  string
+		target    string
+		schemaURL string
+		fetched   []string
+		noop      bool
+	}{
+		{
+			scenario:  "no schema url",
+			target:    "https://example.com/schemas/1.2.0",
+			schemaURL: "",
+			noop:      true,
+		},
+		{
+			scenario:  "family not targeted",
+			target:    "https://example.com/schemas/1.2.0",
+			schemaURL: "https://opentelemetry.io/schemas/1.0.0",
+			noop:      true,
+		},
+		{
+			scenario:  "invalid schema url",
+			target:    "https://example.com/schemas/1.2.0",
+			schemaURL: "https://example.com/schemas/latest",
+			noop:      true,
+		},
+		{
+			scenario:  "same version",
+			target:    "https://example.com/schemas/1.2.0",
+			schemaURL: "https://example.com/schemas/1.2.0",
+		},
+		{
+			scenario:  "update to target",
+			target:    "https://example.com/schemas/1.2.0",
+			schemaURL: "https://example.com/schemas/1.0.0",
+			fetched:   []string{"https://example.com/schemas/1.2.0"},
+		},
+		{
+			scenario:  "revert to target",
+			target:    "https://example.com/schemas/1.0.0",
+			schemaURL: "https://example.com/schemas/1.2.0",
+			fetched:   []string{"https://example.com/schemas/1.2.0"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			provider := &recordingProvider{}
+			m, err := NewManager([]string{tc.target}, provider, zaptest.NewLogger(t))
+			require.NoError(t, err, "Must not error when creating manager")
+
+			for i := 0; i < 2; i++ {
+				tn, err := m.RequestTranslation(context.Background(), tc.schemaURL)
+				require.NoError(t, err, "Must not error when requesting translation")
+				if tc.noop {
+					assert.Nil(t, tn)
+				} else {
+					require.NotNil(t, tn)
+					assert.Equal(t, tc.target, tn.SchemaURL())
+				}
+			}
+			assert.Equal(t, tc.fetched, provider.requested, "Must only fetch the needed schema once")
+		})
+	}
+}
+
+func TestManagerUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.2.0"}, &recordingProvider{}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/0.9.0")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.2.0"}, &countingProvider{}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.1.0")
+		return err
+	})
+}
+
+type failingProvider struct {
+	requests int32
+}
+
+func (fp *failingProvider) Retrieve(_ context.Context, _ string) ([]byte, error) {
+	atomic.AddInt32(&fp.requests, 1)
+	return nil, errors.New("unavailable")
+}
+
+func TestManagerCachesFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := &failingProvider{}
+	m, err := NewManager([]string{"https://example.com/schemas/1.2.0"}, provider, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	m.initialRetryInterval = 100 * time.Millisecond
+
+	for i := 0; i < 3; i++ {
+		_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.1.0")
+		assert.EqualError(t, err, `unable to retrieve schema "https://example.com/schemas/1.2.0": unavailable`)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&provider.requests), "Must not retrieve the schema again before the retry interval")
+
+	require.Eventually(t, func() bool {
+		_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.1.0")
+		return atomic.LoadInt32(&provider.requests) == 2
+	}, time.Second, 10*time.Millisecond, "Must retrieve the schema again after the retry interval")
+	assert.Error(t, err)
+}
+
+func TestManagerRetryInterval(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager(nil, &failingProvider{}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	assert.Equal(t, 5*time.Second, m.retryInterval(1))
+	assert.Equal(t, 10*time.Second, m.retryInterval(2))
+	assert.Equal(t, 160*time.Second, m.retryInterval(6))
+	assert.Equal(t, 5*time.Minute, m.retryInterval(7))
+	assert.Equal(t, 5*time.Minute, m.retryInterval(100))
+}
+
+type blockingProvider struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (bp *blockingProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	if schemaURL == "https://example.com/schemas/1.2.0" {
+		close(bp.started)
+		select {
+		case <-bp.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []byte(exampleSchema), nil
+}
+
+func TestManagerDoesNotBlockOtherSchemas(t *testing.T) {
+	t.Parallel()
+
+	provider := &blockingProvider{started: make(chan struct{}), release: make(chan struct{})}
+	m, err := NewManager([]string{"https://example.com/schemas/1.2.0", "https://other.example.com/schemas/1.2.0"}, provider, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.1.0")
+		blocked <- err
+	}()
+	<-provider.started
+
+	// The retrieval of another schema file must not wait for the blocked one
+	_, err = m.RequestTranslation(context.Background(), "https://other.example.com/schemas/1.1.0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = m.RequestTranslation(ctx, "https://example.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must wait for the pending retrieval of the same schema file")
+
+	close(provider.release)
+	require.NoError(t, <-blocked)
+}
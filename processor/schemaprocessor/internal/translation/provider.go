@@ -0,0 +1,114 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Provider allows for collector extensions to be used to look up schemaURLs
+type Provider interface {
+	// Retrieve returns the content of the schema file published at schemaURL
+	Retrieve(ctx context.Context, schemaURL string) ([]byte, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a provider that downloads the schema files
+// from their schema URL
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code returned: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+type fileCacheProvider struct {
+	dir  string
+	next Provider
+}
+
+var _ Provider = (*fileCacheProvider)(nil)
+
+// NewFileCacheProvider returns a provider that keeps a copy of the schema files
+// retrieved by next in dir, so that they are only downloaded once and remain
+// available across restarts of the collector.
+func NewFileCacheProvider(dir string, next Provider) Provider {
+	return &fileCacheProvider{dir: dir, next: next}
+}
+
+func (fp *fileCacheProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	// Schema files of a published version are immutable,
+	// so that a cached copy never has to be refreshed.
+	path := filepath.Join(fp.dir, url.QueryEscape(schemaURL))
+	content, err := os.ReadFile(path)
+	if err == nil {
+		return content, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	content, err = fp.next.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(fp.dir, 0700); err != nil {
+		return nil, err
+	}
+	// The file is written to a temporary name first so that
+	// a partially written file is never read back.
+	tmp, err := os.CreateTemp(fp.dir, ".schema-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
@@ -0,0 +1,74 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/1.2.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write([]byte(exampleSchema))
+		assert.NoError(t, err, "Must not error when writing schema content")
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewHTTPProvider(server.Client())
+
+	content, err := provider.Retrieve(context.Background(), server.URL+"/schemas/1.2.0")
+	assert.NoError(t, err, "Must not error when retrieving a published schema")
+	assert.Equal(t, exampleSchema, string(content))
+
+	_, err = provider.Retrieve(context.Background(), server.URL+"/schemas/1.3.0")
+	assert.EqualError(t, err, "invalid status code returned: 404")
+}
+
+type countingProvider struct {
+	requests int32
+}
+
+func (cp *countingProvider) Retrieve(_ context.Context, _ string) ([]byte, error) {
+	atomic.AddInt32(&cp.requests, 1)
+	return []byte(exampleSchema), nil
+}
+
+func TestFileCacheProvider(t *testing.T) {
+	t.Parallel()
+
+	next := &countingProvider{}
+	dir := t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		// A new provider reads the schema file cached by the previous one
+		provider := NewFileCacheProvider(dir, next)
+		content, err := provider.Retrieve(context.Background(), "https://example.com/schemas/1.2.0")
+		require.NoError(t, err, "Must not error when retrieving schema")
+		assert.Equal(t, exampleSchema, string(content))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&next.requests), "Must only retrieve the schema once")
+}
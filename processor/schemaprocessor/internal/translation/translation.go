@@ -0,0 +1,402 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	ast10 "go.opentelemetry.io/otel/schema/v1.0/ast"
+	types10 "go.opentelemetry.io/otel/schema/v1.0/types"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+var ErrUnsupportedVersion = errors.New("schema version not defined by the schema file")
+
+// Translation applies the changes defined by a schema file to signals
+// published with one version of a schema family, so that they conform
+// to the target version of the family.
+//
+// The changes of every version between the two are flattened into steps,
+// ordered as they must be applied. Moving to an older version applies
+// the inverse of the changes in the reverse order.
+type Translation struct {
+	targetURL string
+
+	resources  []renames
+	spans      []spanStep
+	spanEvents []spanEventStep
+	metrics    []metricStep
+	logs       []renames
+}
+
+// renames maps the current names to the names used by the target version
+type renames map[string]string
+
+// names is a set of names that a step applies to, nil meaning all names
+type names map[string]struct{}
+
+type spanStep struct {
+	attributes renames
+	spans      names
+}
+
+type spanEventStep struct {
+	events     renames
+	attributes renames
+	spans      names
+	eventNames names
+}
+
+type metricStep struct {
+	metrics    renames
+	attributes renames
+	metricSet  names
+}
+
+type version struct {
+	*Version
+	def ast.VersionDef
+}
+
+func newTranslation(schema *ast.Schema, from, to *Version, targetURL string) (*Translation, error) {
+	lo, hi := from, to
+	revert := from.GreaterThan(to)
+	if revert {
+		lo, hi = to, from
+	}
+
+	var (
+		versions           []version
+		foundFrom, foundTo bool
+	)
+	for ident, def := range schema.Versions {
+		v, err := NewVersion(string(ident))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q in schema %q: %w", ident, schema.SchemaURL, err)
+		}
+		foundFrom = foundFrom || v.Equal(from)
+		foundTo = foundTo || v.Equal(to)
+		if v.GreaterThan(lo) && !v.GreaterThan(hi) {
+			versions = append(versions, version{Version: v, def: def})
+		}
+	}
+	if !foundFrom {
+		return nil, fmt.Errorf("%s: %w", from, ErrUnsupportedVersion)
+	}
+	if !foundTo {
+		return nil, fmt.Errorf("%s: %w", to, ErrUnsupportedVersion)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].LessThan(versions[j].Version)
+	})
+
+	t := &Translation{targetURL: targetURL}
+	for _, v := range versions {
+		t.addVersion(v.def)
+	}
+	if revert {
+		t.invert()
+	}
+	return t, nil
+}
+
+// addVersion appends the steps updating signals to the given version definition.
+// The changes that apply to all the signals are applied first.
+func (t *Translation) addVersion(def ast.VersionDef) {
+	var all []renames
+	for _, change := range def.All.Changes {
+		if change.RenameAttributes != nil {
+			all = append(all, renames(*change.RenameAttributes))
+		}
+	}
+
+	t.resources = append(t.resources, all...)
+	for _, change := range def.Resources.Changes {
+		if change.RenameAttributes != nil {
+			t.resources = append(t.resources, renames(*change.RenameAttributes))
+		}
+	}
+
+	for _, r := range all {
+		t.spans = append(t.spans, spanStep{attributes: r})
+	}
+	for _, change := range def.Spans.Changes {
+		if change.RenameAttributes != nil {
+			t.spans = append(t.spans, spanStep{
+				attributes: renames(change.RenameAttributes.AttributeMap),
+				spans:      newSpanNames(change.RenameAttributes.ApplyToSpans),
+			})
+		}
+	}
+
+	for _, r := range all {
+		t.spanEvents = append(t.spanEvents, spanEventStep{attributes: r})
+	}
+	for _, change := range def.SpanEvents.Changes {
+		t.spanEvents = append(t.spanEvents, newSpanEventSteps(change)...)
+	}
+
+	for _, r := range all {
+		t.metrics = append(t.metrics, metricStep{attributes: r})
+	}
+	for _, change := range def.Metrics.Changes {
+		if len(change.RenameMetrics) > 0 {
+			r := make(renames, len(change.RenameMetrics))
+			for from, to := range change.RenameMetrics {
+				r[string(from)] = string(to)
+			}
+			t.metrics = append(t.metrics, metricStep{metrics: r})
+		}
+		// Splitting metrics is not supported, the metrics are left unchanged.
+		if change.RenameAttributes != nil {
+			step := metricStep{attributes: renames(change.RenameAttributes.AttributeMap)}
+			if len(change.RenameAttributes.ApplyToMetrics) > 0 {
+				step.metricSet = make(names, len(change.RenameAttributes.ApplyToMetrics))
+				for _, name := range change.RenameAttributes.ApplyToMetrics {
+					step.metricSet[string(name)] = struct{}{}
+				}
+			}
+			t.metrics = append(t.metrics, step)
+		}
+	}
+
+	t.logs = append(t.logs, all...)
+	for _, change := range def.Logs.Changes {
+		if change.RenameAttributes != nil {
+			t.logs = append(t.logs, renames(change.RenameAttributes.AttributeMap))
+		}
+	}
+}
+
+func newSpanEventSteps(change ast10.SpanEventsChange) []spanEventStep {
+	var steps []spanEventStep
+	if change.RenameEvents != nil && len(change.RenameEvents.EventNameMap) > 0 {
+		steps = append(steps, spanEventStep{events: renames(change.RenameEvents.EventNameMap)})
+	}
+	if change.RenameAttributes != nil {
+		step := spanEventStep{
+			attributes: renames(change.RenameAttributes.AttributeMap),
+			spans:      newSpanNames(change.RenameAttributes.ApplyToSpans),
+		}
+		if len(change.RenameAttributes.ApplyToEvents) > 0 {
+			step.eventNames = make(names, len(change.RenameAttributes.ApplyToEvents))
+			for _, name := range change.RenameAttributes.ApplyToEvents {
+				step.eventNames[string(name)] = struct{}{}
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func newSpanNames(spans []types10.SpanName) names {
+	if len(spans) == 0 {
+		return nil
+	}
+	n := make(names, len(spans))
+	for _, name := range spans {
+		n[string(name)] = struct{}{}
+	}
+	return n
+}
+
+// invert turns the steps updating signals into the steps reverting them
+func (t *Translation) invert() {
+	for i := range t.resources {
+		t.resources[i] = t.resources[i].inverse()
+	}
+	for i := range t.spans {
+		t.spans[i].attributes = t.spans[i].attributes.inverse()
+	}
+	for i := range t.spanEvents {
+		t.spanEvents[i].events = t.spanEvents[i].events.inverse()
+		t.spanEvents[i].attributes = t.spanEvents[i].attributes.inverse()
+	}
+	for i := range t.metrics {
+		t.metrics[i].metrics = t.metrics[i].metrics.inverse()
+		t.metrics[i].attributes = t.metrics[i].attributes.inverse()
+	}
+	for i := range t.logs {
+		t.logs[i] = t.logs[i].inverse()
+	}
+	reverseRenames(t.resources)
+	reverseRenames(t.logs)
+	for i, j := 0, len(t.spans)-1; i < j; i, j = i+1, j-1 {
+		t.spans[i], t.spans[j] = t.spans[j], t.spans[i]
+	}
+	for i, j := 0, len(t.spanEvents)-1; i < j; i, j = i+1, j-1 {
+		t.spanEvents[i], t.spanEvents[j] = t.spanEvents[j], t.spanEvents[i]
+	}
+	for i, j := 0, len(t.metrics)-1; i < j; i, j = i+1, j-1 {
+		t.metrics[i], t.metrics[j] = t.metrics[j], t.metrics[i]
+	}
+}
+
+func reverseRenames(steps []renames) {
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+}
+
+func (r renames) inverse() renames {
+	if r == nil {
+		return nil
+	}
+	inv := make(renames, len(r))
+	for from, to := range r {
+		inv[to] = from
+	}
+	return inv
+}
+
+func (n names) contains(name string) bool {
+	if n == nil {
+		return true
+	}
+	_, ok := n[name]
+	return ok
+}
+
+// SchemaURL returns the schema URL of the target version
+func (t *Translation) SchemaURL() string {
+	return t.targetURL
+}
+
+// ApplyResourceChanges translates the attributes of the resource and
+// sets the schema URL of the target version
+func (t *Translation) ApplyResourceChanges(in alias.Resource) {
+	attrs := in.Resource().Attributes()
+	for _, r := range t.resources {
+		r.applyToAttributes(attrs)
+	}
+	in.SetSchemaUrl(t.targetURL)
+}
+
+// ApplySpanChanges translates the attributes of the spans,
+// and the names and attributes of their events
+func (t *Translation) ApplySpanChanges(spans ptrace.SpanSlice) {
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		for _, step := range t.spans {
+			if step.spans.contains(span.Name()) {
+				step.attributes.applyToAttributes(span.Attributes())
+			}
+		}
+		events := span.Events()
+		for j := 0; j < events.Len(); j++ {
+			event := events.At(j)
+			for _, step := range t.spanEvents {
+				step.events.applyToName(event)
+				if step.spans.contains(span.Name()) && step.eventNames.contains(event.Name()) {
+					step.attributes.applyToAttributes(event.Attributes())
+				}
+			}
+		}
+	}
+}
+
+// ApplyLogChanges translates the attributes of the log records
+func (t *Translation) ApplyLogChanges(logs plog.LogRecordSlice) {
+	for i := 0; i < logs.Len(); i++ {
+		attrs := logs.At(i).Attributes()
+		for _, r := range t.logs {
+			r.applyToAttributes(attrs)
+		}
+	}
+}
+
+// ApplyMetricChanges translates the names of the metrics and
+// the attributes of their data points
+func (t *Translation) ApplyMetricChanges(metrics pmetric.MetricSlice) {
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		for _, step := range t.metrics {
+			step.metrics.applyToName(metric)
+			if step.attributes != nil && step.metricSet.contains(metric.Name()) {
+				forEachDataPointAttributes(metric, step.attributes.applyToAttributes)
+			}
+		}
+	}
+}
+
+// applyToName renames the signal if its name is on the list
+func (r renames) applyToName(in alias.Signal) {
+	if to, ok := r[in.Name()]; ok {
+		in.SetName(to)
+	}
+}
+
+// applyToAttributes renames the attributes on the list. All the attributes are
+// moved at once, so that renames such as swapping two keys are applied correctly.
+func (r renames) applyToAttributes(attrs pcommon.Map) {
+	var moved pcommon.Map
+	found := false
+	for from, to := range r {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		if !found {
+			moved = pcommon.NewMap()
+			found = true
+		}
+		moved.Upsert(to, v)
+		attrs.Remove(from)
+	}
+	if found {
+		moved.Range(func(k string, v pcommon.Value) bool {
+			attrs.Upsert(k, v)
+			return true
+		})
+	}
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	}
+}
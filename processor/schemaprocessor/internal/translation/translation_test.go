@@ -0,0 +1,232 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+)
+
+const exampleSchema = `
+file_format: 1.1.0
+schema_url: https://example.com/schemas/1.2.0
+versions:
+  1.2.0:
+    all:
+      changes:
+        - rename_attributes:
+            host: host.name
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              http.status: http.status_code
+            apply_to_spans:
+              - GET
+    span_events:
+      changes:
+        - rename_events:
+            name_map: {stacktrace: stack_trace}
+        - rename_attributes:
+            attribute_map:
+              frames: stack_trace.frames
+            apply_to_events:
+              - stack_trace
+    metrics:
+      changes:
+        - rename_metrics:
+            cpu.usage: system.cpu.usage
+        - rename_attributes:
+            attribute_map:
+              status: state
+            apply_to_metrics:
+              - system.cpu.usage
+    logs:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              level: severity
+  1.1.0:
+    resources:
+      changes:
+        - rename_attributes:
+            a: b
+            b: a
+  1.0.0:
+`
+
+func newExampleSchema(t *testing.T) *ast.Schema {
+	s, err := schema.Parse(strings.NewReader(exampleSchema))
+	require.NoError(t, err, "Must be a valid schema")
+	return s
+}
+
+func newExampleTranslation(t *testing.T, from, to string) *Translation {
+	fromVersion, err := NewVersion(from)
+	require.NoError(t, err)
+	toVersion, err := NewVersion(to)
+	require.NoError(t, err)
+
+	tn, err := newTranslation(newExampleSchema(t), fromVersion, toVersion, "https://example.com/schemas/"+to)
+	require.NoError(t, err, "Must not error when creating translation")
+	return tn
+}
+
+func TestTranslationUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := newTranslation(newExampleSchema(t), &Version{1, 0, 0}, &Version{1, 3, 0}, "https://example.com/schemas/1.3.0")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestTranslationResource(t *testing.T) {
+	t.Parallel()
+
+	rs := ptrace.NewResourceSpans()
+	rs.SetSchemaUrl("https://example.com/schemas/1.0.0")
+	rs.Resource().Attributes().InsertString("a", "first")
+	rs.Resource().Attributes().InsertString("b", "second")
+	rs.Resource().Attributes().InsertString("host", "localhost")
+
+	newExampleTranslation(t, "1.0.0", "1.2.0").ApplyResourceChanges(rs)
+
+	assert.Equal(t, "https://example.com/schemas/1.2.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{
+		"a":         "second",
+		"b":         "first",
+		"host.name": "localhost",
+	}, rs.Resource().Attributes().AsRaw())
+
+	newExampleTranslation(t, "1.2.0", "1.0.0").ApplyResourceChanges(rs)
+
+	assert.Equal(t, "https://example.com/schemas/1.0.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{
+		"a":    "first",
+		"b":    "second",
+		"host": "localhost",
+	}, rs.Resource().Attributes().AsRaw())
+}
+
+func TestTranslationSpans(t *testing.T) {
+	t.Parallel()
+
+	spans := ptrace.NewSpanSlice()
+	get := spans.AppendEmpty()
+	get.SetName("GET")
+	get.Attributes().InsertInt("http.status", 200)
+	event := get.Events().AppendEmpty()
+	event.SetName("stacktrace")
+	event.Attributes().InsertInt("frames", 3)
+	post := spans.AppendEmpty()
+	post.SetName("POST")
+	post.Attributes().InsertInt("http.status", 201)
+	post.Attributes().InsertString("host", "localhost")
+
+	newExampleTranslation(t, "1.1.0", "1.2.0").ApplySpanChanges(spans)
+
+	assert.Equal(t, map[string]interface{}{"http.status_code": int64(200)}, get.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", event.Name())
+	assert.Equal(t, map[string]interface{}{"stack_trace.frames": int64(3)}, event.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"http.status": int64(201),
+		"host.name":   "localhost",
+	}, post.Attributes().AsRaw())
+
+	newExampleTranslation(t, "1.2.0", "1.1.0").ApplySpanChanges(spans)
+
+	assert.Equal(t, map[string]interface{}{"http.status": int64(200)}, get.Attributes().AsRaw())
+	assert.Equal(t, "stacktrace", event.Name())
+	assert.Equal(t, map[string]interface{}{"frames": int64(3)}, event.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"http.status": int64(201),
+		"host":        "localhost",
+	}, post.Attributes().AsRaw())
+}
+
+func TestTranslationMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := pmetric.NewMetricSlice()
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("cpu.usage")
+	cpu.SetDataType(pmetric.MetricDataTypeSum)
+	cpu.Sum().DataPoints().AppendEmpty().Attributes().InsertString("status", "idle")
+	memory := metrics.AppendEmpty()
+	memory.SetName("memory.usage")
+	memory.SetDataType(pmetric.MetricDataTypeGauge)
+	memory.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("status", "free")
+
+	newExampleTranslation(t, "1.0.0", "1.2.0").ApplyMetricChanges(metrics)
+
+	assert.Equal(t, "system.cpu.usage", cpu.Name())
+	assert.Equal(t, map[string]interface{}{"state": "idle"}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "memory.usage", memory.Name())
+	assert.Equal(t, map[string]interface{}{"status": "free"}, memory.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	newExampleTranslation(t, "1.2.0", "1.0.0").ApplyMetricChanges(metrics)
+
+	assert.Equal(t, "cpu.usage", cpu.Name())
+	assert.Equal(t, map[string]interface{}{"status": "idle"}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslationLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := plog.NewLogRecordSlice()
+	record := logs.AppendEmpty()
+	record.Attributes().InsertString("level", "info")
+	record.Attributes().InsertString("host", "localhost")
+
+	newExampleTranslation(t, "1.0.0", "1.2.0").ApplyLogChanges(logs)
+
+	assert.Equal(t, map[string]interface{}{
+		"severity":  "info",
+		"host.name": "localhost",
+	}, record.Attributes().AsRaw())
+}
+
+func TestTranslationNoChange(t *testing.T) {
+	t.Parallel()
+
+	logs := plog.NewLogRecordSlice()
+	attrs := logs.AppendEmpty().Attributes()
+	attrs.InsertString("level", "info")
+
+	newExampleTranslation(t, "1.2.0", "1.2.0").ApplyLogChanges(logs)
+
+	assert.Equal(t, map[string]interface{}{"level": "info"}, attrs.AsRaw())
+}
+
+func TestRenameAttributesKeepsValues(t *testing.T) {
+	t.Parallel()
+
+	attrs := pcommon.NewMap()
+	list := pcommon.NewValueSlice()
+	list.SliceVal().AppendEmpty().SetStringVal("value")
+	attrs.Upsert("list", list)
+
+	renames{"list": "items"}.applyToAttributes(attrs)
+
+	assert.Equal(t, map[string]interface{}{"items": []interface{}{"value"}}, attrs.AsRaw())
+}
@@ -24,11 +24,16 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	config   *Config
+	settings component.TelemetrySettings
+	log      *zap.Logger
+
+	manager *translation.Manager
 }
 
 func newTransformer(
@@ -41,28 +46,121 @@ func newTransformer(
 		return nil, errors.New("invalid configuration provided")
 	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		config:   cfg,
+		settings: set.TelemetrySettings,
+		log:      set.Logger,
 	}, nil
 }
 
 func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceTranslation := t.requestTranslation(ctx, rLog.SchemaUrl())
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			sLog := rLog.ScopeLogs().At(sl)
+			tn := resourceTranslation
+			if sLog.SchemaUrl() != "" {
+				tn = t.requestTranslation(ctx, sLog.SchemaUrl())
+				if tn != nil {
+					sLog.SetSchemaUrl(tn.SchemaURL())
+				}
+			}
+			if tn != nil {
+				tn.ApplyLogChanges(sLog.LogRecords())
+			}
+		}
+		if resourceTranslation != nil {
+			resourceTranslation.ApplyResourceChanges(rLog)
+		}
+	}
 	return ld, nil
 }
 
 func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceTranslation := t.requestTranslation(ctx, rMetric.SchemaUrl())
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			sMetric := rMetric.ScopeMetrics().At(sm)
+			tn := resourceTranslation
+			if sMetric.SchemaUrl() != "" {
+				tn = t.requestTranslation(ctx, sMetric.SchemaUrl())
+				if tn != nil {
+					sMetric.SetSchemaUrl(tn.SchemaURL())
+				}
+			}
+			if tn != nil {
+				tn.ApplyMetricChanges(sMetric.Metrics())
+			}
+		}
+		if resourceTranslation != nil {
+			resourceTranslation.ApplyResourceChanges(rMetric)
+		}
+	}
 	return md, nil
 }
 
 func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rs := 0; rs < td.ResourceSpans().Len(); rs++ {
+		rSpan := td.ResourceSpans().At(rs)
+		resourceTranslation := t.requestTranslation(ctx, rSpan.SchemaUrl())
+		for ss := 0; ss < rSpan.ScopeSpans().Len(); ss++ {
+			sSpan := rSpan.ScopeSpans().At(ss)
+			tn := resourceTranslation
+			if sSpan.SchemaUrl() != "" {
+				tn = t.requestTranslation(ctx, sSpan.SchemaUrl())
+				if tn != nil {
+					sSpan.SetSchemaUrl(tn.SchemaURL())
+				}
+			}
+			if tn != nil {
+				tn.ApplySpanChanges(sSpan.Spans())
+			}
+		}
+		if resourceTranslation != nil {
+			resourceTranslation.ApplyResourceChanges(rSpan)
+		}
+	}
 	return td, nil
 }
 
+// requestTranslation returns the translation of the signals published with the
+// schema URL, or nil if they must be passed on unchanged.
+// The schema URL of a scope takes precedence over the one of its resource.
+func (t transformer) requestTranslation(ctx context.Context, schemaURL string) *translation.Translation {
+	tn, err := t.manager.RequestTranslation(ctx, schemaURL)
+	if err != nil {
+		t.log.Warn("Unable to translate signals, passing them unchanged",
+			zap.String("schema-url", schemaURL),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return tn
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.config.HTTPClientSettings.ToClient(host, t.settings)
+	if err != nil {
+		return err
+	}
+	provider := translation.NewHTTPProvider(client)
+	if t.config.CacheDirectory != "" {
+		provider = translation.NewFileCacheProvider(t.config.CacheDirectory, provider)
+	}
+	t.manager, err = translation.NewManager(t.config.Targets, provider, t.log)
+	if err != nil {
+		return err
+	}
+
+	// A schema that can't be fetched is not fatal, it is
+	// requested again once signals are published with it.
+	for _, schemaURL := range append(append([]string{}, t.config.Targets...), t.config.Prefetch...) {
+		if err := t.manager.Prefetch(ctx, schemaURL); err != nil {
+			t.log.Warn("Unable to prefetch schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
@@ -18,11 +18,13 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -48,6 +50,7 @@ func newTestTransformer(t *testing.T) *transformer {
 		},
 	})
 	require.NoError(t, err, "Must not error when creating default transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()), "Must not error when starting transformer")
 	return trans
 }
 
@@ -110,3 +113,77 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerSchemaTranslation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(server.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{server.URL + "/schemas/1.1.0"}
+	trans, err := newTransformer(context.Background(), cfg, componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()), "Must not error when starting transformer")
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(server.URL + "/schemas/1.0.0")
+		rm.Resource().Attributes().InsertString("k8s.pod.name", "pod")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("container.cpu.usage.total")
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		m.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("k8s.node.name", "node")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		rm = out.ResourceMetrics().At(0)
+		assert.Equal(t, server.URL+"/schemas/1.1.0", rm.SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, rm.Resource().Attributes().AsRaw())
+		m = rm.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "cpu.usage.total", m.Name())
+		assert.Equal(t, map[string]interface{}{"kubernetes.node.name": "node"}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(server.URL + "/schemas/1.0.0")
+		rs.Resource().Attributes().InsertString("telemetry.auto.version", "1.0")
+		ss := rs.ScopeSpans().AppendEmpty()
+		s := ss.Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().InsertString("peer.service", "backend")
+		e := s.Events().AppendEmpty()
+		e.SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, server.URL+"/schemas/1.1.0", rs.SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"telemetry.auto_instr.version": "1.0"}, rs.Resource().Attributes().AsRaw())
+		s = rs.ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, s.Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", s.Events().At(0).Name())
+	})
+
+	t.Run("logs with scope schema url", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.SetSchemaUrl(server.URL + "/schemas/1.0.0")
+		sl.LogRecords().AppendEmpty().Attributes().InsertString("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		rl = out.ResourceLogs().At(0)
+		assert.Equal(t, "", rl.SchemaUrl(), "Must not set the schema url of a resource without one")
+		sl = rl.ScopeLogs().At(0)
+		assert.Equal(t, server.URL+"/schemas/1.1.0", sl.SchemaUrl())
+		assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, sl.LogRecords().At(0).Attributes().AsRaw())
+	})
+}
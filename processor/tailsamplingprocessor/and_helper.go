@@ -54,6 +54,9 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tcCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tcCfg.ResourceConditions, tcCfg.SpanConditions, tcCfg.MatchAllSpans)
 	case SpanCount:
 		scfCfg := cfg.SpanCountCfg
 		return sampling.NewSpanCount(logger, scfCfg.MinSpans), nil
@@ -85,6 +85,9 @@ func getSubPolicyEvaluator(logger *zap.Logger, cfg *SubPolicyCfg) (sampling.Poli
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tcCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tcCfg.ResourceConditions, tcCfg.SpanConditions, tcCfg.MatchAllSpans)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -15,6 +15,7 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -48,6 +49,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// TQLCondition sample traces with spans matching conditions written in the telemetry query language.
+	TQLCondition PolicyType = "tql_condition"
 )
 
 // SubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -74,6 +77,8 @@ type SubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state policy evaluator.
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for tql_condition policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type AndSubPolicyCfg struct {
@@ -97,6 +102,8 @@ type AndSubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state filter sampling policy evaluator
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for tql_condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type TraceStateCfg struct {
@@ -106,6 +113,19 @@ type TraceStateCfg struct {
 	Values []string `mapstructure:"values"`
 }
 
+// TQLConditionCfg holds the configurable settings to create a TQL condition filter sampling
+// policy evaluator.
+type TQLConditionCfg struct {
+	// ResourceConditions are conditions on the resource of the spans, with paths relative to the resource,
+	// e.g. `attributes["service.name"] == "checkout"`. A span matches if its resource matches any of them.
+	ResourceConditions []string `mapstructure:"resource"`
+	// SpanConditions are conditions on the spans, using the paths of the tqltraces context. A span matches
+	// if it matches any of them.
+	SpanConditions []string `mapstructure:"span"`
+	// MatchAllSpans samples the trace only if all of its spans match, instead of any of them.
+	MatchAllSpans bool `mapstructure:"match_all_spans"`
+}
+
 type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
@@ -151,6 +171,8 @@ type PolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for defining tql_condition policy
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -238,4 +260,35 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// Storage, if set, is the ID of a storage extension used to keep the spans of pending traces
+	// out of memory. Traces still pending on shutdown are persisted and reloaded on start.
+	Storage *config.ComponentID `mapstructure:"storage"`
+	// MaxTracesInMemory is the number of pending traces whose spans are kept in memory when
+	// Storage is set. The spans of any further traces are written to the storage extension.
+	// It must be positive when Storage is set.
+	MaxTracesInMemory uint64 `mapstructure:"max_traces_in_memory"`
+	// DecisionCache configures the cache of the decisions taken for recent traces, used for the spans
+	// of traces that arrive after the trace was removed from memory.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Storage != nil && cfg.MaxTracesInMemory == 0 {
+		return errors.New("max_traces_in_memory must be positive when storage is set")
+	}
+	return nil
+}
+
+// DecisionCacheCfg holds the configurable settings of the cache of sampling decisions.
+type DecisionCacheCfg struct {
+	// SampledCacheSize is the number of trace IDs kept for sampled traces. Zero disables the cache.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the number of trace IDs kept for not sampled traces. Zero disables the cache.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// TTL is the time a decision is kept after it was taken. Zero keeps decisions until they are
+	// evicted by newer ones.
+	TTL time.Duration `mapstructure:"ttl"`
 }
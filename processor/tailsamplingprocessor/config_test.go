@@ -43,6 +43,11 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				SampledCacheSize:    1000,
+				NonSampledCacheSize: 5000,
+				TTL:                 10 * time.Minute,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -88,6 +93,15 @@ func TestLoadConfig(t *testing.T) {
 					Type:          TraceState,
 					TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 				},
+				{
+					Name: "test-policy-10",
+					Type: TQLCondition,
+					TQLConditionCfg: TQLConditionCfg{
+						ResourceConditions: []string{`attributes["service.name"] == "checkout"`},
+						SpanConditions:     []string{`attributes["db.system"] == "redis" and end_time_unix_nano - start_time_unix_nano > 500000000`},
+						MatchAllSpans:      true,
+					},
+				},
 				{
 					Name: "and-policy-1",
 					Type: And,
@@ -143,3 +157,15 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Storage = &storageID
+	assert.EqualError(t, cfg.Validate(), "max_traces_in_memory must be positive when storage is set")
+
+	cfg.MaxTracesInMemory = 10
+	assert.NoError(t, cfg.Validate())
+}
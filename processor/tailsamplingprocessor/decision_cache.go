@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+// decisionCache keeps the sampling decisions of recent traces, so that spans arriving after
+// their trace was removed from memory follow the original decision. The sampled and not
+// sampled trace IDs are kept in separate LRU caches, and entries expire after the TTL.
+type decisionCache struct {
+	sync.Mutex
+	sampled    *lru.Cache
+	notSampled *lru.Cache
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// newDecisionCache returns a decisionCache for the given configuration, or nil if both caches are disabled.
+func newDecisionCache(cfg DecisionCacheCfg) *decisionCache {
+	if cfg.SampledCacheSize <= 0 && cfg.NonSampledCacheSize <= 0 {
+		return nil
+	}
+
+	c := &decisionCache{
+		ttl: cfg.TTL,
+		now: time.Now,
+	}
+	if cfg.SampledCacheSize > 0 {
+		c.sampled = lru.New(cfg.SampledCacheSize)
+	}
+	if cfg.NonSampledCacheSize > 0 {
+		c.notSampled = lru.New(cfg.NonSampledCacheSize)
+	}
+	return c
+}
+
+// put records the final decision taken for a trace. Decisions other than Sampled and NotSampled are ignored.
+func (c *decisionCache) put(id pcommon.TraceID, decision sampling.Decision, decisionTime time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	switch decision {
+	case sampling.Sampled:
+		if c.sampled != nil {
+			c.sampled.Add(id, decisionTime)
+		}
+		if c.notSampled != nil {
+			c.notSampled.Remove(id)
+		}
+	case sampling.NotSampled:
+		if c.notSampled != nil {
+			c.notSampled.Add(id, decisionTime)
+		}
+		if c.sampled != nil {
+			c.sampled.Remove(id)
+		}
+	}
+}
+
+// get returns the decision recorded for a trace and the time it was taken, if it did not expire yet.
+func (c *decisionCache) get(id pcommon.TraceID) (sampling.Decision, time.Time, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if decisionTime, ok := c.lookup(c.sampled, id); ok {
+		return sampling.Sampled, decisionTime, true
+	}
+	if decisionTime, ok := c.lookup(c.notSampled, id); ok {
+		return sampling.NotSampled, decisionTime, true
+	}
+	return sampling.Unspecified, time.Time{}, false
+}
+
+func (c *decisionCache) lookup(cache *lru.Cache, id pcommon.TraceID) (time.Time, bool) {
+	if cache == nil {
+		return time.Time{}, false
+	}
+	v, ok := cache.Get(id)
+	if !ok {
+		return time.Time{}, false
+	}
+	decisionTime := v.(time.Time)
+	if c.ttl > 0 && c.now().Sub(decisionTime) > c.ttl {
+		cache.Remove(id)
+		return time.Time{}, false
+	}
+	return decisionTime, true
+}
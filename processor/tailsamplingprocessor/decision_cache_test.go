@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestNewDecisionCacheDisabled(t *testing.T) {
+	assert.Nil(t, newDecisionCache(DecisionCacheCfg{TTL: time.Minute}))
+}
+
+func TestDecisionCache(t *testing.T) {
+	traceIDs, _ := generateIdsAndBatches(3)
+	now := time.Unix(1660000000, 0)
+	c := newDecisionCache(DecisionCacheCfg{SampledCacheSize: 1, NonSampledCacheSize: 2, TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.put(traceIDs[0], sampling.Sampled, now)
+	c.put(traceIDs[1], sampling.NotSampled, now)
+	c.put(traceIDs[2], sampling.Error, now)
+
+	decision, decisionTime, ok := c.get(traceIDs[0])
+	require.True(t, ok)
+	assert.Equal(t, sampling.Sampled, decision)
+	assert.Equal(t, now, decisionTime)
+
+	decision, _, ok = c.get(traceIDs[1])
+	require.True(t, ok)
+	assert.Equal(t, sampling.NotSampled, decision)
+
+	_, _, ok = c.get(traceIDs[2])
+	assert.False(t, ok, "only final decisions should be cached")
+
+	// a newer decision for the same trace replaces the previous one
+	c.put(traceIDs[0], sampling.NotSampled, now)
+	decision, _, ok = c.get(traceIDs[0])
+	require.True(t, ok)
+	assert.Equal(t, sampling.NotSampled, decision)
+
+	// the least recently used trace is evicted once the cache is full
+	c.put(traceIDs[2], sampling.NotSampled, now)
+	_, _, ok = c.get(traceIDs[1])
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, _, ok = c.get(traceIDs[2])
+	assert.False(t, ok, "expired decisions should not be returned")
+}
+
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	const maxSize = 1
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  atomic.NewUint64(0),
+		decisionCache:   newDecisionCache(DecisionCacheCfg{SampledCacheSize: 10, NonSampledCacheSize: 10}),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID := pcommon.NewTraceID([16]byte{1})
+	notSampledID := pcommon.NewTraceID([16]byte{2})
+	otherID := pcommon.NewTraceID([16]byte{3})
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+
+	// the next trace removes the sampled trace from memory, as only one trace fits
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(otherID)))
+	_, ok := tsp.idToTrace.Load(sampledID)
+	require.False(t, ok)
+	_, ok = tsp.idToTrace.Load(notSampledID)
+	require.False(t, ok)
+	require.Equal(t, 2, mpe.EvaluationCount)
+
+	// late spans are forwarded or dropped without being evaluated again
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	assert.Equal(t, 2, msp.SpanCount())
+	_, ok = tsp.idToTrace.Load(sampledID)
+	assert.False(t, ok)
+	_, ok = tsp.idToTrace.Load(notSampledID)
+	assert.False(t, ok)
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	assert.Equal(t, 3, mpe.EvaluationCount, "only the trace without a cached decision should be evaluated")
+}
@@ -36,6 +36,11 @@ type TraceData struct {
 	SpanCount *atomic.Int64
 	// ReceivedBatches stores all the batches received for the trace.
 	ReceivedBatches []ptrace.Traces
+	// Spilled indicates that the batches received while the decision is pending are
+	// kept in a storage extension instead of ReceivedBatches.
+	Spilled bool
+	// SpilledBatches is the number of batches of the trace kept in the storage extension.
+	SpilledBatches int
 }
 
 // Decision gives the status of sampling decision.
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+var errNoConditions = errors.New("at least one resource or span condition must be provided")
+
+type tqlConditionFilter struct {
+	logger             *zap.Logger
+	resourceConditions []tql.BoolExpressionEvaluator
+	spanConditions     []tql.BoolExpressionEvaluator
+	matchAllSpans      bool
+}
+
+var _ PolicyEvaluator = (*tqlConditionFilter)(nil)
+
+// NewTQLConditionFilter creates a policy evaluator that samples traces with spans matching conditions written
+// in the telemetry query language. Resource conditions access the fields of the resource of the spans, e.g.
+// `attributes["service.name"] == "checkout"`, and span conditions access the fields of the spans, with the
+// paths of the tqltraces context. A span matches if its resource matches any of the resource conditions and
+// it matches any of the span conditions; conditions that are not given always match. The trace is sampled if
+// any span matches, or if all of its spans match when matchAllSpans is true.
+func NewTQLConditionFilter(logger *zap.Logger, resourceConditions []string, spanConditions []string, matchAllSpans bool) (PolicyEvaluator, error) {
+	if len(resourceConditions) == 0 && len(spanConditions) == 0 {
+		return nil, errNoConditions
+	}
+
+	resourceEvaluators, err := tql.ParseConditions(resourceConditions, tqlotel.ConditionFunctions(), parseResourcePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource condition: %w", err)
+	}
+	spanEvaluators, err := tql.ParseConditions(spanConditions, tqlotel.ConditionFunctions(), tqltraces.ParsePath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span condition: %w", err)
+	}
+
+	return &tqlConditionFilter{
+		logger:             logger,
+		resourceConditions: resourceEvaluators,
+		spanConditions:     spanEvaluators,
+		matchAllSpans:      matchAllSpans,
+	}, nil
+}
+
+// parseResourcePath parses the paths of resource conditions, which are relative to the resource.
+func parseResourcePath(val *tql.Path) (tql.GetSetter, error) {
+	if val == nil || len(val.Fields) == 0 {
+		return nil, fmt.Errorf("bad path %v", val)
+	}
+	fields := make([]tql.Field, 0, len(val.Fields)+1)
+	fields = append(fields, tql.Field{Name: "resource"})
+	fields = append(fields, val.Fields...)
+	return tqltraces.ParsePath(&tql.Path{Fields: fields})
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tcf *tqlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	tcf.logger.Debug("Evaluating spans in TQL condition filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	evaluatedSpans := 0
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			resourceMatches := tcf.matchesResource(rs.Resource())
+			if !resourceMatches && !tcf.matchAllSpans {
+				continue
+			}
+
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					matches := resourceMatches && tcf.matchesSpan(spans.At(k), ils.Scope(), rs.Resource())
+					switch {
+					case matches && !tcf.matchAllSpans:
+						return Sampled, nil
+					case !matches && tcf.matchAllSpans:
+						return NotSampled, nil
+					}
+					evaluatedSpans++
+				}
+			}
+		}
+	}
+
+	if tcf.matchAllSpans && evaluatedSpans > 0 {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (tcf *tqlConditionFilter) matchesResource(resource pcommon.Resource) bool {
+	if len(tcf.resourceConditions) == 0 {
+		return true
+	}
+	ctx := tqltraces.SpanTransformContext{
+		Span:                 ptrace.NewSpan(),
+		InstrumentationScope: pcommon.NewInstrumentationScope(),
+		Resource:             resource,
+	}
+	return anyConditionMatches(tcf.resourceConditions, ctx)
+}
+
+func (tcf *tqlConditionFilter) matchesSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(tcf.spanConditions) == 0 {
+		return true
+	}
+	ctx := tqltraces.SpanTransformContext{
+		Span:                 span,
+		InstrumentationScope: scope,
+		Resource:             resource,
+	}
+	return anyConditionMatches(tcf.spanConditions, ctx)
+}
+
+func anyConditionMatches(conditions []tql.BoolExpressionEvaluator, ctx tql.TransformContext) bool {
+	for _, condition := range conditions {
+		if condition(ctx) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+type tqlTestSpan struct {
+	service  string
+	dbSystem string
+	duration time.Duration
+}
+
+func newTQLTestTrace(spans ...tqlTestSpan) *TraceData {
+	traces := ptrace.NewTraces()
+	start := time.Unix(1660000000, 0)
+	for _, s := range spans {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		if s.dbSystem != "" {
+			span.Attributes().InsertString("db.system", s.dbSystem)
+		}
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(s.duration)))
+	}
+	return &TraceData{ReceivedBatches: []ptrace.Traces{traces}}
+}
+
+func TestTQLConditionFilter(t *testing.T) {
+	const slowRedis = `attributes["db.system"] == "redis" and end_time_unix_nano - start_time_unix_nano > 500000000`
+	checkout := []string{`attributes["service.name"] == "checkout"`}
+
+	cases := []struct {
+		desc               string
+		resourceConditions []string
+		spanConditions     []string
+		matchAllSpans      bool
+		trace              *TraceData
+		decision           Decision
+	}{
+		{
+			desc:           "span matches",
+			spanConditions: []string{slowRedis},
+			trace:          newTQLTestTrace(tqlTestSpan{service: "cart"}, tqlTestSpan{service: "checkout", dbSystem: "redis", duration: time.Second}),
+			decision:       Sampled,
+		},
+		{
+			desc:           "no span matches",
+			spanConditions: []string{slowRedis},
+			trace:          newTQLTestTrace(tqlTestSpan{service: "checkout", dbSystem: "redis", duration: 100 * time.Millisecond}, tqlTestSpan{service: "checkout", dbSystem: "mysql", duration: time.Second}),
+			decision:       NotSampled,
+		},
+		{
+			desc:           "any of the span conditions matches",
+			spanConditions: []string{slowRedis, `attributes["db.system"] == "mysql"`},
+			trace:          newTQLTestTrace(tqlTestSpan{service: "checkout", dbSystem: "mysql"}),
+			decision:       Sampled,
+		},
+		{
+			desc:               "span matches on matching resource",
+			resourceConditions: checkout,
+			spanConditions:     []string{slowRedis},
+			trace:              newTQLTestTrace(tqlTestSpan{service: "checkout", dbSystem: "redis", duration: time.Second}),
+			decision:           Sampled,
+		},
+		{
+			desc:               "span matches on other resource",
+			resourceConditions: checkout,
+			spanConditions:     []string{slowRedis},
+			trace:              newTQLTestTrace(tqlTestSpan{service: "cart", dbSystem: "redis", duration: time.Second}, tqlTestSpan{service: "checkout"}),
+			decision:           NotSampled,
+		},
+		{
+			desc:               "resource conditions only",
+			resourceConditions: checkout,
+			trace:              newTQLTestTrace(tqlTestSpan{service: "cart"}, tqlTestSpan{service: "checkout"}),
+			decision:           Sampled,
+		},
+		{
+			desc:               "all spans match",
+			resourceConditions: []string{`IsMatch(attributes["service.name"], "^check") == true`},
+			spanConditions:     []string{`end_time_unix_nano - start_time_unix_nano >= 1000000000`},
+			matchAllSpans:      true,
+			trace:              newTQLTestTrace(tqlTestSpan{service: "checkout", duration: time.Second}, tqlTestSpan{service: "checkin", duration: 2 * time.Second}),
+			decision:           Sampled,
+		},
+		{
+			desc:           "not all spans match",
+			spanConditions: []string{slowRedis},
+			matchAllSpans:  true,
+			trace:          newTQLTestTrace(tqlTestSpan{service: "checkout", dbSystem: "redis", duration: time.Second}, tqlTestSpan{service: "checkout"}),
+			decision:       NotSampled,
+		},
+		{
+			desc:               "not all resources match",
+			resourceConditions: checkout,
+			matchAllSpans:      true,
+			trace:              newTQLTestTrace(tqlTestSpan{service: "checkout"}, tqlTestSpan{service: "cart"}),
+			decision:           NotSampled,
+		},
+		{
+			desc:               "all spans of empty trace",
+			resourceConditions: checkout,
+			matchAllSpans:      true,
+			trace:              &TraceData{},
+			decision:           NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			filter, err := NewTQLConditionFilter(zap.NewNop(), c.resourceConditions, c.spanConditions, c.matchAllSpans)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.NewTraceID([16]byte{1}), c.trace)
+			require.NoError(t, err)
+			assert.Equal(t, c.decision, decision)
+		})
+	}
+}
+
+func TestTQLConditionFilterInvalid(t *testing.T) {
+	_, err := NewTQLConditionFilter(zap.NewNop(), nil, nil, false)
+	assert.ErrorIs(t, err, errNoConditions)
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), []string{`name == "checkout"`}, nil, false)
+	assert.ErrorContains(t, err, "invalid resource condition")
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), nil, []string{`attributes["db.system"] ==`}, false)
+	assert.ErrorContains(t, err, "invalid span condition")
+
+	_, err = NewTQLConditionFilter(zap.NewNop(), nil, []string{`set(name, "test") == true`}, false)
+	assert.Error(t, err, "functions changing the telemetry should not be available")
+}
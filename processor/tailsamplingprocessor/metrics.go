@@ -41,6 +41,9 @@ var (
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount  = stats.Int64("sampling_decision_cache_hit", "Count of spans received after their trace was removed from memory and whose decision was found in the decision cache", stats.UnitDimensionless)
+	statDecisionCacheMissCount = stats.Int64("sampling_decision_cache_miss", "Count of spans of traces that were neither on memory nor in the decision cache", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -116,6 +119,20 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.LastValue(),
 	}
 
+	decisionCacheHitView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+	decisionCacheMissView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheMissCount.Name()),
+		Measure:     statDecisionCacheMissCount,
+		Description: statDecisionCacheMissCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		decisionLatencyView,
 		overallDecisionLatencyView,
@@ -130,5 +147,8 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+
+		decisionCacheHitView,
+		decisionCacheMissView,
 	}
 }
@@ -24,10 +24,12 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
@@ -60,6 +62,17 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	decisionCache   *decisionCache
+
+	id                config.ComponentID
+	storageID         *config.ComponentID
+	storage           *traceStorage
+	maxTracesInMemory uint64
+	// numTracesInMemory counts the pending traces whose spans are kept in memory while storage is enabled.
+	numTracesInMemory atomic.Uint64
+	// changedTraces holds the traces whose changes still have to be journaled to storage.
+	changedTracesMu sync.Mutex
+	changedTraces   map[pcommon.TraceID]struct{}
 }
 
 const (
@@ -100,14 +113,18 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 	}
 
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             ctx,
-		nextConsumer:    nextConsumer,
-		maxNumTraces:    cfg.NumTraces,
-		logger:          logger,
-		decisionBatcher: inBatcher,
-		policies:        policies,
-		tickerFrequency: time.Second,
-		numTracesOnMap:  atomic.NewUint64(0),
+		ctx:               ctx,
+		nextConsumer:      nextConsumer,
+		maxNumTraces:      cfg.NumTraces,
+		logger:            logger,
+		decisionBatcher:   inBatcher,
+		policies:          policies,
+		tickerFrequency:   time.Second,
+		numTracesOnMap:    atomic.NewUint64(0),
+		decisionCache:     newDecisionCache(cfg.DecisionCache),
+		id:                cfg.ID(),
+		storageID:         cfg.Storage,
+		maxTracesInMemory: cfg.MaxTracesInMemory,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -150,6 +167,9 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tcCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tcCfg.ResourceConditions, tcCfg.SpanConditions, tcCfg.MatchAllSpans)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -175,13 +195,29 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
+		if tsp.storage != nil {
+			if err := tsp.restoreSpilledBatches(id, trace); err != nil {
+				// Don't evaluate the policies on part of the spans, try again once the decision wait elapsed.
+				tsp.logger.Warn("Failed to read spans from storage, deferring the decision", zap.String("traceID", id.HexString()), zap.Error(err))
+				trace.DecisionTime = time.Time{}
+				tsp.decisionBatcher.AddToCurrentBatch(id)
+				continue
+			}
+		}
+
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
+		if tsp.decisionCache != nil {
+			tsp.decisionCache.put(id, decision, trace.DecisionTime)
+		}
 
 		// Sampled or not, remove the batches
 		trace.Lock()
 		traceBatches := trace.ReceivedBatches
 		trace.ReceivedBatches = nil
 		trace.Unlock()
+		if tsp.storage != nil && traceBatches != nil {
+			tsp.numTracesInMemory.Dec()
+		}
 
 		if decision == sampling.Sampled {
 
@@ -203,6 +239,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		statPolicyEvaluationErrorCount.M(metrics.evaluateErrorCount),
 		statTracesOnMemoryGauge.M(int64(tsp.numTracesOnMap.Load())))
 
+	if tsp.storage != nil {
+		if err := tsp.journalPendingTraces(tsp.ctx); err != nil {
+			tsp.logger.Warn("Failed to save pending traces to storage", zap.Error(err))
+		}
+	}
+
 	tsp.logger.Debug("Sampling policy evaluation completed",
 		zap.Int("batch.len", batchLen),
 		zap.Int64("sampled", metrics.decisionSampled),
@@ -325,16 +367,16 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
-		lenSpans := int64(len(spans))
-		lenPolicies := len(tsp.policies)
-		initialDecisions := make([]sampling.Decision, lenPolicies)
-		for i := 0; i < lenPolicies; i++ {
-			initialDecisions[i] = sampling.Pending
+		if tsp.decisionCache != nil && tsp.applyCachedDecision(resourceSpans, id, spans) {
+			continue
 		}
+
+		lenSpans := int64(len(spans))
 		initialTraceData := &sampling.TraceData{
-			Decisions:   initialDecisions,
+			Decisions:   tsp.initialDecisions(),
 			ArrivalTime: time.Now(),
 			SpanCount:   atomic.NewInt64(lenSpans),
+			Spilled:     tsp.storage != nil && tsp.numTracesInMemory.Load() >= tsp.maxTracesInMemory,
 		}
 		d, loaded := tsp.idToTrace.LoadOrStore(id, initialTraceData)
 
@@ -343,19 +385,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			actualData.SpanCount.Add(lenSpans)
 		} else {
 			newTraceIDs++
-			tsp.decisionBatcher.AddToCurrentBatch(id)
-			tsp.numTracesOnMap.Add(1)
-			postDeletion := false
-			currTime := time.Now()
-			for !postDeletion {
-				select {
-				case tsp.deleteChan <- id:
-					postDeletion = true
-				default:
-					traceKeyToDrop := <-tsp.deleteChan
-					tsp.dropTrace(traceKeyToDrop, currTime)
-				}
+			if tsp.storage != nil && !actualData.Spilled {
+				tsp.numTracesInMemory.Inc()
 			}
+			tsp.addNewTrace(id)
 		}
 
 		for i, p := range tsp.policies {
@@ -368,7 +401,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				// Add the spans to the trace, but only once for all policy, otherwise same spans will
 				// be duplicated in the final trace.
 				traceTd = prepareTraceBatch(resourceSpans, spans)
-				actualData.ReceivedBatches = append(actualData.ReceivedBatches, traceTd)
+				tsp.appendPendingBatch(id, actualData, traceTd)
 				actualData.Unlock()
 				break
 			}
@@ -403,21 +436,328 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// applyCachedDecision forwards or drops the spans of a trace that is no longer on memory according to
+// the decision cached for it. It returns false if the trace is on memory or its decision is not cached.
+func (tsp *tailSamplingSpanProcessor) applyCachedDecision(rss ptrace.ResourceSpans, id pcommon.TraceID, spans []*ptrace.Span) bool {
+	if _, ok := tsp.idToTrace.Load(id); ok {
+		return false
+	}
+
+	decision, decisionTime, ok := tsp.decisionCache.get(id)
+	if !ok {
+		stats.Record(tsp.ctx, statDecisionCacheMissCount.M(int64(len(spans))))
+		return false
+	}
+
+	switch decision {
+	case sampling.Sampled:
+		_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Insert(tagSampledKey, "true")}, statDecisionCacheHitCount.M(int64(len(spans))))
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, prepareTraceBatch(rss, spans)); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans to destination", zap.Error(err))
+		}
+	case sampling.NotSampled:
+		_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Insert(tagSampledKey, "false")}, statDecisionCacheHitCount.M(int64(len(spans))))
+		stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(decisionTime)/time.Second)))
+	}
+	return true
+}
+
+func (tsp *tailSamplingSpanProcessor) initialDecisions() []sampling.Decision {
+	decisions := make([]sampling.Decision, len(tsp.policies))
+	for i := range decisions {
+		decisions[i] = sampling.Pending
+	}
+	return decisions
+}
+
+// addNewTrace schedules the decision for a trace just added to idToTrace, dropping the
+// oldest trace if the maximum number of traces was reached.
+func (tsp *tailSamplingSpanProcessor) addNewTrace(id pcommon.TraceID) {
+	tsp.decisionBatcher.AddToCurrentBatch(id)
+	tsp.numTracesOnMap.Add(1)
+	postDeletion := false
+	currTime := time.Now()
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
+// appendPendingBatch adds a batch to a trace whose decision is pending. The batches of
+// spilled traces are written to storage. The caller must hold the lock of the trace.
+func (tsp *tailSamplingSpanProcessor) appendPendingBatch(id pcommon.TraceID, trace *sampling.TraceData, td ptrace.Traces) {
+	if trace.Spilled {
+		err := tsp.spillBatch(tsp.ctx, id, trace, td)
+		if err == nil {
+			return
+		}
+		tsp.logger.Warn("Failed to write spans to storage, keeping them in memory", zap.Error(err))
+	}
+	trace.ReceivedBatches = append(trace.ReceivedBatches, td)
+}
+
+// spillBatch writes a batch of a pending trace to storage. The trace is journaled before its
+// first batch is written, so that its batches are found after a crash. The caller must hold
+// the lock of the trace.
+func (tsp *tailSamplingSpanProcessor) spillBatch(ctx context.Context, id pcommon.TraceID, trace *sampling.TraceData, td ptrace.Traces) error {
+	if trace.SpilledBatches == 0 {
+		pt := pendingTrace{traceID: id, arrivalTime: trace.ArrivalTime, spanCount: trace.SpanCount.Load()}
+		if err := tsp.storage.appendJournal(ctx, []pendingTrace{pt}, nil); err != nil {
+			return err
+		}
+	}
+	if err := tsp.storage.appendBatch(ctx, id, trace.SpilledBatches, td); err != nil {
+		return err
+	}
+	trace.SpilledBatches++
+	tsp.markTraceChanged(id)
+	return nil
+}
+
+// markTraceChanged schedules journaling the state of the trace on the next tick.
+func (tsp *tailSamplingSpanProcessor) markTraceChanged(id pcommon.TraceID) {
+	tsp.changedTracesMu.Lock()
+	defer tsp.changedTracesMu.Unlock()
+	if tsp.changedTraces == nil {
+		tsp.changedTraces = make(map[pcommon.TraceID]struct{})
+	}
+	tsp.changedTraces[id] = struct{}{}
+}
+
+// takeChangedTraces returns the traces marked as changed and clears them.
+func (tsp *tailSamplingSpanProcessor) takeChangedTraces() map[pcommon.TraceID]struct{} {
+	tsp.changedTracesMu.Lock()
+	defer tsp.changedTracesMu.Unlock()
+	changed := tsp.changedTraces
+	tsp.changedTraces = nil
+	return changed
+}
+
+// restoreChangedTraces marks the traces as changed again after they failed to be saved.
+func (tsp *tailSamplingSpanProcessor) restoreChangedTraces(changed map[pcommon.TraceID]struct{}) {
+	for id := range changed {
+		tsp.markTraceChanged(id)
+	}
+}
+
+// restoreSpilledBatches moves the batches of a spilled trace from storage back into
+// memory, so that the policies can evaluate them. The trace is left spilled if its
+// batches can't be read.
+func (tsp *tailSamplingSpanProcessor) restoreSpilledBatches(id pcommon.TraceID, trace *sampling.TraceData) error {
+	trace.Lock()
+	defer trace.Unlock()
+	if !trace.Spilled {
+		return nil
+	}
+
+	batches, err := tsp.storage.loadBatches(tsp.ctx, id, trace.SpilledBatches)
+	if err != nil {
+		return err
+	}
+	if err = tsp.storage.deleteBatches(tsp.ctx, id, trace.SpilledBatches); err != nil {
+		tsp.logger.Warn("Failed to delete spans from storage", zap.String("traceID", id.HexString()), zap.Error(err))
+	}
+	trace.ReceivedBatches = append(batches, trace.ReceivedBatches...)
+	trace.Spilled = false
+	trace.SpilledBatches = 0
+	if trace.ReceivedBatches != nil {
+		tsp.numTracesInMemory.Inc()
+	}
+	tsp.markTraceChanged(id)
+	return nil
+}
+
+// journalPendingTraces appends the changes of the spilled traces since the last tick to the
+// journal, and rewrites the index of spilled traces once the journal grew large enough.
+func (tsp *tailSamplingSpanProcessor) journalPendingTraces(ctx context.Context) error {
+	changed := tsp.takeChangedTraces()
+	if len(changed) > 0 {
+		var updated []pendingTrace
+		var removed []pcommon.TraceID
+		for id := range changed {
+			if pt, ok := tsp.pendingTrace(id); ok {
+				updated = append(updated, pt)
+			} else {
+				removed = append(removed, id)
+			}
+		}
+		if err := tsp.storage.appendJournal(ctx, updated, removed); err != nil {
+			tsp.restoreChangedTraces(changed)
+			return err
+		}
+	}
+
+	if !tsp.storage.needsCompaction() {
+		return nil
+	}
+	return tsp.compactPendingTraces(ctx)
+}
+
+// pendingTrace returns the index entry of the trace, if it is spilled.
+func (tsp *tailSamplingSpanProcessor) pendingTrace(id pcommon.TraceID) (pendingTrace, bool) {
+	d, ok := tsp.idToTrace.Load(id)
+	if !ok {
+		return pendingTrace{}, false
+	}
+	trace := d.(*sampling.TraceData)
+	trace.Lock()
+	defer trace.Unlock()
+	if !trace.Spilled {
+		return pendingTrace{}, false
+	}
+	return pendingTrace{
+		traceID:     id,
+		arrivalTime: trace.ArrivalTime,
+		spanCount:   trace.SpanCount.Load(),
+		batches:     trace.SpilledBatches,
+	}, true
+}
+
+// compactPendingTraces rewrites the index of the spilled traces and removes the journal it covers.
+func (tsp *tailSamplingSpanProcessor) compactPendingTraces(ctx context.Context) error {
+	changed := tsp.takeChangedTraces()
+	// Changes journaled before this point are already in idToTrace, so the index covers them.
+	journalEnd := tsp.storage.rotateJournal()
+	var pending []pendingTrace
+	tsp.idToTrace.Range(func(key, value interface{}) bool {
+		trace := value.(*sampling.TraceData)
+		trace.Lock()
+		if trace.Spilled {
+			pending = append(pending, pendingTrace{
+				traceID:     key.(pcommon.TraceID),
+				arrivalTime: trace.ArrivalTime,
+				spanCount:   trace.SpanCount.Load(),
+				batches:     trace.SpilledBatches,
+			})
+		}
+		trace.Unlock()
+		return true
+	})
+
+	if err := tsp.storage.savePendingTraces(ctx, pending, journalEnd); err != nil {
+		tsp.restoreChangedTraces(changed)
+		return err
+	}
+	return nil
+}
+
+// reloadPendingTraces adds the traces left pending in storage by a previous run, scheduling
+// their decision as if they had just arrived. The batches left in storage by the traces removed
+// from the index are deleted.
+func (tsp *tailSamplingSpanProcessor) reloadPendingTraces(ctx context.Context) error {
+	pending, removed, err := tsp.storage.loadPendingTraces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range pending {
+		// Batches appended since the trace was last journaled are not counted in the index.
+		if pending[i].batches, err = tsp.storage.countBatches(ctx, pending[i].traceID, pending[i].batches); err != nil {
+			return err
+		}
+	}
+	leftovers := 0
+	for _, id := range removed {
+		count, err := tsp.storage.countBatches(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+		if err = tsp.storage.deleteBatches(ctx, id, count); err != nil {
+			return err
+		}
+		if count > 0 {
+			leftovers++
+		}
+	}
+	if leftovers > 0 {
+		tsp.logger.Info("Removed spans left in storage by traces no longer pending", zap.Int("traces", leftovers))
+	}
+
+	for _, pt := range pending {
+		trace := &sampling.TraceData{
+			Decisions:      tsp.initialDecisions(),
+			ArrivalTime:    pt.arrivalTime,
+			SpanCount:      atomic.NewInt64(pt.spanCount),
+			Spilled:        true,
+			SpilledBatches: pt.batches,
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(pt.traceID, trace); !loaded {
+			tsp.addNewTrace(pt.traceID)
+		}
+	}
+	if len(pending) > 0 {
+		tsp.logger.Info("Reloaded pending traces from storage", zap.Int("traces", len(pending)))
+	}
+
+	// Rewrite the index right away, so that the replayed journal is removed.
+	return tsp.compactPendingTraces(ctx)
+}
+
+// spillPendingTraces writes the batches of all pending traces kept in memory to storage.
+func (tsp *tailSamplingSpanProcessor) spillPendingTraces(ctx context.Context) error {
+	var errs error
+	tsp.idToTrace.Range(func(key, value interface{}) bool {
+		id := key.(pcommon.TraceID)
+		trace := value.(*sampling.TraceData)
+		trace.Lock()
+		defer trace.Unlock()
+		if trace.ReceivedBatches == nil || !trace.DecisionTime.IsZero() {
+			return true
+		}
+
+		for i, td := range trace.ReceivedBatches {
+			if err := tsp.spillBatch(ctx, id, trace, td); err != nil {
+				// Batches already written are kept in storage and reloaded with the trace.
+				trace.ReceivedBatches = trace.ReceivedBatches[i:]
+				trace.Spilled = trace.SpilledBatches > 0
+				errs = multierr.Append(errs, err)
+				return true
+			}
+		}
+		trace.ReceivedBatches = nil
+		trace.Spilled = true
+		return true
+	})
+	return errs
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		s, err := newTraceStorage(ctx, host, *tsp.storageID, tsp.id)
+		if err != nil {
+			return err
+		}
+		tsp.storage = s
+		if err = tsp.reloadPendingTraces(ctx); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	if tsp.storage == nil {
+		return nil
+	}
+
+	// Persist the traces still pending so that their decision is taken after a restart.
+	err := tsp.spillPendingTraces(ctx)
+	err = multierr.Append(err, tsp.compactPendingTraces(ctx))
+	return multierr.Append(err, tsp.storage.close(ctx))
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -425,6 +765,9 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
 		trace = d.(*sampling.TraceData)
 		tsp.idToTrace.Delete(traceID)
+		if tsp.storage != nil {
+			tsp.releaseDroppedTrace(traceID, trace)
+		}
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
 	}
@@ -436,6 +779,23 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	stats.Record(tsp.ctx, statTraceRemovalAgeSec.M(int64(deletionTime.Sub(trace.ArrivalTime)/time.Second)))
 }
 
+// releaseDroppedTrace removes the batches of a trace dropped before its decision from storage.
+func (tsp *tailSamplingSpanProcessor) releaseDroppedTrace(traceID pcommon.TraceID, trace *sampling.TraceData) {
+	trace.Lock()
+	defer trace.Unlock()
+	switch {
+	case trace.Spilled:
+		if err := tsp.storage.deleteBatches(tsp.ctx, traceID, trace.SpilledBatches); err != nil {
+			tsp.logger.Warn("Failed to delete spans from storage", zap.String("traceID", traceID.HexString()), zap.Error(err))
+		}
+		trace.Spilled = false
+		trace.SpilledBatches = 0
+		tsp.markTraceChanged(traceID)
+	case trace.ReceivedBatches != nil:
+		tsp.numTracesInMemory.Dec()
+	}
+}
+
 func prepareTraceBatch(rss ptrace.ResourceSpans, spans []*ptrace.Span) ptrace.Traces {
 	traceTd := ptrace.NewTraces()
 	rs := traceTd.ResourceSpans().AppendEmpty()
@@ -0,0 +1,388 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// pendingTracesKey is the storage key of the index of the traces pending a decision.
+	pendingTracesKey = "pending_traces"
+	// pendingTraceSize is the encoded size of a pendingTrace: trace ID, arrival time,
+	// span count and number of batches.
+	pendingTraceSize = 16 + 8 + 8 + 4
+	// journalStartKey is the storage key of the first segment of the journal of changes
+	// that is not covered by the index of pending traces.
+	journalStartKey = "pending_traces_journal_start"
+	// journalRecordSize is the encoded size of a journal record: its type and the pending trace.
+	journalRecordSize = 1 + pendingTraceSize
+	// journalRecordsPerSegment is the number of records kept in each segment of the journal.
+	journalRecordsPerSegment = 64
+	// minCompactionRecords is the number of records the journal holds at least before the
+	// index is rewritten.
+	minCompactionRecords = 1024
+)
+
+// Types of the journal records.
+const (
+	journalUpdate byte = iota + 1
+	journalRemove
+)
+
+var (
+	errNoStorageExtension   = errors.New("storage extension not found")
+	errWrongExtensionType   = errors.New("requested extension is not a storage extension")
+	errInvalidPendingTraces = errors.New("invalid pending traces index in storage")
+)
+
+// pendingTrace is an entry of the index of traces kept in storage while their decision is pending.
+type pendingTrace struct {
+	traceID     pcommon.TraceID
+	arrivalTime time.Time
+	spanCount   int64
+	batches     int
+}
+
+// traceStorage keeps the batches of pending traces in a storage extension client.
+//
+// Rewriting the whole index of pending traces on every change is too expensive, so the
+// changes are appended to a journal split into segments, which is replayed over the index
+// when loading it. The index is only rewritten once the journal grew as large as the index.
+type traceStorage struct {
+	client      storage.Client
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	// mu guards the journal.
+	mu sync.Mutex
+	// journalStart is the first segment of the journal not covered by the saved index.
+	journalStart int
+	// journalSegment is the segment the next record is appended to.
+	journalSegment int
+	// journal holds the records of the current segment.
+	journal []byte
+	// journalRecords counts the records appended since the index was saved.
+	journalRecords int
+	// indexedTraces is the number of traces in the saved index.
+	indexedTraces int
+}
+
+func newTraceStorage(ctx context.Context, host component.Host, storageID config.ComponentID, ownerID config.ComponentID) (*traceStorage, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, fmt.Errorf("%w: %v", errNoStorageExtension, storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errWrongExtensionType, storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, ownerID, "")
+	if err != nil {
+		return nil, err
+	}
+	return &traceStorage{
+		client:      client,
+		marshaler:   ptrace.NewProtoMarshaler(),
+		unmarshaler: ptrace.NewProtoUnmarshaler(),
+	}, nil
+}
+
+func batchKey(traceID pcommon.TraceID, index int) string {
+	return fmt.Sprintf("trace_%s_%d", traceID.HexString(), index)
+}
+
+func journalKey(segment int) string {
+	return fmt.Sprintf("pending_traces_journal_%d", segment)
+}
+
+func putPendingTrace(b []byte, pt pendingTrace) {
+	id := pt.traceID.Bytes()
+	copy(b, id[:])
+	binary.BigEndian.PutUint64(b[16:], uint64(pt.arrivalTime.UnixNano()))
+	binary.BigEndian.PutUint64(b[24:], uint64(pt.spanCount))
+	binary.BigEndian.PutUint32(b[32:], uint32(pt.batches))
+}
+
+func readPendingTrace(b []byte) pendingTrace {
+	var id [16]byte
+	copy(id[:], b)
+	return pendingTrace{
+		traceID:     pcommon.NewTraceID(id),
+		arrivalTime: time.Unix(0, int64(binary.BigEndian.Uint64(b[16:]))),
+		spanCount:   int64(binary.BigEndian.Uint64(b[24:])),
+		batches:     int(binary.BigEndian.Uint32(b[32:])),
+	}
+}
+
+// appendBatch stores td as the batch with the given index of the trace.
+func (s *traceStorage) appendBatch(ctx context.Context, traceID pcommon.TraceID, index int, td ptrace.Traces) error {
+	buf, err := s.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, batchKey(traceID, index), buf)
+}
+
+// appendJournal records the traces added to or updated in the index, and the traces removed
+// from it, in the journal.
+func (s *traceStorage) appendJournal(ctx context.Context, updated []pendingTrace, removed []pcommon.TraceID) error {
+	records := make([]byte, (len(updated)+len(removed))*journalRecordSize)
+	b := records
+	for _, pt := range updated {
+		b[0] = journalUpdate
+		putPendingTrace(b[1:], pt)
+		b = b[journalRecordSize:]
+	}
+	for _, id := range removed {
+		b[0] = journalRemove
+		putPendingTrace(b[1:], pendingTrace{traceID: id})
+		b = b[journalRecordSize:]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segment, journal := s.journalSegment, s.journal
+	var ops []storage.Operation
+	for b = records; len(b) > 0; {
+		n := journalRecordsPerSegment*journalRecordSize - len(journal)
+		if n > len(b) {
+			n = len(b)
+		}
+		// Don't share the backing array with s.journal, which is kept if the write fails.
+		journal = append(journal[:len(journal):len(journal)], b[:n]...)
+		b = b[n:]
+		ops = append(ops, storage.SetOperation(journalKey(segment), journal))
+		if len(journal) == journalRecordsPerSegment*journalRecordSize {
+			segment++
+			journal = nil
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	s.journalSegment, s.journal = segment, journal
+	s.journalRecords += len(updated) + len(removed)
+	return nil
+}
+
+// needsCompaction returns whether the journal grew large enough for the index to be rewritten.
+func (s *traceStorage) needsCompaction() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.journalRecords >= minCompactionRecords && s.journalRecords >= s.indexedTraces
+}
+
+// rotateJournal starts a new segment of the journal unless the current one is empty, and
+// returns the first segment that the changes recorded from now on are appended to.
+func (s *traceStorage) rotateJournal() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.journal) > 0 {
+		s.journalSegment++
+		s.journal = nil
+	}
+	return s.journalSegment
+}
+
+// countBatches returns the number of consecutive batches stored for the trace, assuming
+// that the first from batches are stored.
+func (s *traceStorage) countBatches(ctx context.Context, traceID pcommon.TraceID, from int) (int, error) {
+	for count := from; ; count++ {
+		buf, err := s.client.Get(ctx, batchKey(traceID, count))
+		if err != nil {
+			return 0, err
+		}
+		if buf == nil {
+			return count, nil
+		}
+	}
+}
+
+// loadBatches returns the first count batches stored for the trace. Batches missing from storage are skipped.
+func (s *traceStorage) loadBatches(ctx context.Context, traceID pcommon.TraceID, count int) ([]ptrace.Traces, error) {
+	ops := make([]storage.Operation, count)
+	for i := range ops {
+		ops[i] = storage.GetOperation(batchKey(traceID, i))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return nil, err
+	}
+
+	batches := make([]ptrace.Traces, 0, count)
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		td, err := s.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, td)
+	}
+	return batches, nil
+}
+
+// deleteBatches removes the first count batches stored for the trace.
+func (s *traceStorage) deleteBatches(ctx context.Context, traceID pcommon.TraceID, count int) error {
+	if count == 0 {
+		return nil
+	}
+	ops := make([]storage.Operation, count)
+	for i := range ops {
+		ops[i] = storage.DeleteOperation(batchKey(traceID, i))
+	}
+	return s.client.Batch(ctx, ops...)
+}
+
+// savePendingTraces replaces the index of pending traces, which covers the changes recorded in
+// the journal before journalEnd, and removes these segments of the journal.
+func (s *traceStorage) savePendingTraces(ctx context.Context, traces []pendingTrace, journalEnd int) error {
+	buf := make([]byte, len(traces)*pendingTraceSize)
+	for i, pt := range traces {
+		putPendingTrace(buf[i*pendingTraceSize:], pt)
+	}
+	start := make([]byte, 8)
+	binary.BigEndian.PutUint64(start, uint64(journalEnd))
+	err := s.client.Batch(ctx,
+		storage.SetOperation(pendingTracesKey, buf),
+		storage.SetOperation(journalStartKey, start))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexedTraces = len(traces)
+	s.journalRecords = 0
+	if journalEnd <= s.journalStart {
+		return nil
+	}
+	ops := make([]storage.Operation, 0, journalEnd-s.journalStart)
+	for segment := s.journalStart; segment < journalEnd; segment++ {
+		ops = append(ops, storage.DeleteOperation(journalKey(segment)))
+	}
+	if err = s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	s.journalStart = journalEnd
+	return nil
+}
+
+// loadPendingTraces returns the index of pending traces with the journal replayed over it, and
+// the traces removed from the index since it was saved. The batch counts may be lower than the
+// number of batches stored, since they are only journaled periodically.
+func (s *traceStorage) loadPendingTraces(ctx context.Context) ([]pendingTrace, []pcommon.TraceID, error) {
+	buf, err := s.client.Get(ctx, pendingTracesKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(buf)%pendingTraceSize != 0 {
+		return nil, nil, errInvalidPendingTraces
+	}
+	indexed := len(buf) / pendingTraceSize
+	traces := make([]pendingTrace, 0, indexed)
+	positions := make(map[pcommon.TraceID]int, indexed)
+	for b := buf; len(b) > 0; b = b[pendingTraceSize:] {
+		pt := readPendingTrace(b)
+		positions[pt.traceID] = len(traces)
+		traces = append(traces, pt)
+	}
+
+	buf, err = s.client.Get(ctx, journalStartKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	start := 0
+	if buf != nil {
+		if len(buf) != 8 {
+			return nil, nil, errInvalidPendingTraces
+		}
+		start = int(binary.BigEndian.Uint64(buf))
+	}
+
+	removed := make(map[pcommon.TraceID]struct{})
+	records := 0
+	segment := start
+	for ; ; segment++ {
+		buf, err = s.client.Get(ctx, journalKey(segment))
+		if err != nil {
+			return nil, nil, err
+		}
+		if buf == nil {
+			break
+		}
+		if len(buf)%journalRecordSize != 0 {
+			return nil, nil, errInvalidPendingTraces
+		}
+		for b := buf; len(b) > 0; b = b[journalRecordSize:] {
+			pt := readPendingTrace(b[1:])
+			switch b[0] {
+			case journalUpdate:
+				delete(removed, pt.traceID)
+				if i, ok := positions[pt.traceID]; ok {
+					traces[i] = pt
+				} else {
+					positions[pt.traceID] = len(traces)
+					traces = append(traces, pt)
+				}
+			case journalRemove:
+				delete(positions, pt.traceID)
+				removed[pt.traceID] = struct{}{}
+			default:
+				return nil, nil, errInvalidPendingTraces
+			}
+			records++
+		}
+	}
+
+	pending := make([]pendingTrace, 0, len(positions))
+	for i, pt := range traces {
+		if j, ok := positions[pt.traceID]; ok && i == j {
+			pending = append(pending, pt)
+		}
+	}
+	removedIDs := make([]pcommon.TraceID, 0, len(removed))
+	for id := range removed {
+		removedIDs = append(removedIDs, id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.journalStart = start
+	s.journalSegment = segment
+	s.journal = nil
+	s.journalRecords = records
+	s.indexedTraces = indexed
+	return pending, removedIDs, nil
+}
+
+func (s *traceStorage) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
@@ -0,0 +1,381 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+var testStorageID = config.NewComponentIDWithName("nop", "test")
+
+func newTestTraceStorage(t *testing.T, host component.Host) *traceStorage {
+	s, err := newTraceStorage(context.Background(), host, testStorageID, config.NewComponentID(typeStr))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.close(context.Background()))
+	})
+	return s
+}
+
+func TestTraceStorageBatches(t *testing.T) {
+	s := newTestTraceStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	ctx := context.Background()
+
+	traceIDs, batches := generateIdsAndBatches(2)
+	// batches 1 and 2 belong to the second trace
+	require.NoError(t, s.appendBatch(ctx, traceIDs[1], 0, batches[1]))
+	require.NoError(t, s.appendBatch(ctx, traceIDs[1], 1, batches[2]))
+
+	loaded, err := s.loadBatches(ctx, traceIDs[1], 2)
+	require.NoError(t, err)
+	assert.Equal(t, batches[1:], loaded)
+
+	loaded, err = s.loadBatches(ctx, traceIDs[0], 1)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	require.NoError(t, s.deleteBatches(ctx, traceIDs[1], 2))
+	loaded, err = s.loadBatches(ctx, traceIDs[1], 2)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestTraceStoragePendingTraces(t *testing.T) {
+	s := newTestTraceStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	ctx := context.Background()
+
+	pending, removed, err := s.loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.Empty(t, removed)
+
+	traceIDs, _ := generateIdsAndBatches(2)
+	expected := []pendingTrace{
+		{traceID: traceIDs[0], arrivalTime: time.Unix(0, 1660000000000000001), spanCount: 1, batches: 1},
+		{traceID: traceIDs[1], arrivalTime: time.Unix(0, 1660000000000000002), spanCount: 12, batches: 3},
+	}
+	require.NoError(t, s.savePendingTraces(ctx, expected, s.rotateJournal()))
+	pending, _, err = s.loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, pending)
+
+	require.NoError(t, s.client.Set(ctx, pendingTracesKey, []byte{1, 2, 3}))
+	_, _, err = s.loadPendingTraces(ctx)
+	assert.ErrorIs(t, err, errInvalidPendingTraces)
+}
+
+func TestTraceStorageJournal(t *testing.T) {
+	s := newTestTraceStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	ctx := context.Background()
+
+	traceIDs, _ := generateIdsAndBatches(journalRecordsPerSegment + 2)
+	traces := make([]pendingTrace, len(traceIDs))
+	for i, id := range traceIDs {
+		traces[i] = pendingTrace{traceID: id, arrivalTime: time.Unix(0, int64(i)), spanCount: int64(i)}
+	}
+	require.NoError(t, s.savePendingTraces(ctx, traces[:1], s.rotateJournal()))
+
+	// The records span more than one segment.
+	require.NoError(t, s.appendJournal(ctx, traces[1:], nil))
+	traces[2].batches = 3
+	require.NoError(t, s.appendJournal(ctx, traces[2:3], traceIDs[:2]))
+
+	pending, removed, err := s.loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, traces[2:], pending)
+	assert.ElementsMatch(t, traceIDs[:2], removed)
+
+	// A trace journaled again after its removal is pending.
+	require.NoError(t, s.appendJournal(ctx, traces[:1], nil))
+	pending, removed, err = s.loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, append(traces[2:], traces[0]), pending)
+	assert.Equal(t, traceIDs[1:2], removed)
+
+	// Saving the index removes the journal segments it covers.
+	require.NoError(t, s.savePendingTraces(ctx, traces[:1], s.rotateJournal()))
+	pending, removed, err = s.loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, traces[:1], pending)
+	assert.Empty(t, removed)
+	buf, err := s.client.Get(ctx, journalKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+}
+
+func TestTraceStorageNeedsCompaction(t *testing.T) {
+	s := newTestTraceStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	ctx := context.Background()
+
+	traceIDs, _ := generateIdsAndBatches(minCompactionRecords)
+	traces := make([]pendingTrace, len(traceIDs))
+	for i, id := range traceIDs {
+		traces[i] = pendingTrace{traceID: id}
+	}
+	require.NoError(t, s.appendJournal(ctx, traces[1:], nil))
+	assert.False(t, s.needsCompaction())
+	require.NoError(t, s.appendJournal(ctx, nil, traceIDs[:1]))
+	assert.True(t, s.needsCompaction())
+
+	// The journal has to grow as large as the index before it gets rewritten.
+	require.NoError(t, s.savePendingTraces(ctx, append(traces, traces...), s.rotateJournal()))
+	require.NoError(t, s.appendJournal(ctx, traces, nil))
+	assert.False(t, s.needsCompaction())
+	require.NoError(t, s.appendJournal(ctx, traces, nil))
+	assert.True(t, s.needsCompaction())
+}
+
+func TestTraceStorageMissingExtension(t *testing.T) {
+	_, err := newTraceStorage(context.Background(), componenttest.NewNopHost(), testStorageID, config.NewComponentID(typeStr))
+	assert.ErrorIs(t, err, errNoStorageExtension)
+}
+
+func newStorageTestProcessor(msp *consumertest.TracesSink, mpe *mockPolicyEvaluator, maxTracesInMemory uint64) *tailSamplingSpanProcessor {
+	const maxSize = 100
+	storageID := testStorageID
+	return &tailSamplingSpanProcessor{
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    atomic.NewUint64(0),
+		id:                config.NewComponentID(typeStr),
+		storageID:         &storageID,
+		maxTracesInMemory: maxTracesInMemory,
+	}
+}
+
+func TestSpillTracesToStorage(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(msp, mpe, 1)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIdsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	// Only the first trace fits in memory, the spans of the others are written to storage.
+	for i, id := range traceIDs {
+		d, ok := tsp.idToTrace.Load(id)
+		require.True(t, ok)
+		trace := d.(*sampling.TraceData)
+		if i == 0 {
+			assert.False(t, trace.Spilled)
+			assert.Len(t, trace.ReceivedBatches, 1)
+			continue
+		}
+		assert.True(t, trace.Spilled)
+		assert.Equal(t, i+1, trace.SpilledBatches)
+		assert.Empty(t, trace.ReceivedBatches)
+	}
+	assert.EqualValues(t, 1, tsp.numTracesInMemory.Load())
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	require.Equal(t, 3, mpe.EvaluationCount)
+	require.Len(t, msp.AllTraces(), 3)
+	for i, id := range traceIDs {
+		trace := findTrace(msp.AllTraces(), id)
+		require.NotNil(t, trace)
+		assert.EqualValues(t, i+1, trace.SpanCount())
+	}
+	assert.EqualValues(t, 0, tsp.numTracesInMemory.Load())
+
+	loaded, err := tsp.storage.loadBatches(context.Background(), traceIDs[2], 3)
+	require.NoError(t, err)
+	assert.Empty(t, loaded, "spans should be removed from storage once the decision was taken")
+}
+
+func TestReloadPendingTracesFromStorage(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	traceIDs, batches := generateIdsAndBatches(2)
+
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(msp, mpe, 1)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, 0, mpe.EvaluationCount)
+
+	restarted := newStorageTestProcessor(msp, mpe, 1)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	assert.EqualValues(t, 2, restarted.numTracesOnMap.Load())
+
+	restarted.samplingPolicyOnTick()
+	restarted.samplingPolicyOnTick()
+
+	require.Equal(t, 2, mpe.EvaluationCount)
+	for i, id := range traceIDs {
+		trace := findTrace(msp.AllTraces(), id)
+		require.NotNil(t, trace, "pending trace should be reloaded after restart")
+		assert.EqualValues(t, i+1, trace.SpanCount())
+	}
+}
+
+func TestReloadJournaledTraces(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	ctx := context.Background()
+	traceIDs, batches := generateIdsAndBatches(3)
+
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	// All the traces are spilled.
+	tsp := newStorageTestProcessor(msp, mpe, 0)
+	require.NoError(t, tsp.Start(ctx, host))
+	for _, batch := range batches[:3] {
+		require.NoError(t, tsp.ConsumeTraces(ctx, batch))
+	}
+	index, err := tsp.storage.client.Get(ctx, pendingTracesKey)
+	require.NoError(t, err)
+
+	// The first tick journals the spilled traces and the second one their removal once decided,
+	// without rewriting the index.
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+	buf, err := tsp.storage.client.Get(ctx, pendingTracesKey)
+	require.NoError(t, err)
+	assert.Equal(t, index, buf)
+
+	// Simulate a crash: the batches of the decided trace couldn't be deleted, and the last
+	// batch of the pending trace was stored after it was journaled.
+	require.NoError(t, tsp.storage.appendBatch(ctx, traceIDs[1], 0, batches[1]))
+	for _, batch := range batches[3:] {
+		require.NoError(t, tsp.ConsumeTraces(ctx, batch))
+	}
+	require.NoError(t, tsp.storage.appendBatch(ctx, traceIDs[2], 3, batches[5]))
+	tsp.policyTicker.Stop()
+	require.NoError(t, tsp.storage.close(ctx))
+
+	restarted := newStorageTestProcessor(msp, mpe, 0)
+	require.NoError(t, restarted.Start(ctx, host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(ctx))
+	}()
+	assert.EqualValues(t, 1, restarted.numTracesOnMap.Load())
+
+	// The third trace is found in the journal, along with all its batches.
+	d, ok := restarted.idToTrace.Load(traceIDs[2])
+	require.True(t, ok)
+	assert.Equal(t, 4, d.(*sampling.TraceData).SpilledBatches)
+
+	// The second trace was removed from the index, so its batches are deleted.
+	count, err := restarted.storage.countBatches(ctx, traceIDs[1], 0)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	buf, err = restarted.storage.client.Get(ctx, journalKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, buf, "the journal should be removed once the index is rewritten")
+}
+
+// failingReadClient is a storage client whose reads fail while fail is set.
+type failingReadClient struct {
+	storage.Client
+	fail bool
+}
+
+var errRead = errors.New("read failed")
+
+func (c *failingReadClient) Get(ctx context.Context, key string) ([]byte, error) {
+	if c.fail {
+		return nil, errRead
+	}
+	return c.Client.Get(ctx, key)
+}
+
+func (c *failingReadClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		if c.fail && op.Type == storage.Get {
+			return errRead
+		}
+	}
+	return c.Client.Batch(ctx, ops...)
+}
+
+func TestDeferDecisionWhenStorageReadFails(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(msp, mpe, 1)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	client := &failingReadClient{Client: tsp.storage.client, fail: true}
+	tsp.storage.client = client
+
+	traceIDs, batches := generateIdsAndBatches(2)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	// The first trace is in memory, the decision of the spilled one is deferred.
+	require.Equal(t, 1, mpe.EvaluationCount)
+	require.Len(t, msp.AllTraces(), 1)
+	d, ok := tsp.idToTrace.Load(traceIDs[1])
+	require.True(t, ok)
+	trace := d.(*sampling.TraceData)
+	assert.True(t, trace.Spilled)
+	assert.Equal(t, 2, trace.SpilledBatches)
+	assert.True(t, trace.DecisionTime.IsZero())
+
+	client.fail = false
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	require.Equal(t, 2, mpe.EvaluationCount)
+	sampled := findTrace(msp.AllTraces(), traceIDs[1])
+	require.NotNil(t, sampled)
+	assert.EqualValues(t, 2, sampled.SpanCount(), "all the spans must be evaluated once they can be read")
+}
@@ -33,6 +33,17 @@ var registry = map[string]interface{}{
 	"replace_all_patterns": tqlotel.ReplaceAllPatterns,
 	"delete_key":           tqlotel.DeleteKey,
 	"delete_matching_keys": tqlotel.DeleteMatchingKeys,
+	"Concat":               tqlcommon.Concat,
+	"Split":                tqlcommon.Split,
+	"Substring":            tqlcommon.Substring,
+	"Int":                  tqlcommon.Int,
+	"Double":               tqlcommon.Double,
+	"String":               tqlcommon.String,
+	"ParseJSON":            tqlcommon.ParseJSON,
+	"ConvertCase":          tqlcommon.ConvertCase,
+	"SHA256":               tqlcommon.SHA256,
+	"FNV":                  tqlcommon.FNV,
+	"UUID":                 tqlcommon.UUID,
 }
 
 func DefaultFunctions() map[string]interface{} {
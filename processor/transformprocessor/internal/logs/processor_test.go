@@ -139,6 +139,82 @@ func TestProcess(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("http.url", "http://localhost/health")
 			},
 		},
+		{
+			query: `set(attributes["test"], Concat(" ", attributes["http.method"], attributes["http.path"])) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "get /health")
+			},
+		},
+		{
+			query: `set(attributes["test"], Split(attributes["http.url"], "/")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				v := pcommon.NewValueSlice()
+				v.SliceVal().AppendEmpty().SetStringVal("http:")
+				v.SliceVal().AppendEmpty().SetStringVal("")
+				v.SliceVal().AppendEmpty().SetStringVal("localhost")
+				v.SliceVal().AppendEmpty().SetStringVal("health")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("test", v)
+			},
+		},
+		{
+			query: `set(attributes["test"], Substring(attributes["http.url"], 7, 9)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "localhost")
+			},
+		},
+		{
+			query: `set(attributes["test"], ConvertCase(body, "snake")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "operation_a")
+			},
+		},
+		{
+			query: `set(attributes["test"], String(severity_number)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "1")
+			},
+		},
+		{
+			query: `set(attributes["test"], Double(severity_number)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertDouble("test", 1)
+			},
+		},
+		{
+			query: `set(severity_number, Int("9")) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).SetSeverityNumber(9)
+			},
+		},
+		{
+			query: `set(attributes["test"], ParseJSON("{\"id\":1,\"user\":{\"name\":\"alice\"}}")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				v := pcommon.NewValueMap()
+				v.MapVal().InsertInt("id", 1)
+				user := pcommon.NewValueMap()
+				user.MapVal().InsertString("name", "alice")
+				v.MapVal().Insert("user", user)
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("test", v)
+			},
+		},
+		{
+			query: `set(attributes["http"]["request"]["method"], attributes["http.method"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				request := pcommon.NewValueMap()
+				request.MapVal().InsertString("method", "get")
+				http := pcommon.NewValueMap()
+				http.MapVal().Insert("request", request)
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Insert("http", http)
+			},
+		},
+		{
+			query: `set(body, ParseJSON("{\"id\":1}")) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				v := pcommon.NewValueMap()
+				v.MapVal().InsertInt("id", 1)
+				v.CopyTo(td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,6 +234,39 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcess_nestedBody(t *testing.T) {
+	td := constructLogs()
+	processor, err := NewProcessor([]string{
+		`set(body, ParseJSON("{\"items\":[{\"name\":\"first\"}]}")) where body == "operationA"`,
+		`set(attributes["name"], body["items"][0]["name"])`,
+		`set(body["items"][0]["name"], "updated")`,
+		`set(body["status"]["code"], 200)`,
+	}, DefaultFunctions(), component.ProcessorCreateSettings{})
+	assert.NoError(t, err)
+
+	_, err = processor.ProcessLogs(context.Background(), td)
+	assert.NoError(t, err)
+
+	log := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"name": "updated",
+			},
+		},
+		"status": map[string]interface{}{
+			"code": int64(200),
+		},
+	}, log.Body().MapVal().AsRaw())
+	name, _ := log.Attributes().Get("name")
+	assert.Equal(t, "first", name.StringVal())
+
+	unchanged := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, "operationB", unchanged.Body().StringVal())
+	_, ok := unchanged.Attributes().Get("name")
+	assert.False(t, ok)
+}
+
 func constructLogs() plog.Logs {
 	td := plog.NewLogs()
 	rs0 := td.ResourceLogs().AppendEmpty()
@@ -266,6 +266,29 @@ func TestProcess(t *testing.T) {
 				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().InsertString("attr1", "test1")
 			},
 		},
+		{
+			query: []string{`set(metric.name, ConvertCase(Concat(".", metric.name, attributes["attr1"]), "snake")) where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("operation_a_test1")
+			},
+		},
+		{
+			query: []string{`set(attributes["test"], Split(metric.description, " ")) where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				v := pcommon.NewValueSlice()
+				v.SliceVal().AppendEmpty().SetStringVal("operationA")
+				v.SliceVal().AppendEmpty().SetStringVal("description")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Insert("test", v)
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().Insert("test", v)
+			},
+		},
+		{
+			query: []string{`set(attributes["test"], String(value_double)) where metric.name == "operationA"`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().InsertString("test", "1")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().InsertString("test", "0")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -131,6 +131,19 @@ func TestProcess(t *testing.T) {
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertString("test", "pass")
 			},
 		},
+		{
+			query: `set(attributes["duration_ms"], (end_time_unix_nano - start_time_unix_nano) / 1000000)`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertInt("duration_ms", 1000)
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().InsertInt("duration_ms", 1000)
+			},
+		},
+		{
+			query: `set(attributes["test"], "GET " + name) where end_time_unix_nano - start_time_unix_nano == 1000000468 and name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertString("test", "GET operationA")
+			},
+		},
 		{
 			query: `set(attributes["test"], "pass") where attributes["doesnt exist"] == nil`,
 			want: func(td ptrace.Traces) {
@@ -165,6 +178,24 @@ func TestProcess(t *testing.T) {
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetKind(2)
 			},
 		},
+		{
+			query: `set(attributes["test"], SHA256(attributes["http.url"])) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertString("test", "91234995d8917e46a4e0cd6ce5c38981e1ea5b09debe83616342c2097f27726f")
+			},
+		},
+		{
+			query: `set(attributes["test"], FNV(name)) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertInt("test", -8367648336983144923)
+			},
+		},
+		{
+			query: `set(name, Concat(" ", attributes["http.method"], name, ConvertCase(status.message, "upper"))) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("get operationA STATUS-CANCELLED")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -184,6 +215,23 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcess_UUID(t *testing.T) {
+	td := constructTraces()
+	processor, err := NewProcessor([]string{`set(attributes["id"], UUID())`}, DefaultFunctions(), component.ProcessorCreateSettings{})
+	assert.NoError(t, err)
+
+	_, err = processor.ProcessTraces(context.Background(), td)
+	assert.NoError(t, err)
+
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	first, ok := spans.At(0).Attributes().Get("id")
+	assert.True(t, ok)
+	second, ok := spans.At(1).Attributes().Get("id")
+	assert.True(t, ok)
+	assert.Len(t, first.StringVal(), 36)
+	assert.NotEqual(t, first.StringVal(), second.StringVal())
+}
+
 func BenchmarkTwoSpans(b *testing.B) {
 	tests := []struct {
 		name    string